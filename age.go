@@ -0,0 +1,112 @@
+package tableformatter
+
+import (
+	"fmt"
+	"time"
+)
+
+//AddAgeColumn adds a TypeDuration column named newFieldName right after the TypeDateTime column sourceField,
+//holding the time elapsed since the date in the source cell. The column is rendered in a compact form
+//such as 5d or 3h12m and sorts by the underlying duration. Cells that cannot be parsed are nil.
+//If clock is nil time.Now is used.
+func (t *Table) AddAgeColumn(sourceField string, newFieldName string, clock func() time.Time) error {
+	index := -1
+	for i, field := range t.Schema {
+		if field.FieldName == sourceField {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return fmt.Errorf("could not find field with name %s", sourceField)
+	}
+
+	if t.Schema[index].FieldType != TypeDateTime {
+		return fmt.Errorf("field %s is not a date time field", sourceField)
+	}
+
+	if clock == nil {
+		clock = time.Now
+	}
+	now := clock()
+	sourceSchema := t.getResolvedSchema()
+
+	field := SchemaField{
+		FieldName:   newFieldName,
+		FieldType:   TypeDuration,
+		FieldFormat: DurationFormatAge,
+	}
+
+	schema := make([]SchemaField, 0, len(t.Schema)+1)
+	schema = append(schema, t.Schema[:index+1]...)
+	schema = append(schema, field)
+	t.Schema = append(schema, t.Schema[index+1:]...)
+
+	for k, row := range t.Data {
+		if isRawRow(row) {
+			continue
+		}
+
+		var age interface{}
+		if tm, ok := parseDateTimeCell(row[index], &sourceSchema[index]); ok {
+			age = now.Sub(tm)
+		}
+
+		newRow := make([]interface{}, 0, len(row)+1)
+		newRow = append(newRow, row[:index+1]...)
+		newRow = append(newRow, age)
+		t.Data[k] = append(newRow, row[index+1:]...)
+	}
+
+	return nil
+}
+
+//formatAge formats a duration with at most two units, the way kubectl shows the age of resources
+func formatAge(d time.Duration) string {
+	if d < -time.Second {
+		return "<invalid>"
+	}
+	if d < 0 {
+		return "0s"
+	}
+
+	seconds := int(d.Seconds())
+	minutes := int(d.Minutes())
+	hours := int(d.Hours())
+	days := hours / 24
+	years := days / 365
+
+	switch {
+	case seconds < 60*2:
+		return fmt.Sprintf("%ds", seconds)
+	case minutes < 10:
+		if seconds%60 == 0 {
+			return fmt.Sprintf("%dm", minutes)
+		}
+		return fmt.Sprintf("%dm%ds", minutes, seconds%60)
+	case minutes < 60*3:
+		return fmt.Sprintf("%dm", minutes)
+	case hours < 8:
+		if minutes%60 == 0 {
+			return fmt.Sprintf("%dh", hours)
+		}
+		return fmt.Sprintf("%dh%dm", hours, minutes%60)
+	case hours < 48:
+		return fmt.Sprintf("%dh", hours)
+	case hours < 24*8:
+		if hours%24 == 0 {
+			return fmt.Sprintf("%dd", days)
+		}
+		return fmt.Sprintf("%dd%dh", days, hours%24)
+	case days < 365*2:
+		return fmt.Sprintf("%dd", days)
+	case years < 8:
+		if days%365 == 0 {
+			return fmt.Sprintf("%dy", years)
+		}
+		return fmt.Sprintf("%dy%dd", years, days%365)
+	default:
+		return fmt.Sprintf("%dy", years)
+	}
+}
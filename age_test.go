@@ -0,0 +1,77 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFormatAge(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(formatAge(-time.Minute)).To(Equal("<invalid>"))
+	Expect(formatAge(-time.Millisecond)).To(Equal("0s"))
+	Expect(formatAge(45 * time.Second)).To(Equal("45s"))
+	Expect(formatAge(5*time.Minute + 3*time.Second)).To(Equal("5m3s"))
+	Expect(formatAge(5 * time.Minute)).To(Equal("5m"))
+	Expect(formatAge(95 * time.Minute)).To(Equal("95m"))
+	Expect(formatAge(3*time.Hour + 12*time.Minute)).To(Equal("3h12m"))
+	Expect(formatAge(30 * time.Hour)).To(Equal("30h"))
+	Expect(formatAge(5 * 24 * time.Hour)).To(Equal("5d"))
+	Expect(formatAge(5*24*time.Hour + 3*time.Hour)).To(Equal("5d3h"))
+	Expect(formatAge(400 * 24 * time.Hour)).To(Equal("400d"))
+	Expect(formatAge(3 * 365 * 24 * time.Hour)).To(Equal("3y"))
+	Expect(formatAge(10 * 365 * 24 * time.Hour)).To(Equal("10y"))
+}
+
+func TestAddAgeColumn(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "2020-08-01T10:00:00Z", "test"},
+		{2, time.Date(2020, 8, 4, 6, 48, 0, 0, time.UTC), "test2"},
+		{3, "not a date", "test3"},
+	}
+
+	clock := func() time.Time {
+		return time.Date(2020, 8, 4, 10, 0, 0, 0, time.UTC)
+	}
+
+	table := Table{Data: data, Schema: schema}
+	err := table.AddAgeColumn("CREATED", "AGE", clock)
+	Expect(err).To(BeNil())
+
+	Expect(table.Schema[2].FieldName).To(Equal("AGE"))
+	Expect(table.Schema[3].FieldName).To(Equal("LABEL"))
+	Expect(table.Data[0][2]).To(Equal(72 * time.Hour))
+	Expect(table.Data[0][3]).To(Equal("test"))
+	Expect(table.Data[2][2]).To(BeNil())
+
+	s, err := table.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 3d  "))
+	Expect(s).To(ContainSubstring("| 3h12m "))
+
+	err = TableSorter(table.Schema).OrderBy("AGE").SortErr(table.Data[:2])
+	Expect(err).To(BeNil())
+	Expect(table.Data[0][0]).To(Equal(2))
+
+	Expect(table.AddAgeColumn("LABEL", "AGE2", clock)).NotTo(BeNil())
+	Expect(table.AddAgeColumn("UNKNOWN", "AGE2", clock)).NotTo(BeNil())
+}
@@ -0,0 +1,122 @@
+package tableformatter
+
+import (
+	"strconv"
+)
+
+//Aggregate is the value computed over the cells of a field shown in the footer row of the text format
+type Aggregate int
+
+const (
+	//AggregateNone leaves the cell of the field in the footer row empty, it is the default
+	AggregateNone Aggregate = iota
+	//AggregateSum is the sum of the cells
+	AggregateSum
+	//AggregateAvg is the average of the cells. In int fields it is shown with the FieldPrecision of the field
+	//or 2 decimals if it has none.
+	AggregateAvg
+	//AggregateMin is the smallest cell
+	AggregateMin
+	//AggregateMax is the largest cell
+	AggregateMax
+	//AggregateCount is the number of cells
+	AggregateCount
+)
+
+//defaultAggregatePrecision is the number of decimals of the averages of the fields without a FieldPrecision
+const defaultAggregatePrecision = 2
+
+//hasAggregates returns true if any of the fields of the schema has a FieldAggregate
+func hasAggregates(schema []SchemaField) bool {
+	for _, field := range schema {
+		if field.FieldAggregate != AggregateNone {
+			return true
+		}
+	}
+	return false
+}
+
+//getAggregateRow returns the footer row with the FieldAggregate of each field computed over data, nil if no field
+//has one or data has no rows. Only the int and float cells of TypeInt and TypeFloat fields are aggregated,
+//the other cells of the row are nil.
+func getAggregateRow(data [][]interface{}, schema []SchemaField) []interface{} {
+	data = withoutRawRows(data)
+	if len(data) == 0 || !hasAggregates(schema) {
+		return nil
+	}
+
+	row := make([]interface{}, len(schema))
+	for i := range schema {
+		row[i] = getAggregateCell(data, i, &schema[i])
+	}
+	return row
+}
+
+//getAggregateCell returns the FieldAggregate of the field computed over the cells at index in data,
+//nil if there are none. Averages of int fields are returned as a Cell.
+func getAggregateCell(data [][]interface{}, index int, field *SchemaField) interface{} {
+	if field.FieldType != TypeInt && field.FieldType != TypeFloat {
+		return nil
+	}
+
+	var values []float64
+	for _, row := range data {
+		if index >= len(row) {
+			continue
+		}
+		switch v := row[index].(type) {
+		case int:
+			values = append(values, float64(v))
+		case float64:
+			values = append(values, v)
+		}
+	}
+
+	if field.FieldAggregate == AggregateCount {
+		return len(values)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	var value float64
+	switch field.FieldAggregate {
+	case AggregateSum:
+		for _, v := range values {
+			value += v
+		}
+	case AggregateAvg:
+		for _, v := range values {
+			value += v
+		}
+		value /= float64(len(values))
+		if field.FieldType == TypeInt {
+			precision := field.FieldPrecision
+			if precision == 0 {
+				precision = defaultAggregatePrecision
+			}
+			return Cell{Value: value, AsString: strconv.FormatFloat(value, 'f', precision, 64)}
+		}
+	case AggregateMin:
+		value = values[0]
+		for _, v := range values {
+			if v < value {
+				value = v
+			}
+		}
+	case AggregateMax:
+		value = values[0]
+		for _, v := range values {
+			if v > value {
+				value = v
+			}
+		}
+	default:
+		return nil
+	}
+
+	if field.FieldType == TypeInt {
+		return int(value)
+	}
+	return value
+}
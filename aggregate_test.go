@@ -0,0 +1,163 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAggregateText(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "INSTANCES",
+			FieldType:      TypeInt,
+			FieldAggregate: AggregateSum,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+			FieldAggregate: AggregateAvg,
+		},
+		{
+			FieldName:      "CPU",
+			FieldType:      TypeInt,
+			FieldAggregate: AggregateAvg,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web", 2, 10.5, 4},
+		NewRawRow("--- raw ---"),
+		{"db", 1, nil, 8},
+		{"cache", 10, 3.25, 1},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("clusters", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+-------+-----------+-------+------+\n" +
+			"| LABEL | INSTANCES | COST  | CPU  |\n" +
+			"+-------+-----------+-------+------+\n" +
+			"| web   | 2         | 10.50 | 4    |\n" +
+			"--- raw ---\n" +
+			"| db    | 1         |       | 8    |\n" +
+			"| cache | 10        | 3.25  | 1    |\n" +
+			"+-------+-----------+-------+------+\n" +
+			"|       | 13        | 6.88  | 4.33 |\n" +
+			"+-------+-----------+-------+------+\n" +
+			"Total: 3 clusters\n\n"))
+
+	//no footer without rows
+	table.Data = nil
+	s, err = table.RenderTable("clusters", "", "")
+	Expect(err).To(BeNil())
+	Expect(strings.Count(s, "+\n")).To(Equal(3))
+}
+
+func TestGetAggregateRow(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:      "MIN",
+			FieldType:      TypeInt,
+			FieldAggregate: AggregateMin,
+		},
+		{
+			FieldName:      "MAX",
+			FieldType:      TypeFloat,
+			FieldAggregate: AggregateMax,
+		},
+		{
+			FieldName:      "COUNT",
+			FieldType:      TypeFloat,
+			FieldAggregate: AggregateCount,
+		},
+		{
+			FieldName:      "LABEL",
+			FieldType:      TypeString,
+			FieldAggregate: AggregateSum,
+		},
+		{
+			FieldName:      "NILS",
+			FieldType:      TypeInt,
+			FieldAggregate: AggregateSum,
+		},
+		{
+			FieldName: "NONE",
+			FieldType: TypeInt,
+		},
+	}
+
+	data := [][]interface{}{
+		{3, 1.5, 1.0, "a", nil, 1},
+		{-2, 7.25, nil, "b", nil, 2},
+		{5, "masked", 2.0, "c", nil, 3},
+	}
+
+	Expect(getAggregateRow(data, schema)).To(Equal([]interface{}{-2, 7.25, 2, nil, nil, nil}))
+	Expect(getAggregateRow(nil, schema)).To(BeNil())
+	Expect(getAggregateRow(data, schema[5:])).To(BeNil())
+}
+
+func TestAggregateRow(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "INSTANCES",
+			FieldType:      TypeInt,
+			FieldAggregate: AggregateSum,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+			FieldAggregate: AggregateAvg,
+		},
+		{
+			FieldName:      "CPU",
+			FieldType:      TypeInt,
+			FieldAggregate: AggregateAvg,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web", 2, 10.5, 4},
+		NewRawRow("--- raw ---"),
+		{"db", 1, nil, 8},
+		{"cache", 10, 3.25, 1},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	//the other formats ignore the aggregates unless asked for them
+	s, err := table.RenderTable("clusters", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(strings.Split(strings.TrimSpace(s), "\n")).To(HaveLen(4))
+
+	s, err = table.RenderTable("clusters", "", "csv", WithAggregateRow())
+	Expect(err).To(BeNil())
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	Expect(lines).To(HaveLen(5))
+	Expect(lines[4]).To(Equal(",13,6.875000,4.333333333333333"))
+
+	s, err = table.RenderTable("clusters", "", "json", WithAggregateRow())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"INSTANCES": 13`))
+	Expect(s).To(ContainSubstring(`"COST": 6.875`))
+}
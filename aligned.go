@@ -0,0 +1,68 @@
+package tableformatter
+
+import "strings"
+
+//alignedSeparator separates the columns of the aligned format
+const alignedSeparator = "  "
+
+//getTableAsAlignedString returns the table as columns separated by two spaces with the header underlined
+//with dashes, without borders. Colors are always stripped as chat clients show the escape sequences.
+func getTableAsAlignedString(data [][]interface{}, schema []SchemaField) string {
+	//the normalized schema leaves a space to the right of the widest cell, which the separator replaces
+	schema = getNormalizedSchema(data, schema)
+
+	header := []interface{}{}
+	underline := []interface{}{}
+	headerSchema := []SchemaField{}
+	for _, field := range schema {
+		header = append(header, field.FieldName)
+		underline = append(underline, strings.Repeat("-", field.FieldSize-1))
+		headerSchema = append(headerSchema, SchemaField{
+			FieldType:      TypeString,
+			FieldSize:      field.FieldSize,
+			FieldAlignment: field.FieldAlignment,
+		})
+	}
+
+	var sb strings.Builder
+	sb.WriteString(getAlignedRow(header, headerSchema))
+	sb.WriteString(getAlignedRow(underline, headerSchema))
+	for _, row := range data {
+		if isRawRow(row) {
+			sb.WriteString(strings.TrimRight(decolorize(string(row[0].(RawRow))), " ") + "\n")
+			continue
+		}
+		sb.WriteString(getAlignedRow(row, schema))
+	}
+
+	return sb.String()
+}
+
+//getAlignedRow returns the lines of a row in the aligned format, multi-line cells are laid out like in the text format
+func getAlignedRow(row []interface{}, schema []SchemaField) string {
+	var cells [][]string
+	rowHeight := 1
+	for i, field := range schema {
+		lines := getWrappedCellLines(row[i], &field)
+		if rowHeight < len(lines) {
+			rowHeight = len(lines)
+		}
+		cells = append(cells, lines)
+	}
+
+	var sb strings.Builder
+	for y := 0; y < rowHeight; y++ {
+		var line []string
+		for x, cell := range cells {
+			s := ""
+			if y < len(cell) {
+				s = decolorize(cell[y])
+			}
+			line = append(line, align(s, schema[x].FieldSize-1, schema[x].FieldAlignment))
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(line, alignedSeparator), " "))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
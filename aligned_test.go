@@ -0,0 +1,48 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableAligned(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "switch-1\nrack 2", _red + "down" + _reset},
+		{100, "sw", "up"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	expected := "" +
+		"ID   LABEL     STATUS\n" +
+		"---  --------  ------\n" +
+		"1    switch-1  down\n" +
+		"     rack 2\n" +
+		"100  sw        up\n"
+
+	s, err := table.RenderTable("switches", "top line", "aligned")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	s, err = table.RenderTable("switches", "top line", "slack")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+}
@@ -0,0 +1,39 @@
+package tableformatter
+
+//Alignment is the horizontal position of the cells of a field in their column
+type Alignment int
+
+const (
+	//AlignLeft pads the cells on the right, it is the default
+	AlignLeft Alignment = iota
+	//AlignRight pads the cells on the left
+	AlignRight
+	//AlignCenter splits the padding evenly on both sides of the cells, the extra space going to the right
+	AlignCenter
+)
+
+//markdownAlignments are the delimiter row cells of the markdown format for each alignment
+var markdownAlignments = map[Alignment]string{
+	AlignLeft:   "---",
+	AlignRight:  "---:",
+	AlignCenter: ":---:",
+}
+
+//align pads a line of a cell with spaces to width visible characters as set by the alignment.
+//Lines at least width wide are returned as is.
+func align(s string, width int, alignment Alignment) string {
+	s = stripUnterminatedSequence(s)
+	padding := width - VisibleWidth(s)
+	if padding <= 0 {
+		return s
+	}
+
+	switch alignment {
+	case AlignRight:
+		return emptyString(padding) + s
+	case AlignCenter:
+		return emptyString(padding/2) + s + emptyString(padding-padding/2)
+	default:
+		return s + emptyString(padding)
+	}
+}
@@ -0,0 +1,75 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAlign(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(align("ab", 6, AlignLeft)).To(Equal("ab    "))
+	Expect(align("ab", 6, AlignRight)).To(Equal("    ab"))
+	Expect(align("ab", 6, AlignCenter)).To(Equal("  ab  "))
+	Expect(align("ab", 5, AlignCenter)).To(Equal(" ab  "))
+	Expect(align("abcdef", 4, AlignRight)).To(Equal("abcdef"))
+
+	//colors do not count in the width
+	Expect(align("\x1b[31mab\x1b[0m", 4, AlignRight)).To(Equal("  \x1b[31mab\x1b[0m"))
+}
+
+func TestRenderTableAlignment(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:      "NAME",
+			FieldType:      TypeString,
+			FieldAlignment: AlignCenter,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+			FieldAlignment: AlignRight,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web\nload balancer", 1200.5},
+		{"db", "\x1b[31m3.00\x1b[0m"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(decolorize(s)).To(Equal(
+		`+---------------+---------+
+|     NAME      |    COST |
++---------------+---------+
+|      web      | 1200.50 |
+| load balancer |         |
+|      db       |    3.00 |
++---------------+---------+
+Total: 2 servers
+
+`))
+
+	//colored cells are aligned on their visible width
+	Expect(s).To(ContainSubstring("|    \x1b[31m3.00\x1b[0m |"))
+
+	s, err = table.RenderTable("servers", "", "aligned")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		`    NAME          COST
+-------------  -------
+     web       1200.50
+load balancer
+     db           3.00
+`))
+
+	s, err = table.RenderTable("servers", "", "md")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| :---: | ---: |\n"))
+}
@@ -0,0 +1,162 @@
+package tableformatter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+)
+
+//AnonymizeFunc returns the value that replaces a cell when anonymizing a table, or an error that stops
+//the anonymization. It is not called for nil cells.
+type AnonymizeFunc func(value interface{}) interface{}
+
+//Anonymize replaces in place the cells of the columns named in rules, by FieldID or FieldName, with the values
+//returned by the corresponding AnonymizeFunc. Rules are applied row by row, in order, so that stateful functions
+//such as MaskEmail number the values in the order in which they appear in the table.
+//If an AnonymizeFunc returns an error it is returned and the following cells are left as they are.
+func (t *Table) Anonymize(rules map[string]AnonymizeFunc) error {
+	indexes := map[int]AnonymizeFunc{}
+	for fieldName, f := range rules {
+		i := getFieldIndex(t.Schema, fieldName)
+		if i == -1 {
+			return fmt.Errorf("could not find field with name %s", fieldName)
+		}
+		indexes[i] = f
+	}
+
+	for k, row := range t.Data {
+		for i := range row {
+			f, ok := indexes[i]
+			if !ok || row[i] == nil {
+				continue
+			}
+			v := f(row[i])
+			if err, ok := v.(error); ok {
+				return fmt.Errorf("row %d: could not anonymize field %s: %s", k, t.Schema[i].FieldName, err)
+			}
+			row[i] = v
+		}
+	}
+
+	return nil
+}
+
+//AnonymizedCopy is like Anonymize but returns an anonymized copy of the table leaving the original untouched
+func (t *Table) AnonymizedCopy(rules map[string]AnonymizeFunc) (*Table, error) {
+	data := make([][]interface{}, len(t.Data))
+	for k, row := range t.Data {
+		data[k] = make([]interface{}, len(row))
+		copy(data[k], row)
+	}
+
+	schema := make([]SchemaField, len(t.Schema))
+	copy(schema, t.Schema)
+
+	newTable := Table{Data: data, Schema: schema}
+	if err := newTable.Anonymize(rules); err != nil {
+		return nil, err
+	}
+	return &newTable, nil
+}
+
+//HashValue replaces a value with the first 12 characters of the sha256 of the salt followed by the value.
+//Equal values produce equal hashes.
+func HashValue(salt string) AnonymizeFunc {
+	return func(value interface{}) interface{} {
+		sum := sha256.Sum256([]byte(salt + fmt.Sprintf("%v", value)))
+		return hex.EncodeToString(sum[:])[:12]
+	}
+}
+
+//MaskEmail replaces the part before @ of email addresses with user1, user2 etc. keeping the domain.
+//Equal addresses are replaced with the same value. Values that are not strings are returned unchanged.
+func MaskEmail() AnonymizeFunc {
+	seen := map[string]string{}
+	return func(value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok {
+			return value
+		}
+		if masked, ok := seen[s]; ok {
+			return masked
+		}
+
+		domain := ""
+		if i := strings.LastIndex(s, "@"); i >= 0 {
+			domain = s[i:]
+		}
+		masked := fmt.Sprintf("user%d%s", len(seen)+1, domain)
+		seen[s] = masked
+		return masked
+	}
+}
+
+//MaskIPKeepPrefix replaces the host part of IPv4 and IPv6 addresses keeping the first prefixBits bits.
+//Hosts are numbered in the order in which they are seen within each prefix so equal addresses
+//are replaced with the same value. A /prefix suffix (as in CIDRs) is kept.
+//An error is returned for an address whose prefix has no host number left, such as the 256th address of a /24.
+//Values that are not strings or not addresses are returned unchanged.
+func MaskIPKeepPrefix(prefixBits int) AnonymizeFunc {
+	seen := map[string]string{}
+	counters := map[string]uint64{}
+	return func(value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok {
+			return value
+		}
+		if masked, ok := seen[s]; ok {
+			return masked
+		}
+
+		addr, suffix := s, ""
+		if i := strings.IndexByte(s, '/'); i >= 0 {
+			addr, suffix = s[:i], s[i:]
+		}
+
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return value
+		}
+
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = 8 * net.IPv4len
+		}
+
+		mask := net.CIDRMask(prefixBits, bits)
+		if mask == nil {
+			return value
+		}
+
+		masked := ip.Mask(mask)
+		if prefixBits < bits {
+			prefix := masked.String()
+			counters[prefix]++
+			n := counters[prefix]
+			//the host bits are the low bits of each byte of the mask
+			for i := len(masked) - 1; i >= 0 && n > 0; i-- {
+				hostBits := uint(8 - countOnes(mask[i]))
+				masked[i] |= byte(n) &^ mask[i]
+				n >>= hostBits
+			}
+			if n > 0 {
+				return fmt.Errorf("no host number left in %s/%d for %s", prefix, prefixBits, s)
+			}
+		}
+
+		seen[s] = masked.String() + suffix
+		return seen[s]
+	}
+}
+
+//countOnes returns the number of bits set in b
+func countOnes(b byte) int {
+	n := 0
+	for ; b != 0; b &= b - 1 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,135 @@
+package tableformatter
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAnonymize(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "IP",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "alex@alex.com", "192.168.0.15", "test"},
+		{2, "john@alex.com", "192.168.0.20", "test"},
+		{3, "alex@alex.com", "192.168.0.15", nil},
+		{4, "jane@bigstep.com", "2A02:0CB8:0000:0000:0000:0000:0000:0010/53", "test2"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	err := table.Anonymize(map[string]AnonymizeFunc{
+		"OWNER": MaskEmail(),
+		"IP":    MaskIPKeepPrefix(24),
+		"LABEL": HashValue("salt"),
+	})
+	Expect(err).To(BeNil())
+
+	Expect(data[0][0]).To(Equal(1))
+	Expect(data[0][1]).To(Equal("user1@alex.com"))
+	Expect(data[1][1]).To(Equal("user2@alex.com"))
+	Expect(data[2][1]).To(Equal("user1@alex.com"))
+	Expect(data[3][1]).To(Equal("user3@bigstep.com"))
+
+	Expect(data[0][2]).To(Equal("192.168.0.1"))
+	Expect(data[1][2]).To(Equal("192.168.0.2"))
+	Expect(data[2][2]).To(Equal("192.168.0.1"))
+	Expect(data[3][2]).To(Equal("2a02:c00::1/53"))
+
+	Expect(data[0][3]).To(Equal(data[1][3]))
+	Expect(data[0][3]).NotTo(Equal("test"))
+	Expect(data[0][3]).NotTo(Equal(data[3][3]))
+	Expect(data[2][3]).To(BeNil())
+}
+
+func TestAnonymizedCopy(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{"alex@alex.com"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	anonymized, err := table.AnonymizedCopy(map[string]AnonymizeFunc{"OWNER": MaskEmail()})
+	Expect(err).To(BeNil())
+	Expect(anonymized.Data[0][0]).To(Equal("user1@alex.com"))
+	Expect(data[0][0]).To(Equal("alex@alex.com"))
+
+	_, err = table.AnonymizedCopy(map[string]AnonymizeFunc{"UNKNOWN": MaskEmail()})
+	Expect(err).NotTo(BeNil())
+}
+
+func TestMaskIPKeepPrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	//the host numbers continue in the bytes before the last one
+	mask := MaskIPKeepPrefix(16)
+	for k := 1; k <= 256; k++ {
+		Expect(mask(fmt.Sprintf("10.1.%d.%d", k/200, k%200))).To(Equal(fmt.Sprintf("10.1.%d.%d", k/256, k%256)))
+	}
+
+	//a /28 has the host numbers 1 to 15 in the low bits of the last byte
+	mask = MaskIPKeepPrefix(28)
+	for k := 1; k <= 15; k++ {
+		Expect(mask(fmt.Sprintf("192.168.0.%d", 112-k))).To(Equal(fmt.Sprintf("192.168.0.%d", 96+k)))
+	}
+	Expect(mask("192.168.0.96")).To(MatchError("no host number left in 192.168.0.96/28 for 192.168.0.96"))
+	Expect(mask("192.168.0.111")).To(Equal("192.168.0.97"))
+	Expect(mask("192.168.0.112")).To(Equal("192.168.0.113"))
+
+	//the whole address is the prefix
+	Expect(MaskIPKeepPrefix(32)("192.168.0.15")).To(Equal("192.168.0.15"))
+
+	table := Table{
+		Data:   [][]interface{}{{"192.168.0.0"}, {"192.168.0.1"}, {"192.168.0.3"}},
+		Schema: []SchemaField{{FieldName: "IP", FieldType: TypeString}},
+	}
+	err := table.Anonymize(map[string]AnonymizeFunc{"IP": MaskIPKeepPrefix(31)})
+	Expect(err).To(MatchError("row 1: could not anonymize field IP: no host number left in 192.168.0.0/31 for 192.168.0.1"))
+	Expect(table.Data).To(Equal([][]interface{}{{"192.168.0.1"}, {"192.168.0.1"}, {"192.168.0.3"}}))
+}
+
+func TestAnonymizeByFieldID(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := Table{
+		Data:   [][]interface{}{{"alex@alex.com"}},
+		Schema: []SchemaField{{FieldName: "OWNER EMAIL", FieldID: "owner", FieldType: TypeString}},
+	}
+
+	Expect(table.Anonymize(map[string]AnonymizeFunc{"owner": MaskEmail()})).To(Succeed())
+	Expect(table.Data[0][0]).To(Equal("user1@alex.com"))
+
+	Expect(table.Anonymize(map[string]AnonymizeFunc{"OWNER EMAIL": MaskEmail()})).To(Succeed())
+	Expect(table.Data[0][0]).To(Equal("user1@alex.com"))
+
+	Expect(table.Anonymize(map[string]AnonymizeFunc{"email": MaskEmail()})).To(MatchError("could not find field with name email"))
+}
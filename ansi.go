@@ -0,0 +1,232 @@
+package tableformatter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+//ansiSequenceLength returns the length in bytes of the ANSI escape sequence at the start of s
+//or 0 if s does not start with one. An unterminated sequence extends to the end of the string.
+//An escape character that does not start a sequence is a sequence of length 1.
+func ansiSequenceLength(s string) int {
+	n, _ := ansiSequence(s)
+	return n
+}
+
+//ansiSequence returns the length in bytes of the ANSI escape sequence at the start of s
+//and whether the sequence is terminated before the end of s.
+//CSI sequences such as colors, OSC sequences such as hyperlinks and the two and three byte escapes
+//such as ESC ( B are recognized.
+func ansiSequence(s string) (int, bool) {
+	if len(s) == 0 || s[0] != '\x1b' {
+		return 0, false
+	}
+	if len(s) < 2 {
+		//whatever is written after a lone escape character at the end of s could be read as the rest of a sequence
+		return 1, false
+	}
+
+	switch c := s[1]; {
+	case c == '[':
+		for i := 2; i < len(s); i++ {
+			//the final byte of a CSI sequence is in the range @ to ~
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1, true
+			}
+		}
+		return len(s), false
+	case c == ']':
+		//an OSC sequence ends with BEL or with ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\x07' {
+				return i + 1, true
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, true
+			}
+		}
+		return len(s), false
+	case c >= 0x20 && c <= 0x2f:
+		//intermediate bytes followed by a final byte, such as ESC ( B
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x30 && s[i] <= 0x7e {
+				return i + 1, true
+			}
+			if s[i] > 0x2f || s[i] < 0x20 {
+				return i, true
+			}
+		}
+		return len(s), false
+	case c >= 0x30 && c <= 0x7e:
+		return 2, true
+	default:
+		return 1, true
+	}
+}
+
+//decolorize removes all ANSI escape sequences from a string
+func decolorize(s string) string {
+	if strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
+
+//stripUnterminatedSequence removes an ANSI escape sequence that is not terminated before the end of s.
+//Such a sequence would swallow the characters written after s, like padding or a column delimiter.
+func stripUnterminatedSequence(s string) string {
+	if strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
+	for i := 0; i < len(s); i++ {
+		if n, terminated := ansiSequence(s[i:]); n > 0 {
+			if !terminated {
+				//what is left can end with a lone escape character, such as the first of two
+				return stripUnterminatedSequence(s[:i])
+			}
+			i += n - 1
+		}
+	}
+	return s
+}
+
+//pad appends spaces to s until its visible width is at least width.
+//An unterminated ANSI escape sequence at the end of s is removed.
+func pad(s string, width int) string {
+	s = stripUnterminatedSequence(s)
+	w := VisibleWidth(s)
+	if w >= width {
+		return s
+	}
+	return s + emptyString(width-w)
+}
+
+//VisibleWidth returns the number of columns a terminal uses to display s.
+//ANSI escape sequences and combining marks have zero width, East Asian wide characters and emoji are two columns wide.
+func VisibleWidth(s string) int {
+	return stringWidth(decolorize(s))
+}
+
+//TruncateToWidth cuts s so that it is at most w characters wide, ending it with ellipsis if anything was removed.
+//ANSI escape sequences are kept, including those after the cut, so that colors are reset properly.
+//A negative w is the same as 0.
+func TruncateToWidth(s string, w int, ellipsis string) string {
+	if w < 0 {
+		w = 0
+	}
+	if VisibleWidth(s) <= w {
+		return s
+	}
+
+	ellipsisWidth := VisibleWidth(ellipsis)
+	if ellipsisWidth > w {
+		return TruncateToWidth(ellipsis, w, "")
+	}
+
+	var sb strings.Builder
+	width := 0
+	//cut is set once the ellipsis is written, the runes that follow are dropped
+	cut := w == ellipsisWidth
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLength(s[i:]); n > 0 {
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !cut {
+			//a wide rune that does not fit is replaced by the ellipsis as a whole
+			if rw := runeWidth(r); width+rw <= w-ellipsisWidth {
+				sb.WriteString(s[i : i+size])
+				width += rw
+			} else {
+				sb.WriteString(ellipsis)
+				cut = true
+			}
+		}
+		i += size
+	}
+	if w == ellipsisWidth {
+		return ellipsis + sb.String()
+	}
+
+	return sb.String()
+}
+
+//splitToWidth cuts s into pieces of at most w columns without breaking ANSI escape sequences or runes.
+//A piece holding a single wide rune is wider than a w of 1.
+func splitToWidth(s string, w int) []string {
+	var parts []string
+	var sb strings.Builder
+	width := 0
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLength(s[i:]); n > 0 {
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := runeWidth(r)
+		//combining marks stay with the rune they follow, wide runes are not split across pieces
+		if width > 0 && width+rw > w {
+			parts = append(parts, sb.String())
+			sb.Reset()
+			width = 0
+		}
+		sb.WriteString(s[i : i+size])
+		width += rw
+		i += size
+	}
+	return append(parts, sb.String())
+}
+
+//WrapToWidth splits s into lines at most w characters wide. Lines are broken on spaces where possible,
+//words longer than w are broken anywhere. Existing new lines are preserved.
+//ANSI escape sequences are kept and have zero width. A w smaller than 1 disables wrapping.
+func WrapToWidth(s string, w int) []string {
+	if w < 1 {
+		return strings.Split(s, "\n")
+	}
+
+	lines := []string{}
+
+	for _, line := range strings.Split(s, "\n") {
+		var current strings.Builder
+		currentWidth := 0
+
+		for k, word := range strings.Split(line, " ") {
+			wordWidth := VisibleWidth(word)
+
+			if k > 0 && currentWidth+1+wordWidth <= w {
+				current.WriteString(" ")
+				current.WriteString(word)
+				currentWidth += 1 + wordWidth
+				continue
+			}
+
+			if k > 0 {
+				lines = append(lines, current.String())
+				current.Reset()
+				currentWidth = 0
+			}
+
+			parts := splitToWidth(word, w)
+			lines = append(lines, parts[:len(parts)-1]...)
+			current.WriteString(parts[len(parts)-1])
+			currentWidth = VisibleWidth(parts[len(parts)-1])
+		}
+
+		lines = append(lines, current.String())
+	}
+
+	return lines
+}
@@ -0,0 +1,134 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const _red = "\x1b[31m"
+const _reset = "\x1b[0m"
+
+func TestDecolorize(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(decolorize(_red + "test" + _reset)).To(Equal("test"))
+	Expect(decolorize("te\x1b[1;32mst")).To(Equal("test"))
+	Expect(decolorize("no colors")).To(Equal("no colors"))
+	//unterminated sequences are removed up to the end of the string
+	Expect(decolorize("test\x1b[31")).To(Equal("test"))
+	//an escape character that does not start a sequence is removed as well
+	Expect(decolorize("\x1b\x1b[A[")).To(Equal("["))
+	//hyperlinks end with ESC \ or BEL
+	Expect(decolorize("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\")).To(Equal("link"))
+	Expect(decolorize("\x1b]8;;https://example.com\x07link\x1b]8;;\x07")).To(Equal("link"))
+	//character set selection as written by tput sgr0
+	Expect(decolorize("\x1b(B\x1b[mreset")).To(Equal("reset"))
+	Expect(decolorize("\x1b7saved\x1b8")).To(Equal("saved"))
+}
+
+func TestStripUnterminatedSequence(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(stripUnterminatedSequence("cut off \x1b[3")).To(Equal("cut off "))
+	Expect(stripUnterminatedSequence("cut off \x1b[")).To(Equal("cut off "))
+	Expect(stripUnterminatedSequence(_red + "test" + _reset)).To(Equal(_red + "test" + _reset))
+	//the second escape character is a parameter of the first sequence, terminated by [
+	Expect(stripUnterminatedSequence("\x1b[\x1b[")).To(Equal("\x1b[\x1b["))
+	Expect(VisibleWidth(pad("test\x1b[31", 6) + "|")).To(Equal(7))
+	//a lone escape character would swallow the padding
+	Expect(stripUnterminatedSequence("test\x1b")).To(Equal("test"))
+	Expect(stripUnterminatedSequence("test\x1b\x1b")).To(Equal("test"))
+	Expect(stripUnterminatedSequence("test\x1b]8;;")).To(Equal("test"))
+	Expect(VisibleWidth(pad("test\x1b", 6) + "|")).To(Equal(7))
+}
+
+func TestVisibleWidth(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(VisibleWidth("test")).To(Equal(4))
+	Expect(VisibleWidth(_red + "test" + _reset)).To(Equal(4))
+	Expect(VisibleWidth("München")).To(Equal(7))
+	Expect(VisibleWidth("")).To(Equal(0))
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(TruncateToWidth("test", 4, "...")).To(Equal("test"))
+	Expect(TruncateToWidth("testing", 6, "...")).To(Equal("tes..."))
+	Expect(TruncateToWidth("testing", 2, "...")).To(Equal(".."))
+	Expect(TruncateToWidth("testing", 3, "...")).To(Equal("..."))
+	Expect(TruncateToWidth("testing", 0, "...")).To(Equal(""))
+	Expect(TruncateToWidth("testing", -1, "...")).To(Equal(""))
+	Expect(TruncateToWidth(_red+"testing"+_reset, -5, "")).To(Equal(_red + _reset))
+	Expect(TruncateToWidth("Münchener", 5, "…")).To(Equal("Münc…"))
+	Expect(TruncateToWidth(_red+"testing"+_reset, 5, "..")).To(Equal(_red + "tes.." + _reset))
+	Expect(VisibleWidth(TruncateToWidth(_red+"testing"+_reset, 5, ".."))).To(Equal(5))
+}
+
+func TestWrapToWidth(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(WrapToWidth("the quick brown fox", 10)).To(Equal([]string{"the quick", "brown fox"}))
+	Expect(WrapToWidth("abcdefghij klm", 4)).To(Equal([]string{"abcd", "efgh", "ij", "klm"}))
+	Expect(WrapToWidth("first\nsecond line", 6)).To(Equal([]string{"first", "second", "line"}))
+	Expect(WrapToWidth("no wrap", 0)).To(Equal([]string{"no wrap"}))
+
+	lines := WrapToWidth(_red+"the quick"+_reset+" brown fox", 5)
+	Expect(lines).To(Equal([]string{_red + "the", "quick" + _reset, "brown", "fox"}))
+
+	for _, line := range WrapToWidth("München Zürich Genève", 7) {
+		Expect(VisibleWidth(line)).To(BeNumerically("<=", 7))
+	}
+}
+
+func TestGetTableRowWithColorsAndUnicode(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "CITY",
+			FieldType: TypeString,
+			FieldSize: 8,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+			FieldSize: 7,
+		},
+	}
+
+	data := [][]interface{}{
+		{"München", _red + "failed" + _reset},
+		{"Reading", "100%"},
+	}
+
+	s := getTableAsString(data, schema, newRenderOptions())
+	t.Logf("%s", s)
+
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for _, line := range lines {
+		Expect(VisibleWidth(line)).To(Equal(VisibleWidth(lines[0])))
+	}
+	Expect(s).To(ContainSubstring("| 100%   |"))
+}
+
+//coloredSamples is a corpus of colored output of command line tools used to seed the fuzz targets
+var coloredSamples = []string{
+	_red + "error" + _reset,
+	"\x1b[1;32m✔\x1b[0m deployed",
+	"\x1b[38;5;208mwarning\x1b[39m: disk 93% full",
+	"\x1b[38;2;255;100;0mtruecolor\x1b[0m",
+	"\x1b[4munderlined\x1b[24m and \x1b[7mreversed\x1b[27m",
+	"\x1b[31mstarted here\nended here\x1b[0m",
+	"cut off \x1b[3",
+	"cut off \x1b[",
+	"\x1b",
+	"\x1b[K\x1b[2Jcleared",
+	"München \x1b[33mZürich\x1b[0m 東京",
+	"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+	"\x1b(B\x1b[mreset",
+	"",
+}
@@ -0,0 +1,127 @@
+package tableformatter
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+//ansiHTMLColors are the html colors of the 16 ANSI colors, the normal ones followed by the bright ones
+var ansiHTMLColors = []string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+//ansiStyle is the state set by SGR sequences
+type ansiStyle struct {
+	color      string
+	background string
+	bold       bool
+}
+
+//css returns the style attribute of a span with the style or an empty string for the default style
+func (s ansiStyle) css() string {
+	var rules []string
+	if s.color != "" {
+		rules = append(rules, "color:"+s.color)
+	}
+	if s.background != "" {
+		rules = append(rules, "background-color:"+s.background)
+	}
+	if s.bold {
+		rules = append(rules, "font-weight:bold")
+	}
+	return strings.Join(rules, ";")
+}
+
+//apply changes the style according to the parameters of an SGR sequence. Unknown parameters are ignored.
+func (s *ansiStyle) apply(params string) {
+	if params == "" {
+		*s = ansiStyle{}
+		return
+	}
+	for _, param := range strings.Split(params, ";") {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 22:
+			s.bold = false
+		case code >= 30 && code <= 37:
+			s.color = ansiHTMLColors[code-30]
+		case code == 39:
+			s.color = ""
+		case code >= 40 && code <= 47:
+			s.background = ansiHTMLColors[code-40]
+		case code == 49:
+			s.background = ""
+		case code >= 90 && code <= 97:
+			s.color = ansiHTMLColors[code-90+8]
+		case code >= 100 && code <= 107:
+			s.background = ansiHTMLColors[code-100+8]
+		}
+	}
+}
+
+//AnsiToHTML converts the colors and bold set by ANSI SGR sequences into html spans and escapes the text.
+//The 16 colors, bold and reset are supported. Other escape sequences are dropped.
+func AnsiToHTML(s string) string {
+	var sb strings.Builder
+	var style ansiStyle
+	open := false
+
+	for i := 0; i < len(s); {
+		n := ansiSequenceLength(s[i:])
+		if n == 0 {
+			end := strings.IndexByte(s[i:], '\x1b')
+			if end < 0 {
+				end = len(s) - i
+			} else if end == 0 {
+				//a lone escape character
+				i++
+				continue
+			}
+			sb.WriteString(html.EscapeString(s[i : i+end]))
+			i += end
+			continue
+		}
+
+		seq := s[i : i+n]
+		i += n
+		if len(seq) < 3 || seq[1] != '[' || seq[len(seq)-1] != 'm' {
+			continue
+		}
+
+		newStyle := style
+		newStyle.apply(seq[2 : len(seq)-1])
+		if newStyle == style {
+			continue
+		}
+		style = newStyle
+
+		if open {
+			sb.WriteString("</span>")
+			open = false
+		}
+		if css := style.css(); css != "" {
+			sb.WriteString(`<span style="` + css + `">`)
+			open = true
+		}
+	}
+
+	if open {
+		sb.WriteString("</span>")
+	}
+
+	return sb.String()
+}
+
+//getHTMLPre wraps text rendered with ANSI colors in a pre element
+func getHTMLPre(s string) string {
+	return `<pre style="font-family:monospace">` + AnsiToHTML(s) + "</pre>\n"
+}
@@ -0,0 +1,48 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAnsiToHTML(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(AnsiToHTML("a < b & c")).To(Equal("a &lt; b &amp; c"))
+	Expect(AnsiToHTML(_red + "down" + _reset + " ok")).To(Equal(`<span style="color:#cd0000">down</span> ok`))
+	Expect(AnsiToHTML("\x1b[1;92mup\x1b[22m!\x1b[0m")).To(Equal(`<span style="color:#00ff00;font-weight:bold">up</span><span style="color:#00ff00">!</span>`))
+	Expect(AnsiToHTML("\x1b[44mblue\x1b[49m")).To(Equal(`<span style="background-color:#0000ee">blue</span>`))
+
+	//unknown codes and sequences are dropped
+	Expect(AnsiToHTML("\x1b[5mblink\x1b[0m")).To(Equal("blink"))
+	Expect(AnsiToHTML("\x1b[2Kline")).To(Equal("line"))
+
+	//unterminated spans are closed
+	Expect(AnsiToHTML(_red + "<red>")).To(Equal(`<span style="color:#cd0000">&lt;red&gt;</span>`))
+}
+
+func TestRenderTableAsHTMLPre(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{_red + "down" + _reset}}, Schema: schema}
+
+	s, err := table.RenderTable("items", "", "html-pre")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`<pre style="font-family:monospace">+--------+
+| STATUS |
++--------+
+| <span style="color:#cd0000">down</span>   |
++--------+
+Total: 1 items
+
+</pre>
+`))
+}
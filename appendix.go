@@ -0,0 +1,75 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//RenderTableWithAppendix renders the table followed by the raw objects it was built from.
+//The text format appends a "--- raw (json) ---" section with the indented json of rawObjects.
+//The json formats render {"rows": [...], "raw": ...} and the yaml format the same document in yaml.
+//Other formats are not supported. Nothing is returned if either part fails to render.
+func (t *Table) RenderTableWithAppendix(tableName string, topLine string, format string, rawObjects interface{}, opts ...RenderOption) (string, error) {
+	name, err := resolveFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	switch name {
+	case "json", "json-ordered":
+		rows, err := t.RenderTable(tableName, topLine, format, opts...)
+		if err != nil {
+			return "", err
+		}
+		doc := struct {
+			Rows json.RawMessage `json:"rows"`
+			Raw  interface{}     `json:"raw"`
+		}{json.RawMessage(rows), rawObjects}
+
+		ret, err := json.MarshalIndent(doc, "", "\t")
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	case "yaml":
+		data, schema, err := t.getExportedData()
+		if err != nil {
+			return "", err
+		}
+		rows := make([]interface{}, len(data))
+		for k, row := range data {
+			rows[k] = getRowAsYAMLMap(row, schema)
+		}
+		doc := yaml.MapSlice{
+			{Key: "rows", Value: rows},
+			{Key: "raw", Value: rawObjects},
+		}
+
+		ret, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	case "csv", "yaml-docs", "aligned", "html-pre":
+		return "", fmt.Errorf("format %s does not support an appendix", format)
+	default:
+		table, err := t.RenderTable(tableName, topLine, format, opts...)
+		if err != nil {
+			return "", err
+		}
+		raw, err := json.MarshalIndent(rawObjects, "", "\t")
+		if err != nil {
+			return "", err
+		}
+
+		var sb strings.Builder
+		sb.WriteString(table)
+		sb.WriteString("--- raw (json) ---\n")
+		sb.Write(raw)
+		sb.WriteString("\n")
+		return sb.String(), nil
+	}
+}
@@ -0,0 +1,64 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableWithAppendix(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{1}}, Schema: schema}
+	raw := []map[string]interface{}{{"id": 1, "extra": "value"}}
+
+	s, err := table.RenderTableWithAppendix("items", "", "", raw)
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+----+
+| ID |
++----+
+| 1  |
++----+
+Total: 1 items
+
+--- raw (json) ---
+[
+	{
+		"extra": "value",
+		"id": 1
+	}
+]
+`))
+
+	s, err = table.RenderTableWithAppendix("items", "", "json", raw)
+	Expect(err).To(BeNil())
+	var doc map[string][]map[string]interface{}
+	Expect(json.Unmarshal([]byte(s), &doc)).To(BeNil())
+	Expect(doc["rows"]).To(Equal([]map[string]interface{}{{"ID": 1.0}}))
+	Expect(doc["raw"]).To(Equal([]map[string]interface{}{{"id": 1.0, "extra": "value"}}))
+
+	s, err = table.RenderTableWithAppendix("items", "", "yaml", raw)
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`rows:
+- id: 1
+raw:
+- extra: value
+  id: 1
+`))
+
+	//nothing is rendered if the raw objects cannot be marshalled
+	s, err = table.RenderTableWithAppendix("items", "", "", func() {})
+	Expect(err).NotTo(BeNil())
+	Expect(s).To(BeEmpty())
+
+	_, err = table.RenderTableWithAppendix("items", "", "csv", raw)
+	Expect(err).NotTo(BeNil())
+}
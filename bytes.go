@@ -0,0 +1,82 @@
+package tableformatter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+//BytesFormatSI is a FieldFormat of bytes fields printing the sizes with the SI units, such as 1.5 GB, instead of
+//the IEC units, such as 1.5 GiB
+const BytesFormatSI = "si"
+
+//iecByteUnits and siByteUnits are the units of the sizes printed by the bytes fields, each 1024 or 1000 times the previous
+var (
+	iecByteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siByteUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+//bytesHandler handles the numbers of bytes of TypeBytes fields, printed as sizes by the text formats and as they are
+//by csv, json and yaml
+type bytesHandler struct{ interfaceHandler }
+
+func (bytesHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	if n, ok := getNumber(value); ok {
+		return formatBytes(n, field), nil
+	}
+	return getInterfaceAsString(value), nil
+}
+
+//FormatCSV writes the number of bytes
+func (bytesHandler) FormatCSV(value interface{}, field *SchemaField) (string, error) {
+	if n, ok := getNumber(value); ok {
+		return formatNumber(n), nil
+	}
+	return getInterfaceAsString(value), nil
+}
+
+func (h bytesHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+//Less orders the cells by their number, whatever their type, see getNumber
+func (bytesHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return numberLess(a, b)
+}
+
+//formatNumber returns an integer with its digits and a float with the fewest decimals that represent it
+func formatNumber(n number) string {
+	if n.isInt {
+		return strconv.FormatInt(n.i, 10)
+	}
+	return strconv.FormatFloat(n.f, 'f', -1, 64)
+}
+
+//formatBytes returns a number of bytes with the largest unit that keeps it at least 1, such as 1.5 GiB, the IEC units
+//being replaced by the SI units if the FieldFormat is BytesFormatSI. The sizes under 1 KiB are printed in bytes and the
+//others with FieldPrecision decimals, one if the field has no precision.
+func formatBytes(n number, field *SchemaField) string {
+	base := 1024.0
+	units := iecByteUnits
+	if field.FieldFormat == BytesFormatSI {
+		base = 1000
+		units = siByteUnits
+	}
+
+	value := n.f
+	if math.Abs(value) < base || math.IsNaN(value) || math.IsInf(value, 0) {
+		return formatNumber(n) + " " + units[0]
+	}
+
+	k := 0
+	for k < len(units)-1 && math.Abs(value) >= base {
+		value /= base
+		k++
+	}
+	precision := field.FieldPrecision
+	if precision <= 0 {
+		precision = 1
+	}
+	return fmt.Sprintf("%.*f %s", precision, value, units[k])
+}
@@ -0,0 +1,94 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFormatBytes(t *testing.T) {
+	RegisterTestingT(t)
+
+	iec := &SchemaField{FieldType: TypeBytes}
+	si := &SchemaField{FieldType: TypeBytes, FieldFormat: BytesFormatSI}
+	for _, c := range []struct {
+		cell interface{}
+		iec  string
+		si   string
+	}{
+		{0, "0 B", "0 B"},
+		{1023, "1023 B", "1.0 kB"},
+		{int64(1536), "1.5 KiB", "1.5 kB"},
+		{int64(1000000000000), "931.3 GiB", "1.0 TB"},
+		{1610612736.0, "1.5 GiB", "1.6 GB"},
+		{512.5, "512.5 B", "512.5 B"},
+		{-2048, "-2.0 KiB", "-2.0 kB"},
+	} {
+		s, err := getFieldTypeHandler(iec).Format(c.cell, iec)
+		Expect(err).To(BeNil())
+		Expect(s).To(Equal(c.iec), "%v", c.cell)
+		s, err = getFieldTypeHandler(si).Format(c.cell, si)
+		Expect(err).To(BeNil())
+		Expect(s).To(Equal(c.si), "%v", c.cell)
+	}
+
+	iec.FieldPrecision = 3
+	s, _ := getFieldTypeHandler(iec).Format(int64(1000000000000), iec)
+	Expect(s).To(Equal("931.323 GiB"))
+
+	//cells that are not numbers are printed as they are
+	s, _ = getFieldTypeHandler(iec).Format("unknown", iec)
+	Expect(s).To(Equal("unknown"))
+}
+
+func TestTypeBytes(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "DISK",
+			FieldType: TypeBytes,
+		},
+	}
+	data := [][]interface{}{
+		{1, int64(1000000000000)},
+		{2, 1536},
+		{3, 0},
+		{4, 1610612736.0},
+		{5, 1023},
+	}
+	table := Table{Data: data, Schema: schema}
+
+	Expect(TableSorter(schema).OrderBy("DISK").SortErr(data)).To(Succeed())
+	Expect(getIDs(data)).To(Equal([]int{3, 5, 2, 4, 1}))
+
+	table.AdjustFieldSizes()
+	Expect(table.Schema[1].FieldSize).To(Equal(len("931.3 GiB") + 1))
+
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 2  | 1.5 KiB   |"))
+	Expect(s).To(ContainSubstring("| 1  | 931.3 GiB |"))
+
+	//csv, json and yaml write the numbers
+	s, err = table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,DISK\n3,0\n5,1023\n2,1536\n4,1610612736\n1,1000000000000\n"))
+
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"DISK": 1000000000000`))
+	Expect(s).To(ContainSubstring(`"DISK": 1610612736`))
+
+	s, err = table.Render(WithFormat("yaml"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("disk: 1536\n"))
+
+	//cells that are not numbers cannot be sorted
+	data[0][1] = "1 GiB"
+	Expect(TableSorter(schema).OrderBy("DISK").SortErr(data)).NotTo(Succeed())
+}
@@ -0,0 +1,91 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+//FormatCells formats the cells of a row the way the text format does, for callers that draw their own borders.
+//The row holds one cell for each field of the schema, including the computed ones.
+//Each cell is returned as its lines, padded to the same width and to the height of the tallest cell of the row.
+//The width of a cell is its FieldSize or the width of its widest line if that is larger, see ColumnWidths.
+func FormatCells(row []interface{}, schema []SchemaField) ([][]string, error) {
+	if isRawRow(row) {
+		return nil, fmt.Errorf("raw rows have no cells")
+	}
+	if len(row) < len(schema) {
+		return nil, fmt.Errorf("row has %d cells, expected %d", len(row), len(schema))
+	}
+	if err := checkCustomCells([][]interface{}{row}, schema); err != nil {
+		return nil, err
+	}
+	return formatCells(row, schema, newRenderOptions()), nil
+}
+
+//ColumnWidths returns the width of each field: its FieldSize, or the width of its name
+//or of its widest cell in data if any of them is larger, plus a space for right aligned and centered fields.
+//The rows of data hold one cell for each field.
+func ColumnWidths(data [][]interface{}, schema []SchemaField) []int {
+	widths := make([]int, len(schema))
+	for i, field := range getWidenedSchema(data, schema, 0) {
+		widths[i] = field.FieldSize
+	}
+	return widths
+}
+
+//formatCells returns the lines of each cell of the row aligned to the width of the cell and padded to the height of the row
+func formatCells(row []interface{}, schema []SchemaField, options *RenderOptions) [][]string {
+	cells := make([][]string, len(schema))
+	rowHeight := 1
+
+	for i, field := range schema {
+		lines := getWrappedCellLines(row[i], &field)
+
+		cell := []string{}
+		width := field.FieldSize
+		widest := 0
+		for _, line := range lines {
+			line = stripUnterminatedSequence(line)
+			if VisibleWidth(line) > widest {
+				widest = VisibleWidth(line)
+			}
+			if widest > width {
+				width = widest
+			}
+			//the trailing space is added only if the field size leaves room for it
+			if trimmed := strings.TrimRight(line, " "); options.NormalizeTrailingSpace && trimmed != "" && VisibleWidth(trimmed)+1 > width {
+				width = VisibleWidth(trimmed) + 1
+			}
+			cell = append(cell, line)
+		}
+
+		//like left aligned cells, right aligned and centered cells keep a space before the next column if there is room for it
+		margin := 0
+		if field.FieldAlignment != AlignLeft && widest < width {
+			margin = 1
+		}
+
+		//each line is aligned on its own
+		for j := range cell {
+			cell[j] = align(cell[j], width-margin, field.FieldAlignment) + emptyString(margin)
+		}
+
+		if rowHeight < len(cell) {
+			rowHeight = len(cell)
+		}
+		cells[i] = cell
+	}
+
+	//fill the cells to the height of the row with empty lines
+	for i, cell := range cells {
+		width := 0
+		if len(cell) > 0 {
+			width = VisibleWidth(cell[0])
+		}
+		for j := len(cell); j < rowHeight; j++ {
+			cells[i] = append(cells[i], emptyString(width))
+		}
+	}
+
+	return cells
+}
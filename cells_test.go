@@ -0,0 +1,70 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFormatCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	cells, err := FormatCells([]interface{}{10, "first line\nsecond", 1.5}, schema)
+	Expect(err).To(BeNil())
+	Expect(cells).To(Equal([][]string{
+		{"10  ", "    "},
+		{"first line", "second    "},
+		{"1.50", "    "},
+	}))
+
+	_, err = FormatCells([]interface{}{10}, schema)
+	Expect(err).NotTo(BeNil())
+
+	_, err = FormatCells(NewRawRow("raw"), schema)
+	Expect(err).NotTo(BeNil())
+}
+
+func TestColumnWidths(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{10, "production\nx", "ok"},
+		NewRawRow("a raw row wider than all the columns"),
+		{200000, "test", "\x1b[31mfailed\x1b[0m"},
+	}
+
+	Expect(ColumnWidths(data, schema)).To(Equal([]int{6, 10, 6}))
+}
@@ -0,0 +1,31 @@
+package tableformatter
+
+//Cell is a cell that overrides the type of its field, such as "N/A" in a TypeInt field.
+//Any field accepts it: the text formats render AsString, the json, yaml, csv and sql formats write Value
+//and sorting places it after all the other cells of the field, ordered by AsString.
+type Cell struct {
+	Value    interface{}
+	AsString string
+}
+
+//StringCell returns a Cell rendered as s in every format
+func StringCell(s string) Cell {
+	return Cell{Value: s, AsString: s}
+}
+
+//cellLess returns a less function that orders Cell values after the cells compared by less
+func cellLess(less lessFunc) lessFunc {
+	return func(a, b interface{}, field *SchemaField) bool {
+		ca, aIsCell := a.(Cell)
+		cb, bIsCell := b.(Cell)
+		switch {
+		case aIsCell && bIsCell:
+			return ca.AsString < cb.AsString
+		case aIsCell:
+			return false
+		case bIsCell:
+			return true
+		}
+		return less(a, b, field)
+	}
+}
@@ -0,0 +1,162 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCellValueText(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "CORES",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", 8, 0.5},
+		{"web-2", StringCell("N/A"), Cell{Value: 1.25, AsString: "~1.25 (estimated)"}},
+		{"web-3", 4, 2.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	widths := ColumnWidths(table.Data, table.Schema)
+	Expect(widths[2]).To(Equal(len("~1.25 (estimated)")))
+
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| N/A"))
+	Expect(s).To(ContainSubstring("| ~1.25 (estimated) |"))
+	Expect(s).To(ContainSubstring("| 0.50"))
+
+	values, err := table.DistinctValues("CORES", 0)
+	Expect(err).To(BeNil())
+	Expect(values).To(Equal([]string{"8", "N/A", "4"}))
+}
+
+func TestCellValueMachineFormats(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "CORES",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", 8, 0.5},
+		{"web-2", StringCell("N/A"), Cell{Value: 1.25, AsString: "~1.25 (estimated)"}},
+		{"web-3", 4, 2.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("servers", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"CORES": "N/A"`))
+	Expect(s).To(ContainSubstring(`"LOAD": 1.25`))
+	Expect(s).NotTo(ContainSubstring("estimated"))
+
+	s, err = table.RenderTable("servers", "", "yaml")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("load: 1.25"))
+	Expect(s).NotTo(ContainSubstring("estimated"))
+
+	s, err = table.RenderTable("servers", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(strings.Split(s, "\n")[2]).To(Equal("web-2,N/A,1.250000"))
+
+	table.Data[1][2] = Cell{AsString: "unknown"}
+	s, err = table.RenderTable("servers", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"LOAD": null`))
+}
+
+func TestCellValueSort(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "CORES",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", 8, 0.5},
+		{"web-2", StringCell("N/A"), Cell{Value: 1.25, AsString: "~1.25 (estimated)"}},
+		{"web-3", 4, 2.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = append(table.Data, []interface{}{"web-4", StringCell("?"), 1.0})
+
+	Expect(TableSorter(table.Schema).OrderBy("CORES").SortErr(table.Data)).To(BeNil())
+	names := []interface{}{}
+	for _, row := range table.Data {
+		names = append(names, row[0])
+	}
+	Expect(names).To(Equal([]interface{}{"web-3", "web-1", "web-4", "web-2"}))
+}
+
+func TestCellValueDiagnose(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "CORES",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", 8, 0.5},
+		{"web-2", StringCell("N/A"), Cell{Value: 1.25, AsString: "~1.25 (estimated)"}},
+		{"web-3", 4, 2.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	Expect(DiagnoseTable(table)).To(BeEmpty())
+}
@@ -0,0 +1,78 @@
+package tableformatter
+
+import "fmt"
+
+const (
+	//DefaultMaxFieldPrecision is the largest FieldPrecision rendered unless RenderOptions.MaxFieldPrecision is set
+	DefaultMaxFieldPrecision = 20
+	//DefaultMaxFieldSize is the largest FieldSize rendered unless RenderOptions.MaxFieldSize is set
+	DefaultMaxFieldSize = 1000
+)
+
+//maxPadding is the widest field the text formats pad their cells to, whatever the limits of the options
+const maxPadding = 1 << 20
+
+//ErrPaddingTooLarge is returned by the render functions when the widest cell of a field is wider than
+//the text formats can pad the other cells of the field to
+type ErrPaddingTooLarge struct {
+	Field string
+	Width int
+}
+
+func (e *ErrPaddingTooLarge) Error() string {
+	return fmt.Sprintf("field %s is %d characters wide, the widest field that can be rendered is %d characters", e.Field, e.Width, maxPadding)
+}
+
+//getFieldLimits returns the largest FieldPrecision and FieldSize rendered with the options
+func getFieldLimits(options *RenderOptions) (int, int) {
+	maxPrecision := DefaultMaxFieldPrecision
+	maxSize := DefaultMaxFieldSize
+	if options != nil && options.MaxFieldPrecision > 0 {
+		maxPrecision = options.MaxFieldPrecision
+	}
+	if options != nil && options.MaxFieldSize > 0 {
+		maxSize = options.MaxFieldSize
+	}
+	if maxSize > maxPadding {
+		maxSize = maxPadding
+	}
+	return maxPrecision, maxSize
+}
+
+//getClampedSchema returns the schema, or a copy of it in which the FieldPrecision and FieldSize of the fields
+//are at most the limits of the options
+func getClampedSchema(schema []SchemaField, options *RenderOptions) []SchemaField {
+	maxPrecision, maxSize := getFieldLimits(options)
+
+	var newSchema []SchemaField
+	for i := range schema {
+		if schema[i].FieldPrecision <= maxPrecision && schema[i].FieldSize <= maxSize {
+			continue
+		}
+		if newSchema == nil {
+			newSchema = make([]SchemaField, len(schema))
+			copy(newSchema, schema)
+		}
+		if newSchema[i].FieldPrecision > maxPrecision {
+			newSchema[i].FieldPrecision = maxPrecision
+		}
+		if newSchema[i].FieldSize > maxSize {
+			newSchema[i].FieldSize = maxSize
+		}
+	}
+
+	if newSchema == nil {
+		return schema
+	}
+	return newSchema
+}
+
+//checkPadding returns an ErrPaddingTooLarge for the first field of the schema wider than maxPadding
+func checkPadding(schema []SchemaField) error {
+	for _, field := range schema {
+		if field.FieldSize > maxPadding {
+			return &ErrPaddingTooLarge{Field: field.FieldName, Width: field.FieldSize}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAbsurdFieldPrecisionAndSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 200,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 1000000000,
+		},
+	}
+
+	table := Table{
+		Data:   [][]interface{}{{0.5, "a"}, {1.25, "b"}},
+		Schema: append([]SchemaField{}, schema...),
+	}
+
+	start := time.Now()
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+
+	Expect(s).To(ContainSubstring(" 0." + "5" + strings.Repeat("0", DefaultMaxFieldPrecision-1) + " "))
+	for _, line := range strings.Split(s, "\n") {
+		Expect(len(line)).To(BeNumerically("<", DefaultMaxFieldSize+50))
+	}
+
+	//the limits are configurable. Rendering updated the sizes of the table, start over.
+	table.Schema = append([]SchemaField{}, schema...)
+	s, err = table.Render(WithFieldLimits(3, 10))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 1.250 | b         |"))
+
+	problems := DiagnoseTable(Table{Data: table.Data, Schema: schema})
+	Expect(problems).To(ContainElement(Problem{Severity: SeverityWarning, Row: -1, Column: "LOAD", Message: "field precision 200 is clamped to 20"}))
+	Expect(problems).To(ContainElement(Problem{Severity: SeverityWarning, Row: -1, Column: "LABEL", Message: "field size 1000000000 is clamped to 1000"}))
+}
+
+func TestPaddingTooLarge(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(len(emptyString(maxPadding + 10))).To(Equal(maxPadding))
+
+	table := Table{
+		Data:   [][]interface{}{{strings.Repeat("x", maxPadding+1)}, {"a"}},
+		Schema: []SchemaField{{FieldName: "LABEL", FieldType: TypeString}},
+	}
+
+	_, err := table.Render()
+	Expect(err).To(BeAssignableToTypeOf(&ErrPaddingTooLarge{}))
+	Expect(err.Error()).To(HavePrefix("field LABEL is"))
+
+	//the formats that do not pad the cells render them
+	_, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+}
@@ -0,0 +1,70 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+//collapseRuns returns the data with each run of more than RenderOptions.CollapseRunsThreshold consecutive rows
+//with identical cells replaced by its first row, whose CollapseRunsField cell is annotated with the length of the run.
+//Cells are compared as they are formatted by the text format. Raw rows end runs.
+//data is returned as is if no field is set.
+func collapseRuns(data [][]interface{}, schema []SchemaField, options *RenderOptions) ([][]interface{}, error) {
+	if options.CollapseRunsField == "" {
+		return data, nil
+	}
+
+	annotated := getFieldIndex(schema, options.CollapseRunsField)
+	if annotated == -1 {
+		return nil, fmt.Errorf("could not find field with name %s to collapse runs", options.CollapseRunsField)
+	}
+
+	//formatted returns the cells of a row as they are rendered
+	formatted := func(row []interface{}) []string {
+		cells := make([]string, len(schema))
+		for i := range schema {
+			cells[i] = strings.Join(getCellLines(row[i], &schema[i]), "\n")
+		}
+		return cells
+	}
+
+	newData := [][]interface{}{}
+	for k := 0; k < len(data); {
+		if isRawRow(data[k]) {
+			newData = append(newData, data[k])
+			k++
+			continue
+		}
+
+		first := formatted(data[k])
+		n := 1
+		for k+n < len(data) && !isRawRow(data[k+n]) && equalStrings(first, formatted(data[k+n])) {
+			n++
+		}
+
+		if n > options.CollapseRunsThreshold {
+			newRow := make([]interface{}, len(data[k]))
+			copy(newRow, data[k])
+			newRow[annotated] = fmt.Sprintf("%s (×%d)", first[annotated], n)
+			newData = append(newData, newRow)
+		} else {
+			newData = append(newData, data[k:k+n]...)
+		}
+		k += n
+	}
+
+	return newData, nil
+}
+
+//equalStrings returns true if a and b hold the same strings in the same order
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
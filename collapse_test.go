@@ -0,0 +1,98 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableWithCollapsedRuns(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "SERVICE",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{"api", "ok"},
+		{"api", "ok"},
+		{"api", "ok"},
+		{"api", "ok"},
+		{"db", "failed"},
+		{"api", "ok"},
+		{"api", "ok"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("services", "", "", WithCollapsedRuns("STATUS", 2))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+---------+---------+\n" +
+			"| SERVICE | STATUS  |\n" +
+			"+---------+---------+\n" +
+			"| api     | ok (×4) |\n" +
+			"| db      | failed  |\n" +
+			"| api     | ok      |\n" +
+			"| api     | ok      |\n" +
+			"+---------+---------+\n" +
+			"Total: 7 services\n\n"))
+
+	s, err = table.RenderTable("services", "", "aligned", WithCollapsedRuns("SERVICE", 1))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("api (×4)"))
+	Expect(s).To(ContainSubstring("api (×2)"))
+
+	//machine readable formats keep all the rows
+	s, err = table.RenderTable("services", "", "json", WithCollapsedRuns("STATUS", 2))
+	Expect(err).To(BeNil())
+	var rows []map[string]interface{}
+	Expect(json.Unmarshal([]byte(s), &rows)).To(BeNil())
+	Expect(rows).To(HaveLen(7))
+
+	_, err = table.RenderTable("services", "", "", WithCollapsedRuns("UNKNOWN", 2))
+	Expect(err).NotTo(BeNil())
+}
+
+func TestCollapseRunsStopsAtRawRows(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "SERVICE",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{"api", "ok"},
+		{"api", "ok"},
+		{"api", "ok"},
+		{"api", "ok"},
+		{"db", "failed"},
+		{"api", "ok"},
+		{"api", "ok"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = append(table.Data[:2], append([][]interface{}{NewRawRow("-- maintenance --")}, table.Data[2:]...)...)
+
+	s, err := table.RenderTable("services", "", "", WithCollapsedRuns("STATUS", 1))
+	Expect(err).To(BeNil())
+	Expect(strings.Count(s, "(×2)")).To(Equal(3))
+	Expect(s).To(ContainSubstring("-- maintenance --"))
+	Expect(s).To(ContainSubstring("Total: 7 services"))
+}
@@ -0,0 +1,108 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+//getColumn returns the cells of one field, without the raw rows and with the value masks of the options applied
+func (t *Table) getColumn(fieldName string, options *RenderOptions) ([]interface{}, *SchemaField, error) {
+	index := getFieldIndex(t.Schema, fieldName)
+	if index == -1 {
+		return nil, nil, fmt.Errorf("could not find field with name %s", fieldName)
+	}
+
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := computeColumns(t.getData(fieldName), t.Schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema := t.getResolvedSchema()
+	data = withoutRawRows(data)
+	if err := checkCustomCells(data, schema); err != nil {
+		return nil, nil, err
+	}
+
+	data, err = applyValueMasks(applySanitizedUTF8(applyEmptyAsNil(data, options), options), schema, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cells := make([]interface{}, len(data))
+	for k, row := range data {
+		cells[k] = row[index]
+	}
+
+	return cells, &schema[index], nil
+}
+
+//getColumnCellString returns a cell formatted like in the text format, without colors
+func getColumnCellString(d interface{}, field *SchemaField) string {
+	return decolorize(strings.Join(getCellLines(d, field), "\n"))
+}
+
+//RenderColumn renders the cells of one field as a plain list without colors, each cell followed by
+//RenderOptions.ColumnSeparator, a new line if empty. Cells are formatted like in the text format.
+//With RenderOptions.ColumnHeader the list starts with the name of the field.
+//Nil cells are rendered as the FieldDefault of the field, empty if not set, unless RenderOptions.SkipNil is set.
+func (t *Table) RenderColumn(fieldName string, opts ...RenderOption) (string, error) {
+	options := newRenderOptions(opts...)
+
+	cells, field, err := t.getColumn(fieldName, options)
+	if err != nil {
+		return "", err
+	}
+
+	separator := options.ColumnSeparator
+	if separator == "" {
+		separator = "\n"
+	}
+
+	var sb strings.Builder
+	if options.ColumnHeader {
+		sb.WriteString(decolorize(field.FieldName))
+		sb.WriteString(separator)
+	}
+	for _, d := range cells {
+		if d == nil && options.SkipNil {
+			continue
+		}
+		sb.WriteString(getColumnCellString(d, field))
+		sb.WriteString(separator)
+	}
+
+	return sb.String(), nil
+}
+
+//DistinctValues returns the distinct cells of one field in the order they first appear, such as for shell completion.
+//Cells are formatted like in the text format, without colors. Nil cells are skipped.
+//At most limit values are returned, all of them if limit is 0.
+func (t *Table) DistinctValues(fieldName string, limit int) ([]string, error) {
+	cells, field, err := t.getColumn(fieldName, newRenderOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	seen := map[string]bool{}
+	for _, d := range cells {
+		if limit > 0 && len(values) == limit {
+			break
+		}
+		if d == nil {
+			continue
+		}
+		s := getColumnCellString(d, field)
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		values = append(values, s)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,108 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderColumn(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderColumn("LABEL")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("web-1\n\ndb-1\n"))
+
+	s, err = table.RenderColumn("LABEL", WithSkippedNil(), WithColumnSeparator("\x00"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("web-1\x00db-1\x00"))
+
+	s, err = table.RenderColumn("COST", WithColumnHeader(), WithColumnSeparator(","))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("COST,10.50,1.26,3.00,"))
+
+	s, err = table.RenderColumn("CREATED")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("2020-11-03\n2020-11-04\n2020-11-05\n"))
+
+	_, err = table.RenderColumn("UNKNOWN")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestDistinctValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = append(table.Data, []interface{}{"\x1b[31mweb-1\x1b[0m", 10.499, "2020-11-03"})
+
+	values, err := table.DistinctValues("LABEL", 0)
+	Expect(err).To(BeNil())
+	Expect(values).To(Equal([]string{"web-1", "db-1"}))
+
+	//values are formatted like in the text format
+	values, err = table.DistinctValues("COST", 0)
+	Expect(err).To(BeNil())
+	Expect(values).To(Equal([]string{"10.50", "1.26", "3.00"}))
+
+	values, err = table.DistinctValues("COST", 2)
+	Expect(err).To(BeNil())
+	Expect(values).To(Equal([]string{"10.50", "1.26"}))
+
+	table.Data = nil
+	values, err = table.DistinctValues("LABEL", 0)
+	Expect(err).To(BeNil())
+	Expect(values).To(BeEmpty())
+
+	_, err = table.DistinctValues("MISSING", 0)
+	Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,239 @@
+package tableformatter
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//getCompatRenders returns the positional render functions by the name of their golden file. The formats are the
+//ones of the release the golden files were written with, the other formats are covered by the snapshot tests.
+func getCompatRenders() map[string]func(t *Table) (string, error) {
+	renders := map[string]func(t *Table) (string, error){
+		"RenderTableAsJSON": func(t *Table) (string, error) { return t.RenderTableAsJSON() },
+		"RenderTableAsCSV":  func(t *Table) (string, error) { return t.RenderTableAsCSV() },
+		"RenderTableAsYAML": func(t *Table) (string, error) { return t.RenderTableAsYAML() },
+		"RenderTableFoldable_folded": func(t *Table) (string, error) {
+			return t.RenderTableFoldable("servers", "Servers:", "", 10)
+		},
+		"RenderTransposedTable": func(t *Table) (string, error) {
+			return t.RenderTransposedTable("server", "Server:", "")
+		},
+		"RenderTransposedTable_json": func(t *Table) (string, error) {
+			return t.RenderTransposedTable("server", "Server:", "json")
+		},
+		"RenderTransposedTableHumanReadable": func(t *Table) (string, error) {
+			return t.RenderTransposedTableHumanReadable("server", "Server:")
+		},
+	}
+
+	for _, format := range []string{"", "json", "csv", "yaml"} {
+		format := format
+		name := "RenderTable_" + format
+		if format == "" {
+			name = "RenderTable"
+		}
+		renders[name] = func(t *Table) (string, error) {
+			return t.RenderTable("servers", "Servers:", format)
+		}
+		renders["RenderTableFoldable_"+format] = func(t *Table) (string, error) {
+			return t.RenderTableFoldable("servers", "Servers:", format, 1000)
+		}
+	}
+	return renders
+}
+
+//intentionalDifferences are the ways in which the output differs on purpose from the golden files, which hold the
+//output of the first release and are never updated. Each is applied to the golden files of the renders it concerns.
+var intentionalDifferences = []struct {
+	description string
+	renders     []string
+	apply       func(s string) string
+}{
+	{
+		description: "nil interface cells are empty instead of <nil>",
+		renders:     []string{"RenderTable", "RenderTableFoldable_"},
+		apply:       strings.NewReplacer("| <nil> |", "|       |").Replace,
+	},
+	{
+		description: "nil interface cells are empty instead of <nil>",
+		renders:     []string{"RenderTableAsCSV", "RenderTable_csv", "RenderTableFoldable_csv"},
+		apply:       strings.NewReplacer(",<nil>\n", ",\n").Replace,
+	},
+	{
+		description: "slices in interface cells are printed with %v and padded like the other cells",
+		renders:     []string{"RenderTable", "RenderTableFoldable_"},
+		apply:       strings.NewReplacer("| [a      b     ]|", "| [a b] |").Replace,
+	},
+	{
+		description: "colored cells are padded to their visible width",
+		renders:     []string{"RenderTable", "RenderTableFoldable_"},
+		apply: strings.NewReplacer(
+			"| \x1b[31mtest\x1b[0m             |",
+			"| \x1b[31mtest\x1b[0m                      |",
+		).Replace,
+	},
+	{
+		description: "the folded cells format the numbers like the text format",
+		renders:     []string{"RenderTableFoldable_folded"},
+		apply: strings.NewReplacer(
+			"|   cost: 10.5                      |", `|   cost: "10.50"                   |`,
+			"|   cost: 1.257                     |", `|   cost: "1.26"                    |`,
+		).Replace,
+	},
+	{
+		description: "the folded cells leave out the colors, which would be quoted as escape sequences",
+		renders:     []string{"RenderTableFoldable_folded"},
+		apply:       strings.NewReplacer(`|   label: "\e[31mtest\e[0m"        |`, "|   label: test                     |").Replace,
+	},
+	{
+		description: "the transposed tables format the numbers with their FieldPrecision like the other tables",
+		renders:     []string{"RenderTransposedTable"},
+		apply:       strings.NewReplacer("| COST    | 10.5                      |", "| COST    | 10.50                     |").Replace,
+	},
+	{
+		description: "the transposed tables format the numbers with their FieldPrecision like the other tables",
+		renders:     []string{"RenderTransposedTableHumanReadable"},
+		apply:       strings.NewReplacer("\nCOST: 10.5\n", "\nCOST: 10.50\n").Replace,
+	},
+	{
+		description: "the human readable transposed tables indent the following lines of multi-line cells and leave nil cells empty",
+		renders:     []string{"RenderTransposedTableHumanReadable"},
+		apply:       strings.NewReplacer("\nsecond line\n", "\n       second line\n", "\nEXTRA: <nil>\n", "\nEXTRA: \n").Replace,
+	},
+}
+
+//getExpectedOutput returns the output expected for the render name given the content of its golden file
+func getExpectedOutput(golden string, name string) string {
+	for _, difference := range intentionalDifferences {
+		for _, render := range difference.renders {
+			if render == name {
+				golden = difference.apply(golden)
+			}
+		}
+	}
+	return golden
+}
+
+func TestRenderCompatibility(t *testing.T) {
+	RegisterTestingT(t)
+
+	//getTable returns the fixture rendered through the positional render functions. It only uses the field types
+	//of the release the golden files were written with.
+	getTable := func() *Table {
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+				FieldSize: 4,
+			},
+			{
+				FieldName: "LABEL",
+				FieldType: TypeString,
+			},
+			{
+				FieldName:      "COST",
+				FieldType:      TypeFloat,
+				FieldPrecision: 2,
+			},
+			{
+				FieldName: "CREATED",
+				FieldType: TypeDateTime,
+			},
+			{
+				FieldName: "ACTIVE",
+				FieldType: TypeBool,
+			},
+			{
+				FieldName: "EXTRA",
+				FieldType: TypeInterface,
+			},
+		}
+
+		data := [][]interface{}{
+			{2, "production-infrastructure\nsecond line", 10.5, "2020-11-03T10:00:00Z", true, nil},
+			{1, "\x1b[31mtest\x1b[0m", 1.257, "2020-11-02T09:30:00Z", false, []string{"a", "b"}},
+		}
+
+		return &Table{Data: data, Schema: schema}
+	}
+
+	for name, render := range getCompatRenders() {
+		s, err := render(getTable())
+		Expect(err).To(BeNil(), name)
+
+		golden, err := ioutil.ReadFile(filepath.Join("testdata", "compat", name+".golden"))
+		Expect(err).To(BeNil(), name)
+		Expect(s).To(Equal(getExpectedOutput(string(golden), name)), name)
+	}
+
+	//every difference changes its golden files
+	for _, difference := range intentionalDifferences {
+		for _, name := range difference.renders {
+			golden, err := ioutil.ReadFile(filepath.Join("testdata", "compat", name+".golden"))
+			Expect(err).To(BeNil(), name)
+			Expect(difference.apply(string(golden))).NotTo(Equal(string(golden)), difference.description)
+		}
+	}
+}
+func TestRenderWithOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+		{
+			FieldName: "EXTRA",
+			FieldType: TypeInterface,
+		},
+	}
+
+	data := [][]interface{}{
+		{2, "production-infrastructure\nsecond line", 10.5, "2020-11-03T10:00:00Z", true, nil},
+		{1, "\x1b[31mtest\x1b[0m", 1.257, "2020-11-02T09:30:00Z", false, []string{"a", "b"}},
+	}
+
+	table := &Table{Data: data, Schema: schema}
+
+	s, err := table.Render(WithTableName("servers"), WithTopLine("Servers:"), WithFormat("json-ordered"))
+	Expect(err).To(BeNil())
+	expected, err := table.RenderTable("servers", "Servers:", "json-ordered")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	s, err = table.Render(WithTableName("servers"), WithFoldAtLength(10))
+	Expect(err).To(BeNil())
+	expected, err = table.RenderTableFoldable("servers", "", "", 10)
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	s, err = table.Render(WithTableName("server"), WithTransposed())
+	Expect(err).To(BeNil())
+	expected, err = table.RenderTransposedTable("server", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+	Expect(s).To(ContainSubstring("| LABEL   | production-infrastructure |"))
+}
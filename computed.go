@@ -0,0 +1,195 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+//isComputed returns true if the cells of the field are computed from other fields
+func isComputed(field *SchemaField) bool {
+	return field.FieldCompute != nil
+}
+
+//hasComputedFields returns true if any of the fields of the schema is computed
+func hasComputedFields(schema []SchemaField) bool {
+	for i := range schema {
+		if isComputed(&schema[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+//getFieldIndex returns the position of the field with the given FieldID or, if there is none, FieldName or -1
+func getFieldIndex(schema []SchemaField, fieldName string) int {
+	for i := range schema {
+		if getFieldID(&schema[i]) == fieldName {
+			return i
+		}
+	}
+	for i, field := range schema {
+		if field.FieldName == fieldName {
+			return i
+		}
+	}
+	return -1
+}
+
+//getComputeOrder returns the positions of the computed fields in an order in which each field
+//is computed after the computed fields it depends on
+func getComputeOrder(schema []SchemaField) ([]int, error) {
+	const (
+		notVisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(schema))
+	var order []int
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("computed fields form a cycle: %s -> %s", strings.Join(path, " -> "), schema[i].FieldName)
+		}
+
+		state[i] = visiting
+		path = append(path, schema[i].FieldName)
+
+		for _, source := range schema[i].FieldComputeFrom {
+			j := getFieldIndex(schema, source)
+			if j == -1 {
+				return fmt.Errorf("could not find field with name %s used to compute field %s", source, schema[i].FieldName)
+			}
+			if isComputed(&schema[j]) {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[i] = visited
+		order = append(order, i)
+		return nil
+	}
+
+	for i := range schema {
+		if isComputed(&schema[i]) {
+			if err := visit(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return order, nil
+}
+
+//computeColumns returns the data with the cells of the computed fields inserted at their positions in the schema.
+//The rows of data hold only the cells of the fields that are not computed. If there are no computed fields data is returned as is.
+func computeColumns(data [][]interface{}, schema []SchemaField) ([][]interface{}, error) {
+	if !hasComputedFields(schema) {
+		return data, nil
+	}
+
+	order, err := getComputeOrder(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := withComputedCells(row, schema)
+		for _, i := range order {
+			values := make([]interface{}, len(schema[i].FieldComputeFrom))
+			for j, source := range schema[i].FieldComputeFrom {
+				values[j] = newRow[getFieldIndex(schema, source)]
+			}
+			newRow[i], err = computeCell(&schema[i], values)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		newData[k] = newRow
+	}
+
+	return newData, nil
+}
+
+//computeCell returns the cell of a computed field, or an error if its FieldCompute panics
+func computeCell(field *SchemaField, values []interface{}) (cell interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("could not compute field %s: %v", field.FieldName, r)
+		}
+	}()
+	return field.FieldCompute(values...), nil
+}
+
+//withComputedCells returns a row of data with nil cells inserted at the positions of the computed fields
+func withComputedCells(row []interface{}, schema []SchemaField) []interface{} {
+	newRow := make([]interface{}, len(schema))
+	n := 0
+	for i := range schema {
+		if isComputed(&schema[i]) {
+			continue
+		}
+		if n < len(row) {
+			newRow[i] = row[n]
+		}
+		n++
+	}
+	//keep cells that are not described by the schema
+	if n < len(row) {
+		newRow = append(newRow, row[n:]...)
+	}
+	return newRow
+}
+
+//Validate checks that every row has one cell for each field that is not computed
+//and that the computed fields can be computed
+func (t *Table) Validate() error {
+	if _, err := getComputeOrder(t.Schema); err != nil {
+		return err
+	}
+
+	cellCount := 0
+	for i := range t.Schema {
+		if !isComputed(&t.Schema[i]) {
+			cellCount++
+		}
+	}
+
+	for k, row := range t.Data {
+		if isRawRow(row) {
+			continue
+		}
+		if len(row) != cellCount {
+			return fmt.Errorf("row %d has %d cells, expected %d", k, len(row), cellCount)
+		}
+	}
+
+	return nil
+}
+
+//getMaterializedSchema returns a copy of the schema in which the computed fields are regular fields,
+//for data that already holds the computed cells
+func getMaterializedSchema(schema []SchemaField) []SchemaField {
+	newSchema := make([]SchemaField, len(schema))
+	copy(newSchema, schema)
+	for i := range newSchema {
+		newSchema[i].FieldCompute = nil
+		newSchema[i].FieldComputeFrom = nil
+	}
+	return newSchema
+}
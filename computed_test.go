@@ -0,0 +1,267 @@
+package tableformatter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func portRange(values ...interface{}) interface{} {
+	if values[0] == values[1] {
+		return fmt.Sprintf("%v", values[0])
+	}
+	return fmt.Sprintf("%v-%v", values[0], values[1])
+}
+
+func TestComputedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, 22, 22},
+		{2, 8000, 8080},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+	table := Table{Data: data, Schema: schema}
+	Expect(table.Validate()).To(BeNil())
+
+	s, err := table.RenderTable("rules", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| PORT      |"))
+	Expect(s).To(ContainSubstring("| 8000-8080 |"))
+	Expect(s).To(ContainSubstring("| 22        |"))
+
+	//the data of the caller is not changed
+	Expect(data[1]).To(HaveLen(3))
+
+	s, err = table.RenderTable("rules", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"PORT": "8000-8080"`))
+
+	s, err = table.RenderTransposedTable("rules", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| PORT | 22   |"))
+}
+
+func TestChainedComputedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	//RULE is before PORT so that it is computed after a field to its right
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "RULE",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"ID", "PORT"},
+			FieldCompute: func(values ...interface{}) interface{} {
+				return fmt.Sprintf("#%v:%v", values[0], values[1])
+			},
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{2, 8000, 8080}}, Schema: schema}
+
+	data, err := computeColumns(table.Data, table.Schema)
+	Expect(err).To(BeNil())
+	Expect(data[0]).To(Equal([]interface{}{2, 8000, 8080, "#2:8000-8080", "8000-8080"}))
+}
+
+func TestComputedFieldsErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:        "A",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"B"},
+			FieldCompute:     portRange,
+		},
+		{
+			FieldName:        "B",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"A"},
+			FieldCompute:     portRange,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{}}, Schema: schema}
+	err := table.Validate()
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("A -> B -> A"))
+
+	_, err = table.RenderTable("test", "", "")
+	Expect(err).NotTo(BeNil())
+
+	schema = []SchemaField{
+		{FieldName: "START", FieldType: TypeInt},
+		{FieldName: "PORT", FieldType: TypeString, FieldComputeFrom: []string{"START", "UNKNOWN"}, FieldCompute: portRange},
+	}
+	table = Table{Data: [][]interface{}{{22}}, Schema: schema}
+	err = table.Validate()
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("UNKNOWN"))
+
+	schema = []SchemaField{
+		{FieldName: "START", FieldType: TypeInt},
+		{FieldName: "END", FieldType: TypeInt},
+		{FieldName: "PORT", FieldType: TypeString, FieldComputeFrom: []string{"START", "END"}, FieldCompute: portRange},
+	}
+	table = Table{Data: [][]interface{}{{22, 22, "22"}}, Schema: schema}
+	Expect(table.Validate()).NotTo(BeNil())
+
+	//a panic of FieldCompute is returned as an error
+	schema[2].FieldCompute = func(values ...interface{}) interface{} {
+		panic("no port")
+	}
+	table = Table{Data: [][]interface{}{{22, 22}}, Schema: schema}
+	_, err = table.RenderTable("test", "", "json")
+	Expect(err).To(MatchError("could not compute field PORT: no port"))
+}
+
+func TestAdjustFieldSizesWithComputedFieldsErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+	schema[3].FieldComputeFrom = []string{"START", "UNKNOWN"}
+	data := [][]interface{}{{1, 22, 65535}}
+	table := Table{Data: data, Schema: schema}
+
+	err := table.AdjustFieldSizesErr()
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("UNKNOWN"))
+
+	//the other fields are adjusted anyway
+	Expect(table.Schema[0].FieldSize).To(Equal(len("ID") + 1))
+	Expect(table.Schema[2].FieldSize).To(Equal(len("65535") + 1))
+	Expect(table.Schema[3].FieldSize).To(Equal(len("PORT") + 1))
+	Expect(table.Data).To(Equal([][]interface{}{{1, 22, 65535}}))
+}
+
+func TestSortByComputedField(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, 8000, 8080},
+		{2, 22, 22},
+		{3, 443, 443},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+	err := TableSorter(schema).OrderBy("PORT").SortErr(data)
+	Expect(err).To(BeNil())
+	Expect(data).To(Equal([][]interface{}{
+		{2, 22, 22},
+		{3, 443, 443},
+		{1, 8000, 8080},
+	}))
+}
+
+func TestSaveComputedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+	table := Table{Data: [][]interface{}{{2, 8000, 8080}}, Schema: schema}
+
+	var buf bytes.Buffer
+	Expect(table.Save(&buf)).To(BeNil())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Data[0]).To(Equal([]interface{}{2, 8000, 8080, "8000-8080"}))
+	Expect(loaded.Schema[3].FieldCompute).To(BeNil())
+}
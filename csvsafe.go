@@ -0,0 +1,71 @@
+package tableformatter
+
+import "strings"
+
+//defaultFormulaEscapePrefix is prepended to cells that spreadsheets would interpret as formulas
+const defaultFormulaEscapePrefix = "'"
+
+//formulaTriggers are the first characters that make spreadsheets interpret a cell as a formula
+const formulaTriggers = "=+-@\t\r"
+
+//CSVOptions holds the settings of the csv renderer
+type CSVOptions struct {
+	//EscapeFormulas prefixes the cells, other than the numbers of numeric fields, that start with =, +, -, @, tab or carriage return
+	//so that spreadsheets do not interpret them as formulas
+	EscapeFormulas bool
+	//FormulaEscapePrefix is prepended to the escaped cells, a single quote if empty. A tab is also accepted by most spreadsheets.
+	FormulaEscapePrefix string
+	//Strict4180 separates the records with CRLF as required by RFC 4180, without a separator after the last record.
+	//The carriage returns and new lines of the cells are written as they are, inside quotes.
+	Strict4180 bool
+}
+
+//NewSafeCSVOptions returns the options used by RenderTableAsSafeCSV, with formulas escaped
+func NewSafeCSVOptions() *CSVOptions {
+	return &CSVOptions{
+		EscapeFormulas:      true,
+		FormulaEscapePrefix: defaultFormulaEscapePrefix,
+	}
+}
+
+//RenderTableAsSafeCSV renders the table as csv that can be opened in a spreadsheet without running formulas
+//held by the cells. If options is nil NewSafeCSVOptions is used. Raw rows are skipped.
+func (t *Table) RenderTableAsSafeCSV(options *CSVOptions) (string, error) {
+	if options == nil {
+		options = NewSafeCSVOptions()
+	}
+
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return "", err
+	}
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		return "", err
+	}
+
+	return getTableAsCSVString(withoutRawRows(data), t.Schema, options)
+}
+
+//isNumericCell returns true if the cell is a number of a numeric field
+func isNumericCell(d interface{}, field *SchemaField) bool {
+	switch d.(type) {
+	case int:
+		return field.FieldType == TypeInt
+	case float64:
+		return field.FieldType == TypeFloat
+	default:
+		return false
+	}
+}
+
+//escapeFormula prefixes the cell with prefix if a spreadsheet would interpret it as a formula
+func escapeFormula(s string, prefix string) string {
+	if s == "" || !strings.ContainsRune(formulaTriggers, rune(s[0])) {
+		return s
+	}
+	if prefix == "" {
+		prefix = defaultFormulaEscapePrefix
+	}
+	return prefix + s
+}
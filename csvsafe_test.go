@@ -0,0 +1,182 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableAsSafeCSV(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:      "BALANCE",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName: "DESCRIPTION",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{-1, -2.5, "=HYPERLINK(\"http://evil\")"},
+		{2, 3.0, "+1"},
+		{3, 1.0, "-1"},
+		{4, 1.0, "@SUM(A1)"},
+		{5, 1.0, "plain text"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTableAsSafeCSV(nil)
+	Expect(err).To(BeNil())
+
+	m, err := CSVUnmarshal(s)
+	Expect(err).To(BeNil())
+	Expect(m[1]).To(Equal([]string{"-1", "-2.500000", "'=HYPERLINK(\"http://evil\")"}))
+	Expect(m[2][2]).To(Equal("'+1"))
+	Expect(m[3][2]).To(Equal("'-1"))
+	Expect(m[4][2]).To(Equal("'@SUM(A1)"))
+	Expect(m[5][2]).To(Equal("plain text"))
+
+	s, err = table.RenderTableAsSafeCSV(&CSVOptions{EscapeFormulas: true, FormulaEscapePrefix: "\t"})
+	Expect(err).To(BeNil())
+	m, err = CSVUnmarshal(s)
+	Expect(err).To(BeNil())
+	Expect(m[2][2]).To(Equal("\t+1"))
+
+	//opt out
+	s, err = table.RenderTableAsSafeCSV(&CSVOptions{})
+	Expect(err).To(BeNil())
+	m, err = CSVUnmarshal(s)
+	Expect(err).To(BeNil())
+	Expect(m[2][2]).To(Equal("+1"))
+
+	//the csv format is not changed
+	s, err = table.RenderTable("", "", "csv")
+	Expect(err).To(BeNil())
+	m, err = CSVUnmarshal(s)
+	Expect(err).To(BeNil())
+	Expect(m[2][2]).To(Equal("+1"))
+}
+
+//parseRFC4180 parses s as RFC 4180 csv: records separated by CRLF, without a separator after the last one,
+//and fields with a comma, a quote, a CR or a LF quoted. The CR and LF of quoted fields are kept as they are.
+func parseRFC4180(s string) ([][]string, error) {
+	records := [][]string{}
+	record := []string{}
+	var field strings.Builder
+	i := 0
+	for {
+		if i < len(s) && s[i] == '"' {
+			i++
+			for {
+				if i >= len(s) {
+					return nil, fmt.Errorf("unterminated quoted field")
+				}
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						field.WriteByte('"')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				field.WriteByte(s[i])
+				i++
+			}
+		} else {
+			for i < len(s) && s[i] != ',' && s[i] != '\r' && s[i] != '"' {
+				if s[i] == '\n' {
+					return nil, fmt.Errorf("bare LF at %d", i)
+				}
+				field.WriteByte(s[i])
+				i++
+			}
+		}
+
+		record = append(record, field.String())
+		field.Reset()
+
+		switch {
+		case i == len(s):
+			return append(records, record), nil
+		case s[i] == ',':
+			i++
+		case strings.HasPrefix(s[i:], "\r\n"):
+			if i+2 == len(s) {
+				return nil, fmt.Errorf("separator after the last record")
+			}
+			records = append(records, record)
+			record = []string{}
+			i += 2
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", s[i], i)
+		}
+	}
+}
+
+func TestCSVStrict4180(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "NOTE",
+			FieldType: TypeString,
+		},
+	}
+
+	notes := []string{
+		`say "hello"`,
+		"a, b",
+		"first\r\nsecond",
+		"lone\nnew line",
+		"lone\rcarriage return",
+		"  padded  ",
+		"",
+		"plain",
+	}
+	data := [][]interface{}{}
+	for k, note := range notes {
+		data = append(data, []interface{}{k, note})
+	}
+
+	s, err := getTableAsCSVString(data, schema, &CSVOptions{Strict4180: true})
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID,NOTE\r\n0,\"say \"\"hello\"\"\"\r\n"))
+	Expect(s).To(HaveSuffix("\r\n7,plain"))
+	Expect(s).To(ContainSubstring("\"first\r\nsecond\""))
+
+	records, err := parseRFC4180(s)
+	Expect(err).To(BeNil())
+	Expect(records).To(HaveLen(len(notes) + 1))
+	Expect(records[0]).To(Equal([]string{"ID", "NOTE"}))
+	for k, note := range notes {
+		Expect(records[k+1]).To(Equal([]string{strconv.Itoa(k), note}))
+	}
+
+	//the default output ends each record with LF
+	s, err = getTableAsCSVString(data[7:], schema, &CSVOptions{})
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,NOTE\n7,plain\n"))
+
+	table := Table{Data: data[7:], Schema: schema}
+	s, err = table.RenderTable("notes", "", "csv", WithCSVOptions(CSVOptions{Strict4180: true}))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,NOTE\r\n7,plain"))
+}
@@ -0,0 +1,136 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//DescribeView is a single object followed by named child tables, such as an instance array and its firewall rules
+type DescribeView struct {
+	//Object is the described struct. It is ignored if Table is set.
+	Object interface{}
+	//Table holds the described object as the first row of a table
+	Table *Table
+	//Children are rendered after the object in order
+	Children []DescribeChild
+}
+
+//DescribeChild is a named child table of a DescribeView
+type DescribeChild struct {
+	//Name is used upper cased as the header of the child table in the text format
+	//and lower camel cased as its key in the json and yaml formats
+	Name  string
+	Table *Table
+}
+
+//Render renders the object and its child tables.
+//The text format renders the object in a human readable way followed by each child table under a NAME: header.
+//The json and yaml formats render a single document {object: {...}, childName: [...]}.
+func (v *DescribeView) Render(format string) (string, error) {
+	name, err := resolveFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	switch name {
+	case "json":
+		doc, err := v.getDocument(getRowAsJSONMap)
+		if err != nil {
+			return "", err
+		}
+		ret, err := json.MarshalIndent(doc, "", "\t")
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	case "yaml":
+		doc, err := v.getDocument(getRowAsYAMLMap)
+		if err != nil {
+			return "", err
+		}
+		ret, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	case "csv", "json-ordered", "yaml-docs":
+		return "", fmt.Errorf("format %s is not supported by the describe view", format)
+	default:
+		var sb strings.Builder
+
+		if v.Table != nil {
+			ret, err := v.Table.RenderTransposedTableHumanReadable("", "")
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(ret)
+		} else {
+			ret, err := RenderRawObject(v.Object, "", "")
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(ret)
+		}
+
+		for _, child := range v.Children {
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%s:\n", strings.ToUpper(child.Name)))
+			ret, err := child.Table.RenderTable(strings.ToLower(child.Name), "", "")
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(ret)
+		}
+
+		return sb.String(), nil
+	}
+}
+
+//getDocument returns the object and the rows of the child tables as a map, with the rows converted by rowToMap
+func (v *DescribeView) getDocument(rowToMap func(row []interface{}, schema []SchemaField) map[string]interface{}) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+
+	if v.Table != nil {
+		data, schema, err := v.Table.getExportedData()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			doc["object"] = rowToMap(data[0], schema)
+		}
+	} else {
+		doc["object"] = v.Object
+	}
+
+	for _, child := range v.Children {
+		data, schema, err := child.Table.getExportedData()
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]interface{}, len(data))
+		for k, row := range data {
+			rows[k] = rowToMap(row, schema)
+		}
+		doc[getYAMLKey(child.Name)] = rows
+	}
+
+	return doc, nil
+}
+
+//getExportedData returns the rows of the table rendered by the machine readable formats, with the computed cells
+//and without the raw rows and the hidden fields, and the schema of the other fields with the resolved time format
+func (t *Table) getExportedData() ([][]interface{}, []SchemaField, error) {
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	schema := t.getResolvedSchema()
+	return withoutHiddenFields(withoutRawRows(data), schema), getVisibleSchema(schema), nil
+}
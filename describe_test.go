@@ -0,0 +1,127 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+func TestDescribeViewText(t *testing.T) {
+	RegisterTestingT(t)
+
+	view := DescribeView{
+		Table: &Table{
+			Data: [][]interface{}{{10, "web"}},
+			Schema: []SchemaField{
+				{
+					FieldName: "ID",
+					FieldType: TypeInt,
+				},
+				{
+					FieldName: "Label",
+					FieldType: TypeString,
+				},
+			},
+		},
+		Children: []DescribeChild{
+			{
+				Name: "Firewall rules",
+				Table: &Table{
+					Data: [][]interface{}{{"tcp", "22"}, {"tcp", "443"}},
+					Schema: []SchemaField{
+						{
+							FieldName: "PROTOCOL",
+							FieldType: TypeString,
+						},
+						{
+							FieldName: "PORT",
+							FieldType: TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s, err := view.Render("")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`ID: 10
+Label: web
+
+FIREWALL RULES:
++----------+------+
+| PROTOCOL | PORT |
++----------+------+
+| tcp      | 22   |
+| tcp      | 443  |
++----------+------+
+Total: 2 firewall rules
+
+`))
+}
+
+func TestDescribeViewJSONAndYAML(t *testing.T) {
+	RegisterTestingT(t)
+
+	view := DescribeView{
+		Table: &Table{
+			Data: [][]interface{}{{10, "web"}},
+			Schema: []SchemaField{
+				{
+					FieldName: "ID",
+					FieldType: TypeInt,
+				},
+				{
+					FieldName: "Label",
+					FieldType: TypeString,
+				},
+			},
+		},
+		Children: []DescribeChild{
+			{
+				Name: "Firewall rules",
+				Table: &Table{
+					Data: [][]interface{}{{"tcp", "22"}, {"tcp", "443"}},
+					Schema: []SchemaField{
+						{
+							FieldName: "PROTOCOL",
+							FieldType: TypeString,
+						},
+						{
+							FieldName: "PORT",
+							FieldType: TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s, err := view.Render("json")
+	Expect(err).To(BeNil())
+
+	var doc map[string]interface{}
+	Expect(json.Unmarshal([]byte(s), &doc)).To(BeNil())
+	Expect(doc["object"]).To(Equal(map[string]interface{}{"ID": 10.0, "Label": "web"}))
+	Expect(doc["firewallRules"]).To(HaveLen(2))
+
+	s, err = view.Render("yaml")
+	Expect(err).To(BeNil())
+
+	var yamlDoc map[string]interface{}
+	Expect(yaml.Unmarshal([]byte(s), &yamlDoc)).To(BeNil())
+	Expect(yamlDoc["object"]).To(Equal(map[interface{}]interface{}{"id": 10, "label": "web"}))
+	Expect(yamlDoc["firewallRules"]).To(HaveLen(2))
+
+	//a struct can be described instead of a table
+	view.Table = nil
+	view.Object = struct{ Name string }{"web"}
+	s, err = view.Render("json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"Name": "web"`))
+
+	_, err = view.Render("csv")
+	Expect(err).NotTo(BeNil())
+}
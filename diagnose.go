@@ -0,0 +1,227 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//Severity is the severity of a Problem
+type Severity string
+
+const (
+	//SeverityError is a problem that breaks rendering or sorting
+	SeverityError Severity = "error"
+	//SeverityWarning is a problem that is likely a mistake but does not break rendering
+	SeverityWarning Severity = "warning"
+)
+
+//Problem is an issue found by DiagnoseTable
+type Problem struct {
+	Severity Severity
+	//Row is the index of the row in Data or -1 if the problem is in the schema
+	Row int
+	//Column is the name of the field or empty if the problem is not in a specific field
+	Column  string
+	Message string
+}
+
+//DiagnoseTable returns all the problems found in the schema and the data of a table
+func DiagnoseTable(t Table) []Problem {
+	problems := []Problem{}
+
+	add := func(severity Severity, row int, column string, format string, a ...interface{}) {
+		problems = append(problems, Problem{
+			Severity: severity,
+			Row:      row,
+			Column:   column,
+			Message:  fmt.Sprintf(format, a...),
+		})
+	}
+
+	seen := map[string]bool{}
+	seenIDs := map[string]bool{}
+	for _, field := range t.Schema {
+		if seen[field.FieldName] {
+			add(SeverityError, -1, field.FieldName, "duplicate field name")
+		}
+		seen[field.FieldName] = true
+
+		if field.FieldID != "" {
+			if seenIDs[field.FieldID] {
+				add(SeverityError, -1, field.FieldName, "duplicate field id %s", field.FieldID)
+			}
+			seenIDs[field.FieldID] = true
+		}
+
+		if _, ok := fieldTypeHandlers[field.FieldType]; !ok {
+			add(SeverityError, -1, field.FieldName, "unknown field type %d", field.FieldType)
+		}
+		if field.FieldSize < 0 {
+			add(SeverityError, -1, field.FieldName, "negative field size %d", field.FieldSize)
+		}
+		if field.FieldSize > 0 && field.FieldSize < VisibleWidth(field.FieldName) {
+			add(SeverityWarning, -1, field.FieldName, "field size %d is smaller than the header", field.FieldSize)
+		}
+		if field.FieldPrecision < 0 {
+			add(SeverityError, -1, field.FieldName, "negative field precision %d", field.FieldPrecision)
+		}
+		if field.FieldSize > DefaultMaxFieldSize {
+			add(SeverityWarning, -1, field.FieldName, "field size %d is clamped to %d", field.FieldSize, DefaultMaxFieldSize)
+		}
+		if field.FieldPrecision > DefaultMaxFieldPrecision {
+			add(SeverityWarning, -1, field.FieldName, "field precision %d is clamped to %d", field.FieldPrecision, DefaultMaxFieldPrecision)
+		}
+	}
+
+	if _, err := getComputeOrder(t.Schema); err != nil {
+		add(SeverityError, -1, "", "%s", err)
+	}
+
+	//the fields that have cells in the rows of Data
+	schema := t.getResolvedSchema()
+	var fields []SchemaField
+	for _, field := range schema {
+		if !isComputed(&field) {
+			fields = append(fields, field)
+		}
+	}
+
+	invalidUTF8 := make([]int, len(fields))
+	invalidUTF8RawRows := 0
+	for k, row := range t.Data {
+		if isRawRow(row) {
+			if isInvalidUTF8Cell(row[0]) {
+				invalidUTF8RawRows++
+			}
+			continue
+		}
+
+		if len(row) != len(fields) {
+			add(SeverityError, k, "", "row has %d cells, expected %d", len(row), len(fields))
+		}
+
+		for i := 0; i < len(row) && i < len(fields); i++ {
+			field := fields[i]
+			d := row[i]
+
+			if d == nil {
+				if field.FieldType != TypeInterface && field.FieldDefault == "" {
+					add(SeverityWarning, k, field.FieldName, "nil cell in a field without a default")
+				}
+				continue
+			}
+
+			if !hasCellType(d, &field) {
+				add(SeverityError, k, field.FieldName, "%T cell in a %s field", d, fieldTypeNames[field.FieldType])
+				continue
+			}
+
+			if isInvalidUTF8Cell(d) {
+				invalidUTF8[i]++
+			}
+
+			if s, ok := d.(string); ok && field.FieldNormalizeUnits {
+				if _, ok := parseUnitValue(s); !ok {
+					add(SeverityWarning, k, field.FieldName, "cannot parse %q as a value with a unit", s)
+				}
+			}
+
+			if field.FieldType == TypeDateTime {
+				if _, ok := parseDateTimeCell(d, &field); !ok {
+					add(SeverityWarning, k, field.FieldName, "cannot parse %v with the layouts %q", d, strings.Join(getTimeLayouts(&field), timeLayoutSeparator))
+				}
+			}
+		}
+	}
+
+	for i, n := range invalidUTF8 {
+		if n > 0 {
+			add(SeverityWarning, -1, fields[i].FieldName, "%d cells are not valid UTF-8, invalid bytes are rendered as U+FFFD", n)
+		}
+	}
+	if invalidUTF8RawRows > 0 {
+		add(SeverityWarning, -1, "", "%d raw rows are not valid UTF-8, invalid bytes are rendered as U+FFFD", invalidUTF8RawRows)
+	}
+
+	return problems
+}
+
+//hasCellType returns true if the cell holds the go type expected by the type of the field
+func hasCellType(d interface{}, field *SchemaField) bool {
+	if _, ok := d.(Cell); ok {
+		return true
+	}
+	if c, ok := d.(SeverityCell); ok {
+		return c.Value == nil || hasCellType(c.Value, field)
+	}
+	switch field.FieldType {
+	case TypeInt:
+		_, ok := d.(int)
+		return ok
+	case TypeString:
+		switch d.(type) {
+		case string, UnitValue:
+			return true
+		}
+		return false
+	case TypeFloat:
+		_, ok := d.(float64)
+		return ok
+	case TypeDateTime:
+		//numbers are accepted by the epoch layouts
+		switch d.(type) {
+		case string, time.Time, *time.Time, int, int64, float64:
+			return true
+		}
+		return false
+	case TypeBool:
+		_, ok := d.(bool)
+		return ok
+	case TypeDuration:
+		_, ok := parseDuration(d)
+		return ok
+	case TypeBytes:
+		_, ok := getNumber(d)
+		return ok
+	case TypeVersion, TypeIP:
+		_, ok := d.(string)
+		return ok
+	default:
+		return true
+	}
+}
+
+//ProblemsToTable returns the problems as a table that can be rendered like any other table
+func ProblemsToTable(problems []Problem) Table {
+	schema := []SchemaField{
+		{
+			FieldName: "SEVERITY",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:        "ROW",
+			FieldType:        TypeInterface,
+			FieldDescription: "the index of the row, empty for problems of the schema",
+		},
+		{
+			FieldName: "COLUMN",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "MESSAGE",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for _, problem := range problems {
+		var row interface{}
+		if problem.Row >= 0 {
+			row = problem.Row
+		}
+		data = append(data, []interface{}{string(problem.Severity), row, problem.Column, problem.Message})
+	}
+
+	return Table{Data: data, Schema: schema}
+}
@@ -0,0 +1,66 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDiagnoseTable(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 2,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "ID",
+			FieldType: TypeFloat,
+			FieldSize: -1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "test", "2020-01-01T00:00:00Z", 1.0},
+		{"2", nil, "yesterday", 2.0},
+		{3, "test", time.Now()},
+		NewRawRow("raw"),
+	}
+
+	problems := DiagnoseTable(Table{Data: data, Schema: schema})
+
+	Expect(problems).To(ConsistOf(
+		Problem{SeverityWarning, -1, "LABEL", "field size 2 is smaller than the header"},
+		Problem{SeverityError, -1, "ID", "duplicate field name"},
+		Problem{SeverityError, -1, "ID", "negative field size -1"},
+		Problem{SeverityError, 1, "ID", "string cell in a int field"},
+		Problem{SeverityWarning, 1, "LABEL", "nil cell in a field without a default"},
+		Problem{SeverityWarning, 1, "CREATED", `cannot parse yesterday with the layouts "2006-01-02T15:04:05Z"`},
+		Problem{SeverityError, 2, "", "row has 3 cells, expected 4"},
+	))
+
+	table := ProblemsToTable(problems[:1])
+	s, err := table.RenderTable("problems", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+----------+-----+--------+-----------------------------------------+
+| SEVERITY | ROW | COLUMN | MESSAGE                                 |
++----------+-----+--------+-----------------------------------------+
+| warning  |     | LABEL  | field size 2 is smaller than the header |
++----------+-----+--------+-----------------------------------------+
+Total: 1 problems
+
+`))
+
+	Expect(DiagnoseTable(Table{Data: [][]interface{}{{1}}, Schema: schema[:1]})).To(BeEmpty())
+}
@@ -0,0 +1,93 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	//DurationFormatAge is a FieldFormat of duration fields printing the durations with at most two units, such as 3h12m
+	DurationFormatAge = "age"
+	//DurationFormatHuman is a FieldFormat of duration fields printing the durations with all their units down to
+	//the second, such as 1d 2h 3m 4s
+	DurationFormatHuman = "human"
+)
+
+//parseDuration returns the duration held by a duration cell: a time.Duration, an int or int64 number of seconds
+//or a string accepted by time.ParseDuration, such as 93784s or 1h30m
+func parseDuration(d interface{}) (time.Duration, bool) {
+	switch v := d.(type) {
+	case time.Duration:
+		return v, true
+	case int:
+		return time.Duration(v) * time.Second, true
+	case int64:
+		return time.Duration(v) * time.Second, true
+	case string:
+		duration, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return 0, false
+		}
+		return duration, true
+	}
+	return 0, false
+}
+
+//formatHumanDuration formats a duration as days, hours, minutes and seconds separated by spaces, leaving out the
+//units that are zero, such as 1d 2h 3m 4s or 2h 4s. Durations shorter than a second are printed as 0s.
+func formatHumanDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	seconds := int64(d / time.Second)
+	if seconds == 0 {
+		return "0s"
+	}
+
+	var parts []string
+	for _, unit := range []struct {
+		seconds int64
+		suffix  string
+	}{
+		{24 * 60 * 60, "d"},
+		{60 * 60, "h"},
+		{60, "m"},
+		{1, "s"},
+	} {
+		if n := seconds / unit.seconds; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.suffix))
+			seconds %= unit.seconds
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+//withDurationsInSeconds returns the data with the cells of the duration fields replaced by their number of seconds
+//if RenderOptions.DurationsInSeconds is set. The cells that are not durations are kept.
+func withDurationsInSeconds(data [][]interface{}, schema []SchemaField, options *RenderOptions) [][]interface{} {
+	if !options.DurationsInSeconds {
+		return data
+	}
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		newRow := make([]interface{}, len(row))
+		copy(newRow, row)
+		for i := range schema {
+			if i >= len(row) || schema[i].FieldType != TypeDuration {
+				continue
+			}
+			d := row[i]
+			if c, ok := d.(Cell); ok {
+				d = c.Value
+			}
+			if duration, ok := parseDuration(d); ok {
+				newRow[i] = duration.Seconds()
+			}
+		}
+		newData[k] = newRow
+	}
+	return newData
+}
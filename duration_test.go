@@ -0,0 +1,107 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseDuration(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []struct {
+		cell     interface{}
+		duration time.Duration
+		ok       bool
+	}{
+		{90 * time.Minute, 90 * time.Minute, true},
+		{int64(93784), 93784 * time.Second, true},
+		{120, 2 * time.Minute, true},
+		{"93784s", 93784 * time.Second, true},
+		{" 1h30m ", 90 * time.Minute, true},
+		{"forever", 0, false},
+		{1.5, 0, false},
+		{nil, 0, false},
+	} {
+		duration, ok := parseDuration(c.cell)
+		Expect(ok).To(Equal(c.ok), "%v", c.cell)
+		Expect(duration).To(Equal(c.duration), "%v", c.cell)
+	}
+}
+
+func TestFormatHumanDuration(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(formatHumanDuration(93784 * time.Second)).To(Equal("1d 2h 3m 4s"))
+	Expect(formatHumanDuration(2*time.Hour + 4*time.Second)).To(Equal("2h 4s"))
+	Expect(formatHumanDuration(48 * time.Hour)).To(Equal("2d"))
+	Expect(formatHumanDuration(500 * time.Millisecond)).To(Equal("0s"))
+	Expect(formatHumanDuration(-61 * time.Second)).To(Equal("-1m 1s"))
+}
+
+func TestDurationCellsOfMixedTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:   "UPTIME",
+			FieldType:   TypeDuration,
+			FieldFormat: DurationFormatHuman,
+		},
+	}
+	data := [][]interface{}{
+		{1, "93784s"},
+		{2, int64(600)},
+		{3, 90 * time.Minute},
+		{4, "unknown"},
+		{5, nil},
+	}
+	table := Table{Data: data, Schema: schema}
+
+	TableSorter(schema).OrderBy("UPTIME").Sort(data)
+	Expect(getIDs(data)[:3]).To(Equal([]int{2, 3, 1}))
+	Expect(getIDs(data)[3:]).To(ConsistOf(4, 5))
+
+	table.AdjustFieldSizes()
+	Expect(table.Schema[1].FieldSize).To(Equal(len("1d 2h 3m 4s") + 1))
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID,UPTIME\n2,10m\n3,1h 30m\n1,1d 2h 3m 4s\n"))
+
+	s, err = table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 1  | 1d 2h 3m 4s |"))
+
+	//json and yaml write the cells as they are unless the seconds are requested
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"UPTIME": "93784s"`))
+	Expect(s).To(ContainSubstring(`"UPTIME": 5400000000000`))
+	s, err = table.Render(WithFormat("json"), WithDurationsInSeconds())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"UPTIME": 93784`))
+	Expect(s).To(ContainSubstring(`"UPTIME": 5400`))
+	Expect(s).To(ContainSubstring(`"UPTIME": "unknown"`))
+	s, err = table.Render(WithFormat("json-ordered"), WithDurationsInSeconds())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"UPTIME": 93784`))
+	s, err = table.Render(WithFormat("yaml"), WithDurationsInSeconds())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("uptime: 600\n"))
+	s, err = table.Render(WithFormat("yaml-docs"), WithDurationsInSeconds())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("uptime: 5400\n"))
+	Expect(table.Data[0][1]).To(Equal(int64(600)))
+
+	//without a FieldFormat the durations are printed with Duration.String()
+	table.Schema[1].FieldFormat = ""
+	s, err = table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID,UPTIME\n2,10m0s\n3,1h30m0s\n1,26h3m4s\n"))
+}
@@ -0,0 +1,35 @@
+package tableformatter
+
+//Nil and empty string cells are rendered as follows:
+//
+//	format                  nil cell        empty string cell
+//	text, aligned, csv      FieldDefault    empty
+//	json, yaml              null            ""
+//
+//This holds for every field type. With RenderOptions.EmptyAsNil empty string cells are rendered like nil cells.
+
+//applyEmptyAsNil returns a copy of data with the empty string cells replaced by nil if RenderOptions.EmptyAsNil is set.
+//data is returned as is otherwise.
+func applyEmptyAsNil(data [][]interface{}, options *RenderOptions) [][]interface{} {
+	if !options.EmptyAsNil {
+		return data
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, len(row))
+		for i, d := range row {
+			if s, ok := d.(string); !ok || s != "" {
+				newRow[i] = d
+			}
+		}
+		newData[k] = newRow
+	}
+
+	return newData
+}
@@ -0,0 +1,149 @@
+package tableformatter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"gopkg.in/yaml.v2"
+)
+
+//getRenderedValueCell renders a table with an ID and a VALUE field holding a single row
+//and extracts the VALUE cell from the output. The text formats return strings,
+//the machine readable formats return the decoded value.
+func getRenderedValueCell(table Table, format string, opts ...RenderOption) (interface{}, error) {
+	s, err := table.RenderTable("items", "", format, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "":
+		for _, line := range strings.Split(s, "\n") {
+			if strings.HasPrefix(line, "| 1 ") {
+				return strings.TrimSpace(strings.Split(line, "|")[2]), nil
+			}
+		}
+	case "aligned":
+		for _, line := range strings.Split(s, "\n") {
+			if strings.HasPrefix(line, "1 ") || line == "1" {
+				return strings.TrimSpace(strings.TrimPrefix(line, "1")), nil
+			}
+		}
+	case "csv":
+		records, err := csv.NewReader(strings.NewReader(s)).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		return records[1][1], nil
+	case "json", "json-ordered":
+		var rows []map[string]interface{}
+		if err := json.Unmarshal([]byte(s), &rows); err != nil {
+			return nil, err
+		}
+		return rows[0]["VALUE"], nil
+	case "yaml":
+		var rows []map[string]interface{}
+		if err := yaml.Unmarshal([]byte(s), &rows); err != nil {
+			return nil, err
+		}
+		return rows[0]["value"], nil
+	case "yaml-docs":
+		var row map[string]interface{}
+		if err := yaml.Unmarshal([]byte(s), &row); err != nil {
+			return nil, err
+		}
+		return row["value"], nil
+	}
+
+	return nil, fmt.Errorf("could not find the row in %q", s)
+}
+
+//equalCell is Equal that also accepts an expected nil
+func equalCell(expected interface{}) types.GomegaMatcher {
+	if expected == nil {
+		return BeNil()
+	}
+	return Equal(expected)
+}
+
+func TestEmptyStringAndNilMatrix(t *testing.T) {
+	RegisterTestingT(t)
+
+	fieldTypes := []int{TypeInt, TypeString, TypeFloat, TypeDateTime, TypeInterface, TypeBool, TypeDuration, TypeVersion, TypeIP, TypeBytes}
+
+	//the expected VALUE cell for a nil cell and an empty string cell, by format
+	expected := []struct {
+		format string
+		nil    interface{}
+		empty  interface{}
+	}{
+		{"", "-", ""},
+		{"aligned", "-", ""},
+		{"csv", "-", ""},
+		{"json", nil, ""},
+		{"json-ordered", nil, ""},
+		{"yaml", nil, ""},
+		{"yaml-docs", nil, ""},
+	}
+
+	for _, fieldType := range fieldTypes {
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName:    "VALUE",
+				FieldType:    fieldType,
+				FieldDefault: "-",
+			},
+		}
+
+		for _, e := range expected {
+			for _, emptyAsNil := range []bool{false, true} {
+				var opts []RenderOption
+				if emptyAsNil {
+					opts = append(opts, WithEmptyAsNil())
+				}
+				description := fmt.Sprintf("type %d format %q emptyAsNil %v", fieldType, e.format, emptyAsNil)
+
+				table := Table{Data: [][]interface{}{{1, nil}}, Schema: schema}
+				cell, err := getRenderedValueCell(table, e.format, opts...)
+				Expect(err).To(BeNil(), description)
+				Expect(cell).To(equalCell(e.nil), "nil cell, "+description)
+
+				table = Table{Data: [][]interface{}{{1, ""}}, Schema: schema}
+				cell, err = getRenderedValueCell(table, e.format, opts...)
+				Expect(err).To(BeNil(), description)
+				if emptyAsNil {
+					Expect(cell).To(equalCell(e.nil), "empty cell, "+description)
+				} else {
+					Expect(cell).To(equalCell(e.empty), "empty cell, "+description)
+				}
+			}
+		}
+	}
+}
+
+func TestEmptyAsNilKeepsTheData(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{{1, ""}}
+	table := Table{
+		Data: data,
+		Schema: []SchemaField{
+			{FieldName: "ID", FieldType: TypeInt},
+			{FieldName: "NAME", FieldType: TypeString, FieldDefault: "n/a"},
+		},
+	}
+
+	s, err := table.RenderTable("items", "", "", WithEmptyAsNil())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| n/a "))
+	Expect(data[0][1]).To(Equal(""))
+}
@@ -0,0 +1,103 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const errorRowPrefix = "⚠ partial results: "
+const errorRowColor = "\x1b[31m"
+const errorRowColorReset = "\x1b[0m"
+
+//AddErrorRow records an error that made the data of the table incomplete, such as a page of results that could not be fetched.
+//The text formats render the errors as red rows at the end of the table and mark the total as incomplete,
+//json and yaml render {"rows": [...], "errors": [...]} and csv appends "# ERROR: " comment lines.
+//Error rows are not part of Data so they are not sorted or counted.
+func (t *Table) AddErrorRow(message string) {
+	t.errorRows = append(t.errorRows, message)
+}
+
+//withErrorRows returns a render function that adds the error messages to the output of render in the given format
+func withErrorRows(render func(rows [][]interface{}) (string, error), format string, messages []string) func(rows [][]interface{}) (string, error) {
+	return func(rows [][]interface{}) (string, error) {
+		s, err := render(rows)
+		if err != nil {
+			return "", err
+		}
+
+		switch format {
+		case "json", "json-ordered":
+			doc := struct {
+				Rows   json.RawMessage `json:"rows"`
+				Errors []string        `json:"errors"`
+			}{json.RawMessage(s), messages}
+
+			ret, err := json.MarshalIndent(doc, "", "\t")
+			if err != nil {
+				return "", err
+			}
+			return string(ret), nil
+		case "yaml":
+			var data interface{}
+			if err := yaml.Unmarshal([]byte(s), &data); err != nil {
+				return "", err
+			}
+			doc := yaml.MapSlice{
+				{Key: "rows", Value: data},
+				{Key: "errors", Value: messages},
+			}
+
+			ret, err := yaml.Marshal(doc)
+			if err != nil {
+				return "", err
+			}
+			return string(ret), nil
+		case "yaml-docs":
+			ret, err := yaml.Marshal(map[string][]string{"errors": messages})
+			if err != nil {
+				return "", err
+			}
+			return s + "---\n" + string(ret), nil
+		case "csv":
+			var sb strings.Builder
+			sb.WriteString(s)
+			for _, message := range messages {
+				sb.WriteString(fmt.Sprintf("# ERROR: %s\n", message))
+			}
+			return sb.String(), nil
+		default:
+			return addTextErrorRows(s, messages), nil
+		}
+	}
+}
+
+//addTextErrorRows adds a red row for each message at the end of a rendered table.
+//If s ends with a table delimiter the rows span the width of the table and are drawn above the delimiter.
+func addTextErrorRows(s string, messages []string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	last := lines[len(lines)-1]
+
+	if !strings.HasPrefix(last, "+") {
+		var sb strings.Builder
+		sb.WriteString(s)
+		for _, message := range messages {
+			sb.WriteString(errorRowColor + errorRowPrefix + message + errorRowColorReset + "\n")
+		}
+		return sb.String()
+	}
+
+	//the row has the width of the delimiter, including the borders and a space on each side of the message
+	width := VisibleWidth(last) - 4
+	errorRows := []string{}
+	for _, message := range messages {
+		for _, line := range WrapToWidth(errorRowPrefix+message, width) {
+			errorRows = append(errorRows, defaultDelimiter+" "+errorRowColor+pad(line, width)+errorRowColorReset+" "+defaultDelimiter)
+		}
+	}
+
+	lines = append(lines[:len(lines)-1], append(errorRows, last)...)
+	return strings.Join(lines, "\n") + "\n"
+}
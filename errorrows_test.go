@@ -0,0 +1,106 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRenderTableWithErrorRows(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{2, "second-instance-label"},
+		{1, "first"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.AddErrorRow("page 3 failed: timeout")
+
+	s, err := table.RenderTable("instances", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+----+-----------------------+\n" +
+			"| ID | LABEL                 |\n" +
+			"+----+-----------------------+\n" +
+			"| 2  | second-instance-label |\n" +
+			"| 1  | first                 |\n" +
+			"| " + errorRowColor + "⚠ partial results: page 3 " + errorRowColorReset + " |\n" +
+			"| " + errorRowColor + "failed: timeout           " + errorRowColorReset + " |\n" +
+			"+----+-----------------------+\n" +
+			"Total: 2 instances (incomplete)\n\n"))
+
+	//the error rows are not sorted with the data
+	Expect(TableSorter(table.Schema).OrderBy("ID").SortErr(table.Data)).To(BeNil())
+	s, err = table.RenderTable("instances", "", "aligned")
+	Expect(err).To(BeNil())
+	Expect(strings.HasSuffix(s, errorRowColor+"⚠ partial results: page 3 failed: timeout"+errorRowColorReset+"\n")).To(BeTrue())
+
+	s, err = table.RenderTable("instances", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("1,first\n2,second-instance-label\n# ERROR: page 3 failed: timeout\n"))
+}
+
+func TestRenderTableWithErrorRowsMachineReadable(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{2, "second-instance-label"},
+		{1, "first"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.AddErrorRow("page 3 failed: timeout")
+
+	for _, format := range []string{"json", "json-ordered"} {
+		s, err := table.RenderTable("instances", "", format)
+		Expect(err).To(BeNil())
+
+		var doc struct {
+			Rows   []map[string]interface{} `json:"rows"`
+			Errors []string                 `json:"errors"`
+		}
+		Expect(json.Unmarshal([]byte(s), &doc)).To(BeNil())
+		Expect(doc.Rows).To(HaveLen(2))
+		Expect(doc.Errors).To(Equal([]string{"page 3 failed: timeout"}))
+	}
+
+	s, err := table.RenderTable("instances", "", "yaml")
+	Expect(err).To(BeNil())
+	var doc struct {
+		Rows   []map[string]interface{} `yaml:"rows"`
+		Errors []string                 `yaml:"errors"`
+	}
+	Expect(yaml.Unmarshal([]byte(s), &doc)).To(BeNil())
+	Expect(doc.Rows).To(HaveLen(2))
+	Expect(doc.Errors).To(Equal([]string{"page 3 failed: timeout"}))
+
+	s, err = table.RenderTable("instances", "", "yaml-docs")
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("---\nerrors:\n- 'page 3 failed: timeout'\n"))
+}
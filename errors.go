@@ -0,0 +1,58 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+//wrappedError is an error with a message added before the message of the error it wraps, like the errors
+//returned by fmt.Errorf with %w, which Go 1.12 does not support
+type wrappedError struct {
+	message string
+	err     error
+}
+
+//wrapError returns err with the message formatted from format and args added before its message
+func wrapError(err error, format string, args ...interface{}) error {
+	return &wrappedError{message: fmt.Sprintf(format, args...), err: err}
+}
+
+func (e *wrappedError) Error() string {
+	return e.message + ": " + e.err.Error()
+}
+
+//Unwrap returns the wrapped error, for errors.Is and errors.As
+func (e *wrappedError) Unwrap() error {
+	return e.err
+}
+
+//joinedErrors are errors returned as one, with their messages on separate lines, like the errors returned by
+//errors.Join, which Go 1.12 does not have
+type joinedErrors []error
+
+//joinErrors returns the errors that are not nil joined into one error, or nil if there are none
+func joinErrors(errs ...error) error {
+	var joined joinedErrors
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+	if len(joined) == 0 {
+		return nil
+	}
+	return joined
+}
+
+func (e joinedErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+//Unwrap returns the joined errors, for errors.Is and errors.As since Go 1.20
+func (e joinedErrors) Unwrap() []error {
+	return e
+}
@@ -0,0 +1,30 @@
+package tableformatter
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWrapError(t *testing.T) {
+	RegisterTestingT(t)
+
+	cause := errors.New("disk full")
+	err := wrapError(cause, "could not write format %s", "csv")
+	Expect(err.Error()).To(Equal("could not write format csv: disk full"))
+	Expect(err.(interface{ Unwrap() error }).Unwrap()).To(BeIdenticalTo(cause))
+}
+
+func TestJoinErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(joinErrors()).To(BeNil())
+	Expect(joinErrors(nil, nil)).To(BeNil())
+
+	first := errors.New("disk full")
+	second := errors.New("network down")
+	err := joinErrors(first, nil, second)
+	Expect(err.Error()).To(Equal("disk full\nnetwork down"))
+	Expect(err.(interface{ Unwrap() []error }).Unwrap()).To(Equal([]error{first, second}))
+}
@@ -0,0 +1,94 @@
+package tableformatter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//ErrorDetails is implemented by the errors that carry details, such as the code and the request id of an API error.
+//ErrorToTable renders the details in the DETAILS field.
+type ErrorDetails interface {
+	Details() map[string]interface{}
+}
+
+//maxErrorDepth is the number of levels of wrapped errors rendered by ErrorToTable, against errors that wrap themselves
+const maxErrorDepth = 100
+
+//getErrorTableSchema returns the schema of the tables returned by ErrorToTable
+func getErrorTableSchema() []SchemaField {
+	return []SchemaField{
+		{
+			FieldName:        "MESSAGE",
+			FieldType:        TypeString,
+			FieldDescription: "the message of the error",
+		},
+		{
+			FieldName:        "TYPE",
+			FieldType:        TypeString,
+			FieldDescription: "the Go type of the error",
+		},
+		{
+			FieldName:        "DETAILS",
+			FieldType:        TypeInterface,
+			FieldDescription: "the details of the errors implementing ErrorDetails",
+		},
+	}
+}
+
+//ErrorToTable returns a table with a row for err and a row for each error it wraps, so that errors can be rendered
+//in any format like the other tables. The rows follow the wrapped errors depth first, the outermost error first and
+//the errors returned by an Unwrap() []error method, such as the errors joined by errors.Join, in their order. The DETAILS cells of the errors implementing ErrorDetails are
+//rendered as key=value lines by the text formats and as objects by json and yaml. A nil error returns a table
+//with no rows.
+func ErrorToTable(err error) *Table {
+	table := &Table{Schema: getErrorTableSchema(), Data: [][]interface{}{}}
+	if err != nil {
+		table.Data = appendErrorRows(table.Data, err, 0)
+	}
+	return table
+}
+
+//appendErrorRows appends the rows of err and of the errors it wraps to data
+func appendErrorRows(data [][]interface{}, err error, depth int) [][]interface{} {
+	if err == nil || depth >= maxErrorDepth {
+		return data
+	}
+
+	data = append(data, []interface{}{err.Error(), fmt.Sprintf("%T", err), getErrorDetailsCell(err)})
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		data = appendErrorRows(data, e.Unwrap(), depth+1)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			data = appendErrorRows(data, wrapped, depth+1)
+		}
+	}
+	return data
+}
+
+//getErrorDetailsCell returns the details of an error as a Cell written as key=value lines sorted by key,
+//or nil if the error has none
+func getErrorDetailsCell(err error) interface{} {
+	e, ok := err.(ErrorDetails)
+	if !ok {
+		return nil
+	}
+	details := e.Details()
+	if len(details) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("%s=%v", key, details[key])
+	}
+	return Cell{Value: details, AsString: strings.Join(lines, "\n")}
+}
@@ -0,0 +1,81 @@
+package tableformatter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//apiError is an error with details
+type apiError struct {
+	code int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("request failed with status %d", e.code)
+}
+
+func (e *apiError) Details() map[string]interface{} {
+	return map[string]interface{}{"status": e.code, "retry": e.code >= 500}
+}
+
+func TestErrorToTableWrapped(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := wrapError(wrapError(&apiError{code: 503}, "fetch page 2"), "list servers")
+	table := ErrorToTable(err)
+
+	s, rerr := table.Render()
+	Expect(rerr).To(BeNil())
+	Expect(s).To(Equal(
+		"+------------------------------------------------------------+------------------------------+------------+\n" +
+			"| MESSAGE                                                    | TYPE                         | DETAILS    |\n" +
+			"+------------------------------------------------------------+------------------------------+------------+\n" +
+			"| list servers: fetch page 2: request failed with status 503 | *tableformatter.wrappedError |            |\n" +
+			"| fetch page 2: request failed with status 503               | *tableformatter.wrappedError |            |\n" +
+			"| request failed with status 503                             | *tableformatter.apiError     | retry=true |\n" +
+			"|                                                            |                              | status=503 |\n" +
+			"+------------------------------------------------------------+------------------------------+------------+\n" +
+			"Total: 3 \n\n"))
+
+	s, rerr = table.Render(WithFormat("json"))
+	Expect(rerr).To(BeNil())
+	Expect(s).To(MatchJSON(`[
+		{"MESSAGE": "list servers: fetch page 2: request failed with status 503", "TYPE": "*tableformatter.wrappedError", "DETAILS": null},
+		{"MESSAGE": "fetch page 2: request failed with status 503", "TYPE": "*tableformatter.wrappedError", "DETAILS": null},
+		{"MESSAGE": "request failed with status 503", "TYPE": "*tableformatter.apiError", "DETAILS": {"retry": true, "status": 503}}
+	]`))
+}
+
+func TestErrorToTableJoined(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := joinErrors(errors.New("disk full"), wrapError(&apiError{code: 404}, "network"))
+	table := ErrorToTable(err)
+
+	Expect(table.Data).To(HaveLen(4))
+	Expect(table.Data[0][0]).To(Equal("disk full\nnetwork: request failed with status 404"))
+	Expect(table.Data[0][1]).To(Equal("tableformatter.joinedErrors"))
+	Expect(table.Data[1][0]).To(Equal("disk full"))
+	Expect(table.Data[2][0]).To(Equal("network: request failed with status 404"))
+	Expect(table.Data[3][0]).To(Equal("request failed with status 404"))
+
+	s, rerr := table.Render(WithFormat("csv"))
+	Expect(rerr).To(BeNil())
+	Expect(s).To(ContainSubstring("request failed with status 404,*tableformatter.apiError,"))
+}
+
+func TestErrorToTableSingleAndNil(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := ErrorToTable(errors.New("not found"))
+	Expect(table.Data).To(Equal([][]interface{}{{"not found", "*errors.errorString", nil}}))
+
+	table = ErrorToTable(nil)
+	Expect(table.Data).To(BeEmpty())
+	s, err := table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(MatchJSON("[]"))
+}
@@ -0,0 +1,310 @@
+package tableformatter_test
+
+import (
+	"fmt"
+
+	"github.com/metalsoft-io/tableformatter"
+)
+
+func ExampleTable_RenderTable() {
+	schema := []tableformatter.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: tableformatter.TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "DATACENTER",
+			FieldType: tableformatter.TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{20, "production-infrastructure", "john@alex.com", "us-santaclara\nmultiline-string"},
+		{10, "test-infrastructure", "alex@alex.com", "uk-reading"},
+		{34, "staging", "john@alex.com", "us-santaclara"},
+	}
+
+	table := tableformatter.Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("employees", "Employee list:", "text")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// Employee list:
+	// +----+---------------------------+---------------+------------------+
+	// | ID | LABEL                     | OWNER         | DATACENTER       |
+	// +----+---------------------------+---------------+------------------+
+	// | 20 | production-infrastructure | john@alex.com | us-santaclara    |
+	// |    |                           |               | multiline-string |
+	// | 10 | test-infrastructure       | alex@alex.com | uk-reading       |
+	// | 34 | staging                   | john@alex.com | us-santaclara    |
+	// +----+---------------------------+---------------+------------------+
+	// Total: 3 employees
+}
+
+func ExampleTable_RenderTable_json() {
+	schema := []tableformatter.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: tableformatter.TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "DATACENTER",
+			FieldType: tableformatter.TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{20, "production-infrastructure", "john@alex.com", "us-santaclara\nmultiline-string"},
+		{10, "test-infrastructure", "alex@alex.com", "uk-reading"},
+		{34, "staging", "john@alex.com", "us-santaclara"},
+	}
+
+	table := tableformatter.Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("employees", "", "json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// [
+	// 	{
+	// 		"DATACENTER": "us-santaclara\nmultiline-string",
+	// 		"ID": 20,
+	// 		"LABEL": "production-infrastructure",
+	// 		"OWNER": "john@alex.com"
+	// 	},
+	// 	{
+	// 		"DATACENTER": "uk-reading",
+	// 		"ID": 10,
+	// 		"LABEL": "test-infrastructure",
+	// 		"OWNER": "alex@alex.com"
+	// 	},
+	// 	{
+	// 		"DATACENTER": "us-santaclara",
+	// 		"ID": 34,
+	// 		"LABEL": "staging",
+	// 		"OWNER": "john@alex.com"
+	// 	}
+	// ]
+}
+
+func ExampleTable_RenderTable_csv() {
+	schema := []tableformatter.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: tableformatter.TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "DATACENTER",
+			FieldType: tableformatter.TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{20, "production-infrastructure", "john@alex.com", "us-santaclara\nmultiline-string"},
+		{10, "test-infrastructure", "alex@alex.com", "uk-reading"},
+		{34, "staging", "john@alex.com", "us-santaclara"},
+	}
+
+	table := tableformatter.Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("employees", "", "csv")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// ID,LABEL,OWNER,DATACENTER
+	// 20,production-infrastructure,john@alex.com,"us-santaclara
+	// multiline-string"
+	// 10,test-infrastructure,alex@alex.com,uk-reading
+	// 34,staging,john@alex.com,us-santaclara
+}
+
+func ExampleTable_RenderTransposedTable() {
+	schema := []tableformatter.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: tableformatter.TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "DATACENTER",
+			FieldType: tableformatter.TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{20, "production-infrastructure", "john@alex.com", "us-santaclara\nmultiline-string"},
+		{10, "test-infrastructure", "alex@alex.com", "uk-reading"},
+		{34, "staging", "john@alex.com", "us-santaclara"},
+	}
+
+	table := tableformatter.Table{Data: data, Schema: schema}
+	table.Data = table.Data[:1]
+
+	s, err := table.RenderTransposedTable("employee", "Employee:", "text")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// Employee:
+	// +------------+---------------------------+
+	// | KEY        | VALUE                     |
+	// +------------+---------------------------+
+	// | ID         | 20                        |
+	// | LABEL      | production-infrastructure |
+	// | OWNER      | john@alex.com             |
+	// | DATACENTER | us-santaclara             |
+	// |            | multiline-string          |
+	// +------------+---------------------------+
+	// Total: 4 employee
+}
+
+func ExampleTableSorter() {
+	schema := []tableformatter.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: tableformatter.TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: tableformatter.TypeString,
+		},
+		{
+			FieldName: "DATACENTER",
+			FieldType: tableformatter.TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{20, "production-infrastructure", "john@alex.com", "us-santaclara\nmultiline-string"},
+		{10, "test-infrastructure", "alex@alex.com", "uk-reading"},
+		{34, "staging", "john@alex.com", "us-santaclara"},
+	}
+
+	table := tableformatter.Table{Data: data, Schema: schema}
+
+	err := tableformatter.TableSorter(table.Schema).OrderBy("OWNER", "ID").SortErr(table.Data)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	s, err := table.RenderTable("employees", "", "text")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// +----+---------------------------+---------------+------------------+
+	// | ID | LABEL                     | OWNER         | DATACENTER       |
+	// +----+---------------------------+---------------+------------------+
+	// | 10 | test-infrastructure       | alex@alex.com | uk-reading       |
+	// | 20 | production-infrastructure | john@alex.com | us-santaclara    |
+	// |    |                           |               | multiline-string |
+	// | 34 | staging                   | john@alex.com | us-santaclara    |
+	// +----+---------------------------+---------------+------------------+
+	// Total: 3 employees
+}
+
+func ExampleObjectToTable() {
+	type server struct {
+		ServerID    int
+		ServerLabel string
+		PoweredOn   bool
+	}
+
+	table, err := tableformatter.ObjectToTable(server{ServerID: 100, ServerLabel: "db-1", PoweredOn: true})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	s, err := table.RenderTable("servers", "", "text")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// +-----------+--------------+------------+
+	// | server id | server label | powered on |
+	// +-----------+--------------+------------+
+	// | 100       | db-1         | true       |
+	// +-----------+--------------+------------+
+	// Total: 1 servers
+}
+
+func ExampleGenerateSampleTable() {
+	schema := []tableformatter.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: tableformatter.TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: tableformatter.TypeString,
+			FieldSize: 16,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      tableformatter.TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	table := tableformatter.GenerateSampleTable(schema, 3, 1)
+
+	s, err := table.RenderTable("servers", "", "")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(s)
+	// Output:
+	// +------+-----------------+--------+
+	// | ID   | LABEL           | COST   |
+	// +------+-----------------+--------+
+	// | 8081 | do amet elit s  | 65.64  |
+	// | 2540 | lorem et magna  | 380.66 |
+	// | 4728 | labore et te    | 218.55 |
+	// +------+-----------------+--------+
+	// Total: 3 servers
+}
@@ -0,0 +1,94 @@
+package tableformatter
+
+import "fmt"
+
+//ExtraCells is what happens to the cells of the rows of Data after the last field of the schema,
+//such as trailing debug columns that are not worth declaring
+type ExtraCells int
+
+const (
+	//ExtraCellsStrict makes rendering a table with extra cells an error. ConvertToStringTable and TransposeTable,
+	//which cannot return errors, leave the extra cells out.
+	ExtraCellsStrict ExtraCells = iota
+	//ExtraCellsIgnore leaves the extra cells out of every render, of ConvertToStringTable and of TransposeTable
+	ExtraCellsIgnore
+	//ExtraCellsAutoExtend renders the extra cells in TypeInterface fields named EXTRA 1, EXTRA 2 and so on
+	//added after the last field, the rows with fewer extra cells than others having nil cells in the last ones
+	ExtraCellsAutoExtend
+)
+
+//extraFieldName is the name of the fields added by ExtraCellsAutoExtend, numbered from 1
+const extraFieldName = "EXTRA %d"
+
+//getShapedTable returns the table with the extra cells of Data handled as set by mode, or the table itself
+//if no row has extra cells. The returned table shares the schema of the table unless fields were added.
+func (t *Table) getShapedTable(mode ExtraCells) (*Table, error) {
+	if t.RowSources != nil {
+		return t, nil
+	}
+
+	cellCount := 0
+	for i := range t.Schema {
+		if !isComputed(&t.Schema[i]) {
+			cellCount++
+		}
+	}
+
+	maxCells := cellCount
+	for k, row := range t.Data {
+		if isRawRow(row) || len(row) <= cellCount {
+			continue
+		}
+		if mode == ExtraCellsStrict {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", k, len(row), cellCount)
+		}
+		if len(row) > maxCells {
+			maxCells = len(row)
+		}
+	}
+	if maxCells == cellCount {
+		return t, nil
+	}
+
+	declared := cellCount
+	shaped := *t
+	if mode == ExtraCellsAutoExtend {
+		shaped.Schema = make([]SchemaField, len(t.Schema), len(t.Schema)+maxCells-cellCount)
+		copy(shaped.Schema, t.Schema)
+		for n := 1; n <= maxCells-cellCount; n++ {
+			shaped.Schema = append(shaped.Schema, SchemaField{
+				FieldName: fmt.Sprintf(extraFieldName, n),
+				FieldType: TypeInterface,
+			})
+		}
+		cellCount = maxCells
+	}
+
+	shaped.Data = make([][]interface{}, len(t.Data))
+	for k, row := range t.Data {
+		switch {
+		case isRawRow(row) || len(row) == cellCount || len(row) < declared:
+			shaped.Data[k] = row
+		case len(row) > cellCount:
+			shaped.Data[k] = row[:cellCount:cellCount]
+		default:
+			shaped.Data[k] = append(row[:len(row):len(row)], make([]interface{}, cellCount-len(row))...)
+		}
+	}
+	return &shaped, nil
+}
+
+//getLenientShapedTable returns the table with the extra cells handled as set by ExtraCells,
+//ExtraCellsStrict leaving them out, for the functions that cannot return errors.
+//The tables without a schema are returned as is, all their cells being kept.
+func (t *Table) getLenientShapedTable() *Table {
+	if len(t.Schema) == 0 {
+		return t
+	}
+	mode := t.ExtraCells
+	if mode == ExtraCellsStrict {
+		mode = ExtraCellsIgnore
+	}
+	shaped, _ := t.getShapedTable(mode)
+	return shaped
+}
@@ -0,0 +1,140 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestExtraCellsStrict(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "web-1", "debug-a", 17},
+		{2, "web-2"},
+		{3, "web-3", "debug-c"},
+	}
+	table := &Table{Data: data, Schema: schema, ExtraCells: ExtraCellsStrict}
+	for _, format := range []string{"", "json"} {
+		_, err := table.Render(WithFormat(format))
+		Expect(err).To(MatchError("row 0 has 4 cells, expected 2"), format)
+	}
+	_, err := table.RenderTransposedTable("", "", "")
+	Expect(err).To(MatchError("row 0 has 4 cells, expected 2"))
+	_, err = table.RenderTransposedTableHumanReadable("", "")
+	Expect(err).To(MatchError("row 0 has 4 cells, expected 2"))
+	_, err = table.TransposeSorted("ID")
+	Expect(err).To(MatchError("row 0 has 4 cells, expected 2"))
+
+	//the functions that cannot return errors leave the extra cells out
+	Expect(ConvertToStringTable(*table).Data[0]).To(Equal([]interface{}{"1", "web-1"}))
+	Expect(TransposeTable(*table).Data).To(HaveLen(2))
+}
+
+func TestExtraCellsIgnore(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "web-1", "debug-a", 17},
+		{2, "web-2"},
+		{3, "web-3", "debug-c"},
+	}
+	table := &Table{Data: data, Schema: schema, ExtraCells: ExtraCellsIgnore}
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+----+-------+\n" +
+			"| ID | LABEL |\n" +
+			"+----+-------+\n" +
+			"| 1  | web-1 |\n" +
+			"| 2  | web-2 |\n" +
+			"| 3  | web-3 |\n" +
+			"+----+-------+\n" +
+			"Total: 3 \n\n"))
+
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("debug"))
+
+	s, err = table.RenderTransposedTable("", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("debug"))
+
+	transposed, err := table.TransposeSorted("ID")
+	Expect(err).To(BeNil())
+	Expect(transposed.Data).To(HaveLen(2))
+	Expect(ConvertToStringTable(*table).Data[0]).To(Equal([]interface{}{"1", "web-1"}))
+	Expect(TransposeTable(*table).Data).To(Equal([][]interface{}{{1, 2, 3}, {"web-1", "web-2", "web-3"}}))
+
+	//the table is not changed
+	Expect(table.Data[0]).To(HaveLen(4))
+	Expect(table.Schema).To(HaveLen(2))
+}
+
+func TestExtraCellsAutoExtend(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "web-1", "debug-a", 17},
+		{2, "web-2"},
+		{3, "web-3", "debug-c"},
+	}
+	table := &Table{Data: data, Schema: schema, ExtraCells: ExtraCellsAutoExtend}
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+----+-------+---------+---------+\n" +
+			"| ID | LABEL | EXTRA 1 | EXTRA 2 |\n" +
+			"+----+-------+---------+---------+\n" +
+			"| 1  | web-1 | debug-a | 17      |\n" +
+			"| 2  | web-2 |         |         |\n" +
+			"| 3  | web-3 | debug-c |         |\n" +
+			"+----+-------+---------+---------+\n" +
+			"Total: 3 \n\n"))
+
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"EXTRA 1": "debug-a"`))
+	Expect(s).To(ContainSubstring(`"EXTRA 2": 17`))
+
+	s, err = table.RenderTransposedTable("", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| EXTRA 2 | 17      |"))
+
+	transposed, err := table.TransposeSorted("ID")
+	Expect(err).To(BeNil())
+	Expect(transposed.Data).To(HaveLen(4))
+	Expect(ConvertToStringTable(*table).Schema).To(HaveLen(4))
+
+	Expect(table.Schema).To(HaveLen(2))
+}
@@ -0,0 +1,99 @@
+package tableformatter
+
+import (
+	"fmt"
+)
+
+//extractErrorFormat is the text of the cells whose FieldExtract panicked
+const extractErrorFormat = "error: %v"
+
+//getData returns the rows of the table holding the cells of the fields that are not computed: Data or,
+//if the table has RowSources, the rows extracted from them for the visible fields and the fieldNames
+func (t *Table) getData(fieldNames ...string) [][]interface{} {
+	if t.RowSources == nil {
+		return t.Data
+	}
+
+	needed := getNeededFields(t.Schema, fieldNames)
+	data := make([][]interface{}, len(t.RowSources))
+	for k, source := range t.RowSources {
+		row := []interface{}{}
+		for i := range t.Schema {
+			if isComputed(&t.Schema[i]) {
+				continue
+			}
+			var cell interface{}
+			if needed[i] && t.Schema[i].FieldExtract != nil {
+				cell, _ = extractCell(source, &t.Schema[i])
+			}
+			row = append(row, cell)
+		}
+		data[k] = row
+	}
+	return data
+}
+
+//getNeededFields returns which fields of the schema are rendered: the visible fields, the fieldNames
+//and the fields the computed ones among them are computed from
+func getNeededFields(schema []SchemaField, fieldNames []string) []bool {
+	needed := make([]bool, len(schema))
+
+	var need func(i int)
+	need = func(i int) {
+		if needed[i] {
+			return
+		}
+		needed[i] = true
+		for _, source := range schema[i].FieldComputeFrom {
+			if j := getFieldIndex(schema, source); j != -1 {
+				need(j)
+			}
+		}
+	}
+
+	for i := range schema {
+		if !schema[i].FieldHidden {
+			need(i)
+		}
+	}
+	for _, fieldName := range fieldNames {
+		if i := getFieldIndex(schema, fieldName); i != -1 {
+			need(i)
+		}
+	}
+
+	return needed
+}
+
+//extractCell returns the cell of the field extracted from the source. If FieldExtract panics the cell is
+//a Cell showing the error, which is also returned, and holding nil for the machine readable formats.
+func extractCell(source interface{}, field *SchemaField) (cell interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			cell = Cell{AsString: fmt.Sprintf(extractErrorFormat, r)}
+			err = fmt.Errorf("could not extract field %s: %v", field.FieldName, r)
+		}
+	}()
+	return field.FieldExtract(source), nil
+}
+
+//ValidateExtractors runs the FieldExtract of every field on every one of the RowSources and returns an error
+//for the first one that panics or returns a cell that does not hold the type of its field
+func (t *Table) ValidateExtractors() error {
+	for k, source := range t.RowSources {
+		for i := range t.Schema {
+			field := &t.Schema[i]
+			if field.FieldExtract == nil {
+				continue
+			}
+			cell, err := extractCell(source, field)
+			if err != nil {
+				return fmt.Errorf("row %d: %s", k, err)
+			}
+			if cell != nil && !hasCellType(cell, field) {
+				return fmt.Errorf("row %d: could not extract field %s: %T cell in a %s field", k, field.FieldName, cell, fieldTypeNames[field.FieldType])
+			}
+		}
+	}
+	return nil
+}
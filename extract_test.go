@@ -0,0 +1,174 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type extractedServer struct {
+	ID    int
+	Label string
+	Cores int
+}
+
+func TestRenderRowSources(t *testing.T) {
+	RegisterTestingT(t)
+
+	calls := map[string]int{}
+	extract := func(name string, cell func(s extractedServer) interface{}) func(source interface{}) interface{} {
+		return func(source interface{}) interface{} {
+			calls[name]++
+			return cell(source.(extractedServer))
+		}
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName:    "ID",
+			FieldType:    TypeInt,
+			FieldExtract: extract("ID", func(s extractedServer) interface{} { return s.ID }),
+		},
+		{
+			FieldName:    "LABEL",
+			FieldType:    TypeString,
+			FieldExtract: extract("LABEL", func(s extractedServer) interface{} { return s.Label }),
+		},
+		{
+			FieldName:    "CORES",
+			FieldType:    TypeInt,
+			FieldHidden:  true,
+			FieldExtract: extract("CORES", func(s extractedServer) interface{} { return s.Cores }),
+		},
+	}
+
+	sources := []interface{}{
+		extractedServer{1, "web-1", 4},
+		extractedServer{2, "web-2", 8},
+	}
+
+	table := Table{RowSources: sources, Schema: schema}
+
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+----+-------+\n" +
+			"| ID | LABEL |\n" +
+			"+----+-------+\n" +
+			"| 1  | web-1 |\n" +
+			"| 2  | web-2 |\n" +
+			"+----+-------+\n" +
+			"Total: 2 servers\n\n"))
+
+	//the hidden field is not extracted
+	Expect(calls).To(Equal(map[string]int{"ID": 2, "LABEL": 2}))
+
+	//unless it is needed
+	values, err := table.DistinctValues("CORES", 0)
+	Expect(err).To(BeNil())
+	Expect(values).To(Equal([]string{"4", "8"}))
+
+	table.Schema = append(table.Schema, SchemaField{
+		FieldName:        "THREADS",
+		FieldType:        TypeInt,
+		FieldComputeFrom: []string{"CORES"},
+		FieldCompute: func(values ...interface{}) interface{} {
+			return values[0].(int) * 2
+		},
+	})
+	s, err = table.RenderTable("servers", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,LABEL,THREADS\n1,web-1,8\n2,web-2,16\n"))
+}
+
+func TestRenderRowSourcesPanic(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldExtract: func(source interface{}) interface{} {
+				return source.(extractedServer).ID
+			},
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldExtract: func(source interface{}) interface{} {
+				if source.(extractedServer).ID == 2 {
+					panic("no label")
+				}
+				return source.(extractedServer).Label
+			},
+		},
+	}
+
+	sources := []interface{}{
+		extractedServer{1, "web-1", 4},
+		extractedServer{2, "web-2", 8},
+	}
+
+	table := Table{RowSources: sources, Schema: schema}
+
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 2  | error: no label |"))
+
+	s, err = table.RenderTable("servers", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"LABEL": null`))
+
+	Expect(table.ValidateExtractors()).To(MatchError("row 1: could not extract field LABEL: no label"))
+}
+
+func TestValidateExtractors(t *testing.T) {
+	RegisterTestingT(t)
+
+	calls := map[string]int{}
+	extract := func(name string, cell func(s extractedServer) interface{}) func(source interface{}) interface{} {
+		return func(source interface{}) interface{} {
+			calls[name]++
+			return cell(source.(extractedServer))
+		}
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName:    "ID",
+			FieldType:    TypeInt,
+			FieldExtract: extract("ID", func(s extractedServer) interface{} { return s.ID }),
+		},
+		{
+			FieldName:    "LABEL",
+			FieldType:    TypeString,
+			FieldExtract: extract("LABEL", func(s extractedServer) interface{} { return s.Label }),
+		},
+		{
+			FieldName:    "CORES",
+			FieldType:    TypeInt,
+			FieldHidden:  true,
+			FieldExtract: extract("CORES", func(s extractedServer) interface{} { return s.Cores }),
+		},
+	}
+
+	sources := []interface{}{
+		extractedServer{1, "web-1", 4},
+		extractedServer{2, "web-2", 8},
+	}
+
+	table := Table{RowSources: sources, Schema: schema}
+	Expect(table.ValidateExtractors()).To(Succeed())
+	Expect(calls).To(Equal(map[string]int{"ID": 2, "LABEL": 2, "CORES": 2}))
+
+	table.Schema[2].FieldExtract = func(source interface{}) interface{} {
+		return "many"
+	}
+	Expect(table.ValidateExtractors()).To(MatchError("row 0: could not extract field CORES: string cell in a int field"))
+
+	//nil cells are accepted
+	table.Schema[2].FieldExtract = func(source interface{}) interface{} {
+		return nil
+	}
+	Expect(table.ValidateExtractors()).To(Succeed())
+}
@@ -0,0 +1,23 @@
+package tableformatter
+
+//getFieldID returns the FieldID of the field or its FieldName if it has none
+func getFieldID(field *SchemaField) string {
+	if field.FieldID != "" {
+		return field.FieldID
+	}
+	return field.FieldName
+}
+
+//getKeyedSchema returns the schema with the FieldID of the fields as their FieldName if RenderOptions.FieldIDKeys is set.
+//The schema is returned as is otherwise.
+func getKeyedSchema(schema []SchemaField, options *RenderOptions) []SchemaField {
+	if !options.FieldIDKeys {
+		return schema
+	}
+	newSchema := make([]SchemaField, len(schema))
+	for i := range schema {
+		newSchema[i] = schema[i]
+		newSchema[i].FieldName = getFieldID(&schema[i])
+	}
+	return newSchema
+}
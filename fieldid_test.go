@@ -0,0 +1,90 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOrderByFieldID(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldID:   "server_id",
+			FieldName: "Server ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldID:   "ip_address",
+			FieldName: "IP addr.",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{2, "10.0.0.2", "active"},
+		{1, "10.0.0.1", "active"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	Expect(TableSorter(table.Schema).OrderBy("server_id").SortErr(table.Data)).To(BeNil())
+	Expect(table.Data[0][0]).To(Equal(1))
+
+	//the display name still works for fields without an id
+	Expect(TableSorter(table.Schema).OrderBy("STATUS", "ip_address").SortErr(table.Data)).To(BeNil())
+	Expect(table.Data[0][0]).To(Equal(1))
+
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| Server ID | IP addr. "))
+}
+
+func TestRenderTableWithFieldIDKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldID:   "server_id",
+			FieldName: "Server ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldID:   "ip_address",
+			FieldName: "IP addr.",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{2, "10.0.0.2", "active"},
+		{1, "10.0.0.1", "active"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("servers", "", "json", WithFieldIDKeys())
+	Expect(err).To(BeNil())
+	var rows []map[string]interface{}
+	Expect(json.Unmarshal([]byte(s), &rows)).To(BeNil())
+	Expect(rows[0]).To(Equal(map[string]interface{}{"server_id": 2.0, "ip_address": "10.0.0.2", "STATUS": "active"}))
+
+	s, err = table.RenderTable("servers", "", "json-ordered", WithFieldIDKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"server_id": 2,`))
+
+	//the display names are the keys by default
+	s, err = table.RenderTable("servers", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"Server ID": 2`))
+}
@@ -0,0 +1,266 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//MinCustomFieldType is the smallest id that RegisterFieldType accepts, the ids below it are reserved for the built-in types
+const MinCustomFieldType = 1000
+
+//FieldTypeHandler formats, measures, sorts and exports the cells of a field type
+type FieldTypeHandler interface {
+	//Format returns the cell as shown by the text formats and csv. The text formats split it into lines on \n.
+	Format(value interface{}, field *SchemaField) (string, error)
+	//Measure returns the width of the widest line of the formatted cell. The text formats size their columns
+	//on the decolorized lines returned by Format instead, so that a Measure counting colors cannot misalign them.
+	Measure(value interface{}, field *SchemaField) int
+	//Less reports whether the cell a sorts before the cell b
+	Less(a, b interface{}, field *SchemaField) bool
+	//MarshalValue returns the value written for the cell by the json and yaml formats
+	MarshalValue(value interface{}, field *SchemaField) interface{}
+}
+
+//csvFieldTypeHandler is implemented by the handlers that format csv cells differently than text cells
+type csvFieldTypeHandler interface {
+	FormatCSV(value interface{}, field *SchemaField) (string, error)
+}
+
+//fieldTypeHandlers holds the handlers of the built-in and of the registered field types
+var fieldTypeHandlers = map[int]FieldTypeHandler{
+	TypeInt:       intHandler{},
+	TypeString:    stringHandler{},
+	TypeFloat:     floatHandler{},
+	TypeDateTime:  dateTimeHandler{},
+	TypeInterface: interfaceHandler{},
+	TypeBool:      boolHandler{},
+	TypeDuration:  durationHandler{},
+	TypeVersion:   versionHandler{},
+	TypeIP:        ipHandler{},
+	TypeBytes:     bytesHandler{},
+}
+
+//RegisterFieldType adds a field type handled by handler. The id must be at least MinCustomFieldType and not already registered.
+func RegisterFieldType(id int, handler FieldTypeHandler) error {
+	if id < MinCustomFieldType {
+		return fmt.Errorf("field type %d is in the reserved range, custom field types start at %d", id, MinCustomFieldType)
+	}
+	if _, ok := fieldTypeHandlers[id]; ok {
+		return fmt.Errorf("field type %d is already registered", id)
+	}
+	fieldTypeHandlers[id] = handler
+	return nil
+}
+
+//getFieldTypeHandler returns the handler of the type of the field. Unknown types are handled like interface fields.
+func getFieldTypeHandler(field *SchemaField) FieldTypeHandler {
+	if handler, ok := fieldTypeHandlers[field.FieldType]; ok {
+		return handler
+	}
+	return interfaceHandler{}
+}
+
+//isCustomFieldType returns true if the type of the field was registered with RegisterFieldType
+func isCustomFieldType(field *SchemaField) bool {
+	_, ok := fieldTypeHandlers[field.FieldType]
+	return ok && field.FieldType >= MinCustomFieldType
+}
+
+//checkCustomCells returns the first error returned, or panic raised, by the handlers of the custom field types when formatting the cells of data
+func checkCustomCells(data [][]interface{}, schema []SchemaField) error {
+	for i := range schema {
+		if !isCustomFieldType(&schema[i]) {
+			continue
+		}
+		handler := getFieldTypeHandler(&schema[i])
+		for k, row := range data {
+			if isRawRow(row) {
+				continue
+			}
+			if _, ok := row[i].(Cell); ok {
+				continue
+			}
+			if _, err := tryFormat(handler, row[i], &schema[i]); err != nil {
+				return fmt.Errorf("could not format cell at row %d column %s: %s", k, schema[i].FieldName, err)
+			}
+		}
+	}
+	return nil
+}
+
+//measureLines returns the width of the widest line of s
+func measureLines(s string) int {
+	maxW := 0
+	for _, line := range strings.Split(s, "\n") {
+		if maxW < VisibleWidth(line) {
+			maxW = VisibleWidth(line)
+		}
+	}
+	return maxW
+}
+
+//interfaceHandler formats cells with %v. Its cells are not sortable.
+type interfaceHandler struct{}
+
+func (interfaceHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	return getInterfaceAsString(value), nil
+}
+
+func (h interfaceHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+func (interfaceHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return false
+}
+
+func (interfaceHandler) MarshalValue(value interface{}, field *SchemaField) interface{} {
+	return value
+}
+
+//intHandler handles int cells, printed as %d
+type intHandler struct{ interfaceHandler }
+
+func (intHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	if v, ok := value.(int); ok {
+		return fmt.Sprintf("%d", v), nil
+	}
+	return getInterfaceAsString(value), nil
+}
+
+func (h intHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+//Less orders the cells by their number, whatever their type, see getNumber
+func (intHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return numberLess(a, b)
+}
+
+//stringHandler handles string cells, which can span multiple lines
+type stringHandler struct{ interfaceHandler }
+
+func (stringHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return a.(string) < b.(string)
+}
+
+//MarshalValue writes the UnitValue cells of the fields with FieldNormalizeUnits as they are rendered
+func (stringHandler) MarshalValue(value interface{}, field *SchemaField) interface{} {
+	if v, ok := value.(UnitValue); ok {
+		return v.String()
+	}
+	return value
+}
+
+//floatHandler handles float64 cells, printed with FieldPrecision decimals in text and with %f in csv
+type floatHandler struct{ interfaceHandler }
+
+func (floatHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	if v, ok := value.(float64); ok {
+		return fmt.Sprintf(fmt.Sprintf("%%.%df", field.FieldPrecision), v), nil
+	}
+	return getInterfaceAsString(value), nil
+}
+
+func (floatHandler) FormatCSV(value interface{}, field *SchemaField) (string, error) {
+	if v, ok := value.(float64); ok {
+		return fmt.Sprintf("%f", v), nil
+	}
+	return getInterfaceAsString(value), nil
+}
+
+func (h floatHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+//Less orders the cells by their number, whatever their type, see getNumber. NaN cells go last.
+func (floatHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return numberLess(a, b)
+}
+
+//dateTimeHandler handles time.Time and *time.Time cells and strings in the layouts of the field
+type dateTimeHandler struct{ interfaceHandler }
+
+func (dateTimeHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	return getDateTimeAsString(value, field), nil
+}
+
+func (h dateTimeHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+func (dateTimeHandler) Less(a, b interface{}, field *SchemaField) bool {
+	ta, okA := parseDateTimeCell(a, field)
+	tb, okB := parseDateTimeCell(b, field)
+
+	switch {
+	case okA && okB:
+		return ta.Before(tb)
+	case okA != okB:
+		//cells that cannot be parsed go after the valid times and before the nil cells, see nilLess
+		return okA
+	default:
+		return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+	}
+}
+
+func (dateTimeHandler) MarshalValue(value interface{}, field *SchemaField) interface{} {
+	if isNilTime(value) {
+		return nil
+	}
+	switch value.(type) {
+	case time.Time, *time.Time:
+		tm, _ := parseDateTimeCell(value, field)
+		return formatTime(tm, getTimeLayout(field))
+	}
+	return value
+}
+
+//boolHandler handles bool cells, printed as true or false
+type boolHandler struct{ interfaceHandler }
+
+func (boolHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	return getBoolAsString(value), nil
+}
+
+func (h boolHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+//Less orders false before true
+func (boolHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return !a.(bool) && b.(bool)
+}
+
+//durationHandler handles time.Duration cells, numbers of seconds and duration strings, see parseDuration
+type durationHandler struct{ interfaceHandler }
+
+func (durationHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	return getDurationAsString(value, field), nil
+}
+
+func (h durationHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return measureLines(s)
+}
+
+func (durationHandler) Less(a, b interface{}, field *SchemaField) bool {
+	da, okA := parseDuration(a)
+	db, okB := parseDuration(b)
+
+	switch {
+	case okA && okB:
+		return da < db
+	case okA != okB:
+		//cells that cannot be parsed go last
+		return okA
+	default:
+		return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+	}
+}
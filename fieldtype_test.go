@@ -0,0 +1,135 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const typePercent = MinCustomFieldType + 1
+
+//percentHandler renders float64 ratios as percents
+type percentHandler struct{}
+
+func (percentHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	v, ok := value.(float64)
+	if !ok {
+		return "", fmt.Errorf("%v is not a ratio", value)
+	}
+	return fmt.Sprintf("%.0f%%", v*100), nil
+}
+
+func (h percentHandler) Measure(value interface{}, field *SchemaField) int {
+	s, _ := h.Format(value, field)
+	return len(s)
+}
+
+func (percentHandler) Less(a, b interface{}, field *SchemaField) bool {
+	return a.(float64) < b.(float64)
+}
+
+func (percentHandler) MarshalValue(value interface{}, field *SchemaField) interface{} {
+	return value.(float64) * 100
+}
+
+func TestRegisterFieldType(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer delete(fieldTypeHandlers, typePercent)
+
+	Expect(RegisterFieldType(TypeInt, percentHandler{})).NotTo(BeNil())
+	Expect(RegisterFieldType(typePercent, percentHandler{})).To(BeNil())
+	Expect(RegisterFieldType(typePercent, percentHandler{})).NotTo(BeNil())
+
+	schema := []SchemaField{
+		{
+			FieldName: "HOST",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "LOAD",
+			FieldType: typePercent,
+		},
+	}
+
+	data := [][]interface{}{
+		{"a", 0.5},
+		{"b", 0.25},
+		{"c", 1.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	Expect(TableSorter(schema).OrderBy("LOAD").SortErr(data)).To(BeNil())
+	Expect(data[0][0]).To(Equal("b"))
+
+	s, err := table.RenderTable("hosts", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+------+------+
+| HOST | LOAD |
++------+------+
+| b    | 25%  |
+| a    | 50%  |
+| c    | 100% |
++------+------+
+Total: 3 hosts
+
+`))
+
+	s, err = table.RenderTable("hosts", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("b,25%\n"))
+
+	s, err = table.RenderTable("hosts", "", "json-ordered")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"LOAD": 25`))
+
+	//the errors of the handler are returned
+	data[0][1] = "high"
+	_, err = table.RenderTable("hosts", "", "")
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("high"))
+}
+
+//assertingHandler formats float64 ratios like percentHandler but with a bare type assertion
+type assertingHandler struct{ percentHandler }
+
+func (assertingHandler) Format(value interface{}, field *SchemaField) (string, error) {
+	return fmt.Sprintf("%.0f%%", value.(float64)*100), nil
+}
+
+func TestColorizedCellsInEveryFieldType(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer delete(fieldTypeHandlers, typePercent)
+	Expect(RegisterFieldType(typePercent, assertingHandler{})).To(BeNil())
+
+	colorized := "\x1b[31m12345\x1b[0m"
+	fieldTypes := []int{TypeInt, TypeString, TypeFloat, TypeDateTime, TypeInterface, TypeBool, TypeDuration, TypeVersion, TypeIP, TypeBytes, typePercent}
+
+	for _, fieldType := range fieldTypes {
+		field := SchemaField{FieldName: "VALUE", FieldType: fieldType, FieldPrecision: 2}
+		Expect(getCellSize(colorized, &field)).To(Equal(5), fieldTypeNames[fieldType])
+		Expect(getCellSize(Cell{Value: 1, AsString: colorized}, &field)).To(Equal(5), fieldTypeNames[fieldType])
+
+		schema := []SchemaField{field}
+		data := [][]interface{}{{colorized}, {"1234567"}}
+		Expect(ColumnWidths(data, schema)).To(Equal([]int{7}), fieldTypeNames[fieldType])
+
+		table := Table{Data: data, Schema: schema}
+		s, err := table.RenderTable("values", "", "")
+		if fieldType == typePercent {
+			//custom field types report the cells their handler cannot format instead of rendering them
+			Expect(err).NotTo(BeNil())
+			continue
+		}
+		Expect(err).To(BeNil())
+
+		lines := strings.Split(strings.TrimSpace(s), "\n")
+		for _, line := range lines[:6] {
+			Expect(VisibleWidth(line)).To(Equal(VisibleWidth(lines[0])), fieldTypeNames[fieldType]+": "+line)
+		}
+	}
+}
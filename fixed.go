@@ -0,0 +1,86 @@
+package tableformatter
+
+import (
+	"strings"
+)
+
+//The text-fixed format is the text format with the layout fixed by the schema, for output kept under version control
+//or compared with diff: changing one cell changes one line. The text format widens each column to its widest cell,
+//so one longer cell moves the delimiters of every line, and spreads the lines of a multi-line cell over several lines.
+//The text-fixed format instead
+//
+//	sizes each column to the FieldSize of its field, or to its header if the field has no FieldSize,
+//	cuts the cells and the headers wider than their column, ending them with fixedTruncationMarker,
+//	writes each row on one line, the new lines of the cells written as \n.
+//
+//The price is that cells can be cut, so the fields need a FieldSize wide enough for their usual cells,
+//and that the table is never folded.
+
+//fixedTruncationMarker ends the cells cut by the text-fixed format
+const fixedTruncationMarker = "…"
+
+//getFixedSchema returns the schema of the string cells of the text-fixed format: the fields with their FieldSize,
+//or the width of their header if they have none, and their header cut to it
+func getFixedSchema(schema []SchemaField) []SchemaField {
+	fixedSchema := make([]SchemaField, len(schema))
+	for i, field := range schema {
+		width := field.FieldSize
+		if width <= 0 {
+			width = VisibleWidth(field.FieldName)
+		}
+		fixedSchema[i] = SchemaField{
+			FieldName:      TruncateToWidth(field.FieldName, width, fixedTruncationMarker),
+			FieldType:      TypeString,
+			FieldSize:      width,
+			FieldGroup:     field.FieldGroup,
+			FieldAlignment: field.FieldAlignment,
+		}
+	}
+	return fixedSchema
+}
+
+//getFixedRow returns the cells of a row formatted on one line and cut to the FieldSize of the fixed schema
+func getFixedRow(row []interface{}, schema []SchemaField, fixedSchema []SchemaField) []interface{} {
+	cells := make([]interface{}, len(schema))
+	for i := range schema {
+		s := strings.Join(getCellLines(row[i], &schema[i]), `\n`)
+		cells[i] = TruncateToWidth(stripUnterminatedSequence(s), fixedSchema[i].FieldSize, fixedTruncationMarker)
+	}
+	return cells
+}
+
+//getTableAsFixedString returns the text-fixed format of a table followed, if it is not nil, by the footer row
+func getTableAsFixedString(data [][]interface{}, schema []SchemaField, footer []interface{}, options *RenderOptions) string {
+	fixedSchema := getFixedSchema(schema)
+	delimiter := getTableDelimiter(fixedSchema, options)
+
+	var sb strings.Builder
+	writeLine := func(line string) {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	writeLine(delimiter)
+	writeLine(getTableHeader(fixedSchema, options))
+	writeLine(delimiter)
+	rowIndex := 0
+	for _, row := range data {
+		if isRawRow(row) {
+			writeLine(strings.Replace(string(row[0].(RawRow)), "\n", `\n`, -1))
+			continue
+		}
+		color := ""
+		if options.RowColor != nil && !options.NoColor {
+			color = options.RowColor(rowIndex, row)
+		}
+		writeLine(getColoredTableRow(getFixedRow(row, schema, fixedSchema), fixedSchema, color, options))
+		rowIndex++
+	}
+	writeLine(delimiter)
+	if footer != nil {
+		writeLine(getTableRow(getFixedRow(footer, schema, fixedSchema), fixedSchema, options))
+		writeLine(delimiter)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,130 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTextFixed(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 8,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "web-1", "active"},
+		{2, "web-2", "active"},
+		{3, "database", "active"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Data[1][1] = "first line\nsecond"
+	table.Data[2][2] = "deleted"
+	s, err := table.Render(WithFormat("text-fixed"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+-----+---------+-------+\n" +
+			"| ID  | LABEL   | STATUS|\n" +
+			"+-----+---------+-------+\n" +
+			"| 1   | web-1   | active|\n" +
+			"| 2   | first l…| active|\n" +
+			"| 3   | database| delet…|\n" +
+			"+-----+---------+-------+\n" +
+			"Total: 3 \n\n"))
+
+	//the field sizes are not changed by the render
+	Expect(table.Schema[1].FieldSize).To(Equal(8))
+	Expect(table.Schema[2].FieldSize).To(Equal(0))
+}
+
+func TestRenderTextFixedEscapesNewLines(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 8,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "web-1", "active"},
+		{2, "web-2", "active"},
+		{3, "database", "active"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Schema[1].FieldSize = 20
+	table.Data[0][1] = "first\nsecond"
+	table.Data = append(table.Data, []interface{}{RawRow("raw\nrow")})
+	s, err := table.Render(WithFormat("text-fixed"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`| first\nsecond       |`))
+	Expect(s).To(ContainSubstring("\nraw\\nrow\n"))
+}
+
+func TestRenderTextFixedOneLineDiff(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 8,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "web-1", "active"},
+		{2, "web-2", "active"},
+		{3, "database", "active"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	before, err := table.Render(WithFormat("text-fixed"))
+	Expect(err).To(BeNil())
+
+	table.Data[0][1] = "a much longer label"
+	after, err := table.Render(WithFormat("text-fixed"))
+	Expect(err).To(BeNil())
+
+	beforeLines := strings.Split(before, "\n")
+	afterLines := strings.Split(after, "\n")
+	Expect(afterLines).To(HaveLen(len(beforeLines)))
+	changed := 0
+	for i := range beforeLines {
+		if beforeLines[i] != afterLines[i] {
+			changed++
+		}
+	}
+	Expect(changed).To(Equal(1))
+}
@@ -0,0 +1,58 @@
+package tableformatter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//formatNames maps the lower cased names accepted by the render functions to the name of their format.
+//A format added here can be rendered by every render function.
+var formatNames = map[string]string{
+	"":             "text",
+	"text":         "text",
+	"text-fixed":   "text-fixed",
+	"json":         "json",
+	"json-ordered": "json-ordered",
+	"csv":          "csv",
+	"yaml":         "yaml",
+	"yaml-docs":    "yaml-docs",
+	"md":           "md",
+	"markdown":     "md",
+	"html":         "html",
+	"aligned":      "aligned",
+	"slack":        "aligned",
+	"html-pre":     "html-pre",
+}
+
+//ErrInvalidFormat is returned by the render functions for a format they do not know
+type ErrInvalidFormat struct {
+	Format string
+}
+
+func (e *ErrInvalidFormat) Error() string {
+	return fmt.Sprintf("invalid format %s, supported formats are %s", e.Format, strings.Join(getFormats(), ", "))
+}
+
+//resolveFormat returns the name of the format selected by a format name in any case, such as md for MARKDOWN
+func resolveFormat(format string) (string, error) {
+	name, ok := formatNames[strings.ToLower(format)]
+	if !ok {
+		return "", &ErrInvalidFormat{Format: format}
+	}
+	return name, nil
+}
+
+//getFormats returns the sorted names of the supported formats, without their aliases
+func getFormats() []string {
+	seen := map[string]bool{}
+	var formats []string
+	for _, name := range formatNames {
+		if !seen[name] {
+			seen[name] = true
+			formats = append(formats, name)
+		}
+	}
+	sort.Strings(formats)
+	return formats
+}
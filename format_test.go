@@ -0,0 +1,125 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestResolveFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	for format, expected := range map[string]string{
+		"":         "text",
+		"TEXT":     "text",
+		"Json":     "json",
+		"MARKDOWN": "md",
+		"slack":    "aligned",
+		"HTML-PRE": "html-pre",
+	} {
+		name, err := resolveFormat(format)
+		Expect(err).To(BeNil())
+		Expect(name).To(Equal(expected), format)
+	}
+
+	_, err := resolveFormat("xml")
+	Expect(err).To(Equal(&ErrInvalidFormat{Format: "xml"}))
+	Expect(err.Error()).To(Equal("invalid format xml, supported formats are aligned, csv, html, html-pre, json, json-ordered, md, text, text-fixed, yaml, yaml-docs"))
+}
+
+func TestFormatsOfAllRenderFunctions(t *testing.T) {
+	RegisterTestingT(t)
+
+	type server struct {
+		ID    int
+		Label string
+	}
+	obj := server{ID: 1, Label: "web-1"}
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	entryPoints := map[string]func(format string) (string, error){
+		"RenderTable": func(format string) (string, error) {
+			return table.RenderTable("servers", "", format)
+		},
+		"RenderTransposedTable": func(format string) (string, error) {
+			schema := []SchemaField{
+				{
+					FieldName:     "ID",
+					FieldType:     TypeInt,
+					FieldPriority: 2,
+				},
+				{
+					FieldName: "VENDOR",
+					FieldType: TypeString,
+				},
+				{
+					FieldName:     "NAME",
+					FieldType:     TypeString,
+					FieldPriority: 1,
+				},
+				{
+					FieldName: "PORTS",
+					FieldType: TypeInt,
+				},
+				{
+					FieldName:     "STATUS",
+					FieldType:     TypeString,
+					FieldPriority: 1,
+				},
+			}
+
+			data := [][]interface{}{
+				{1, "dell", "sw-1", 48, "active"},
+				{2, nil, "sw-2", 24, "down"},
+			}
+
+			table := Table{Data: data, Schema: schema}
+			return table.RenderTransposedTable("switches", "", format)
+		},
+		"RenderRawObject": func(format string) (string, error) {
+			return RenderRawObject(obj, format, "")
+		},
+	}
+
+	for name, render := range entryPoints {
+		for _, format := range getFormats() {
+			s, err := render(format)
+			Expect(err).To(BeNil(), name+" "+format)
+			Expect(s).NotTo(BeEmpty(), name+" "+format)
+		}
+
+		_, err := render("xml")
+		Expect(err).To(Equal(&ErrInvalidFormat{Format: "xml"}), name)
+	}
+
+	Expect(table.RenderTo(nil, WithFormat("xml"))).To(Equal(&ErrInvalidFormat{Format: "xml"}))
+	_, err := table.RenderTableWithAppendix("servers", "", "xml", obj)
+	Expect(err).To(Equal(&ErrInvalidFormat{Format: "xml"}))
+	_, err = (&DescribeView{Object: obj}).Render("xml")
+	Expect(err).To(Equal(&ErrInvalidFormat{Format: "xml"}))
+}
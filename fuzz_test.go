@@ -0,0 +1,73 @@
+//go:build go1.18
+// +build go1.18
+
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+)
+
+//The fuzz targets need the testing.F of Go 1.18 and are left out by the older toolchains.
+//Their seed corpus is coloredSamples, and the inputs found by fuzzing are kept in testdata/fuzz.
+
+func FuzzDecolorize(f *testing.F) {
+	for _, s := range coloredSamples {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d := decolorize(s)
+		if decolorize(d) != d {
+			t.Fatalf("decolorize is not idempotent for %q: %q", s, d)
+		}
+		if VisibleWidth(d) != VisibleWidth(s) {
+			t.Fatalf("decolorize changed the width of %q from %d to %d", s, VisibleWidth(s), VisibleWidth(d))
+		}
+	})
+}
+
+func FuzzPad(f *testing.F) {
+	for _, s := range coloredSamples {
+		f.Add(s, 20)
+	}
+	f.Fuzz(func(t *testing.T, s string, width int) {
+		if width < 0 || width > 1000 {
+			return
+		}
+		d := decolorize(s)
+		expected := VisibleWidth(d)
+		if expected < width {
+			expected = width
+		}
+		if w := VisibleWidth(pad(d, width)); w != expected {
+			t.Fatalf("pad(%q, %d) is %d wide, expected %d", d, width, w, expected)
+		}
+		if w := VisibleWidth(pad(s, width)); w != expected {
+			t.Fatalf("pad(%q, %d) is %d wide, expected %d", s, width, w, expected)
+		}
+	})
+}
+
+func FuzzGetTableRow(f *testing.F) {
+	for i, s := range coloredSamples {
+		f.Add(s, coloredSamples[(i+1)%len(coloredSamples)])
+	}
+	f.Fuzz(func(t *testing.T, first string, second string) {
+		schema := []SchemaField{
+			{FieldName: "FIRST", FieldType: TypeString},
+			{FieldName: "SECOND", FieldType: TypeString},
+			{FieldName: "ID", FieldType: TypeInt},
+		}
+		data := [][]interface{}{{first, second, 1}}
+
+		table := Table{Data: data, Schema: schema}
+		table.adjustFieldSizes(data, table.Schema)
+
+		lines := strings.Split(strings.TrimSuffix(getTableAsString(data, table.Schema, newRenderOptions()), "\n"), "\n")
+		for _, line := range lines {
+			if VisibleWidth(line) != VisibleWidth(lines[0]) {
+				t.Fatalf("line %q is %d wide, expected %d", line, VisibleWidth(line), VisibleWidth(lines[0]))
+			}
+		}
+	})
+}
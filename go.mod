@@ -6,5 +6,6 @@ require (
 	github.com/bigstepinc/metal-cloud-sdk-go v1.5.2
 	github.com/iancoleman/strcase v0.1.2
 	github.com/onsi/gomega v1.10.3
+	golang.org/x/net v0.0.0-20201006153459-a7d1128ccaa0
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,74 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//RenderForGolden renders the table in the given format for the golden files of tests, such as the txtar archives
+//of testscript, so that the output is the same on every machine. It pins
+//
+//	the colors, removed from the cells, the raw rows and the field names like with WithoutColors,
+//	the time zone, the time.Time and *time.Time cells being converted to UTC before they are formatted,
+//	the line endings, CRLF and CR being replaced by LF in the string cells and in the output,
+//	the trailing spaces and tabs of each line, removed, such as the one after the total of the text format.
+//
+//The maps are formatted with their keys sorted by every format. An error, such as an invalid format, is rendered
+//as an "error: " line so that it is part of the golden output.
+func RenderForGolden(t Table, format string) string {
+	s, err := renderForGolden(&t, format)
+	if err != nil {
+		s = fmt.Sprintf("error: %s\n", err)
+	}
+	return normalizeGoldenOutput(s)
+}
+
+//renderForGolden renders the table with the computed and extracted cells, see getGoldenCells
+func renderForGolden(t *Table, format string) (string, error) {
+	return t.Render(WithFormat(format), WithoutColors(), WithPreRender((*Table).getMaterializedTable, withGoldenCells))
+}
+
+//withGoldenCells is the PreRenderHook of RenderForGolden replacing the cells of the table, see getGoldenCells
+func withGoldenCells(t *Table) (*Table, error) {
+	t.Data = getGoldenCells(t.Data)
+	return t, nil
+}
+
+//getGoldenCells returns a copy of data with the time.Time and the non nil *time.Time cells converted to UTC time.Time cells
+//and the string cells with LF line endings
+func getGoldenCells(data [][]interface{}) [][]interface{} {
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		newRow := make([]interface{}, len(row))
+		for i, d := range row {
+			switch v := d.(type) {
+			case time.Time:
+				d = v.UTC()
+			case *time.Time:
+				if v != nil {
+					d = v.UTC()
+				}
+			case string:
+				d = withLFLineEndings(v)
+			}
+			newRow[i] = d
+		}
+		newData[k] = newRow
+	}
+	return newData
+}
+
+//withLFLineEndings returns s with the CRLF and CR line endings replaced by LF
+func withLFLineEndings(s string) string {
+	return strings.Replace(strings.Replace(s, "\r\n", "\n", -1), "\r", "\n", -1)
+}
+
+//normalizeGoldenOutput returns s with LF line endings and without trailing spaces and tabs
+func normalizeGoldenOutput(s string) string {
+	lines := strings.Split(withLFLineEndings(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
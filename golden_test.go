@@ -0,0 +1,126 @@
+package tableformatter
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderForGolden(t *testing.T) {
+	RegisterTestingT(t)
+
+	//getTable returns a table with cells that depend on the environment: times in the local time zone,
+	//colors, CRLF line endings and maps
+	getTable := func() Table {
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "LABEL",
+				FieldType: TypeString,
+			},
+			{
+				FieldName: "CREATED",
+				FieldType: TypeDateTime,
+			},
+			{
+				FieldName: "TAGS",
+				FieldType: TypeInterface,
+			},
+		}
+		created := time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC).In(time.Local)
+		data := [][]interface{}{
+			{1, "\x1b[32mweb-1\x1b[0m", created, map[string]int{"zone": 2, "rack": 1}},
+			{2, "db-1\r\nreplica", created.Add(time.Hour), nil},
+		}
+		return Table{Data: data, Schema: schema}
+	}
+
+	local := time.Local
+	defer func() {
+		time.Local = local
+	}()
+	for _, key := range []string{"TZ", "NO_COLOR"} {
+		value, ok := os.LookupEnv(key)
+		defer restoreEnv(key, value, ok)
+	}
+
+	environments := []struct {
+		tz      string
+		noColor string
+		zone    *time.Location
+	}{
+		{"UTC", "", time.UTC},
+		{"Asia/Tokyo", "1", time.FixedZone("JST", 9*3600)},
+		{"America/Los_Angeles", "1", time.FixedZone("PST", -8*3600)},
+	}
+
+	for _, format := range []string{"", "json", "csv", "yaml"} {
+		outputs := []string{}
+		for _, env := range environments {
+			os.Setenv("TZ", env.tz)
+			os.Setenv("NO_COLOR", env.noColor)
+			time.Local = env.zone
+			outputs = append(outputs, RenderForGolden(getTable(), format))
+		}
+		Expect(outputs[1]).To(Equal(outputs[0]), format)
+		Expect(outputs[2]).To(Equal(outputs[0]), format)
+		Expect(outputs[0]).NotTo(ContainSubstring("\r"), format)
+		Expect(outputs[0]).NotTo(ContainSubstring("\x1b"), format)
+		Expect(outputs[0]).NotTo(MatchRegexp(`[ \t]\n`), format)
+	}
+
+	time.Local = time.FixedZone("JST", 9*3600)
+	Expect(RenderForGolden(getTable(), "")).To(Equal(
+		"+----+---------+----------------------+--------------------+\n" +
+			"| ID | LABEL   | CREATED              | TAGS               |\n" +
+			"+----+---------+----------------------+--------------------+\n" +
+			"| 1  | web-1   | 2020-11-03T10:00:00Z | map[rack:1 zone:2] |\n" +
+			"| 2  | db-1    | 2020-11-03T11:00:00Z |                    |\n" +
+			"|    | replica |                      |                    |\n" +
+			"+----+---------+----------------------+--------------------+\n" +
+			"Total: 2\n\n"))
+
+	Expect(RenderForGolden(getTable(), "xml")).To(Equal("error: invalid format xml, supported formats are aligned, csv, html, html-pre, json, json-ordered, md, text, text-fixed, yaml, yaml-docs\n"))
+}
+
+func TestRenderForGoldenTimePointers(t *testing.T) {
+	RegisterTestingT(t)
+
+	local := time.Local
+	defer func() {
+		time.Local = local
+	}()
+
+	created := time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC)
+	var deleted *time.Time
+	table := Table{
+		Schema: []SchemaField{
+			{FieldName: "CREATED", FieldType: TypeDateTime, FieldFormat: "2006-01-02 15:04 MST"},
+			{FieldName: "DELETED", FieldType: TypeDateTime, FieldFormat: "2006-01-02 15:04 MST"},
+		},
+	}
+
+	outputs := []string{}
+	for _, zone := range []*time.Location{time.UTC, time.FixedZone("JST", 9*3600)} {
+		time.Local = zone
+		localCreated := created.In(time.Local)
+		table.Data = [][]interface{}{{&localCreated, deleted}}
+		outputs = append(outputs, RenderForGolden(table, "csv"))
+	}
+	Expect(outputs[0]).To(Equal("CREATED,DELETED\n2020-11-03 10:00 UTC,\n"))
+	Expect(outputs[1]).To(Equal(outputs[0]))
+}
+
+//restoreEnv sets an environment variable back to value, or unsets it if it was not set
+func restoreEnv(key string, value string, ok bool) {
+	if ok {
+		os.Setenv(key, value)
+	} else {
+		os.Unsetenv(key)
+	}
+}
@@ -0,0 +1,67 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableWithGroupSeparator(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:  "ID",
+			FieldType:  TypeInt,
+			FieldGroup: "identification",
+		},
+		{
+			FieldName:  "LABEL",
+			FieldType:  TypeString,
+			FieldGroup: "identification",
+		},
+		{
+			FieldName:  "IP",
+			FieldType:  TypeString,
+			FieldGroup: "network",
+		},
+		{
+			FieldName:  "STATUS",
+			FieldType:  TypeString,
+			FieldGroup: "status",
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "web", "10.0.0.1", "on"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("servers", "", "", WithGroupSeparator(""))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+----+-------+----------+--------+
+| ID | LABEL ‖ IP       ‖ STATUS |
++----+-------+----------+--------+
+| 1  | web   ‖ 10.0.0.1 ‖ on     |
++----+-------+----------+--------+
+Total: 1 servers
+
+`))
+
+	s, err = table.RenderTable("servers", "", "", WithGroupSeparator("||"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+----+-------++----------++--------+
+| ID | LABEL || IP       || STATUS |
++----+-------++----------++--------+
+| 1  | web   || 10.0.0.1 || on     |
++----+-------++----------++--------+
+Total: 1 servers
+
+`))
+
+	//machine formats ignore the groups
+	s, err = table.RenderTable("servers", "", "csv", WithGroupSeparator(""))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,LABEL,IP,STATUS\n1,web,10.0.0.1,on\n"))
+}
@@ -0,0 +1,43 @@
+package tableformatter
+
+//hasHiddenFields returns true if any of the fields of the schema is hidden
+func hasHiddenFields(schema []SchemaField) bool {
+	for _, field := range schema {
+		if field.FieldHidden {
+			return true
+		}
+	}
+	return false
+}
+
+//getVisibleSchema returns the fields of the schema that are not hidden
+func getVisibleSchema(schema []SchemaField) []SchemaField {
+	visible := make([]SchemaField, 0, len(schema))
+	for _, field := range schema {
+		if !field.FieldHidden {
+			visible = append(visible, field)
+		}
+	}
+	return visible
+}
+
+//withoutHiddenFields returns a copy of data without the cells of the hidden fields of the schema, which describes the cells of data.
+//Raw rows are kept as is and cells that are not described by the schema are dropped.
+func withoutHiddenFields(data [][]interface{}, schema []SchemaField) [][]interface{} {
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, 0, len(schema))
+		for i, field := range schema {
+			if !field.FieldHidden && i < len(row) {
+				newRow = append(newRow, row[i])
+			}
+		}
+		newData[k] = newRow
+	}
+	return newData
+}
@@ -0,0 +1,90 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFieldHidden(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:   "CREATED_TIMESTAMP",
+			FieldType:   TypeInt,
+			FieldHidden: true,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", 1604400000, "active"},
+		{"db-1", 1604300000, "down"},
+		{"web-2", 1604500000, "active"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	for _, format := range getFormats() {
+		s, err := table.Render(WithFormat(format))
+		Expect(err).To(BeNil(), format)
+		Expect(s).To(ContainSubstring("web-1"), format)
+		Expect(s).NotTo(ContainSubstring("1604400000"), format)
+		Expect(s).NotTo(MatchRegexp("(?i)created"), format)
+	}
+
+	s, err := table.Render(WithTransposed())
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("CREATED_TIMESTAMP"))
+
+	s, err = table.RenderTableAsSQL("servers")
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("CREATED_TIMESTAMP"))
+
+	//the hidden field keeps its cells and its size
+	Expect(table.Data[0]).To(HaveLen(3))
+	Expect(table.Schema[1].FieldSize).To(Equal(0))
+	table.AdjustFieldSizes()
+	Expect(table.Schema[1].FieldSize).To(Equal(0))
+	Expect(table.Schema[0].FieldSize).To(Equal(6))
+}
+
+func TestFieldHiddenSort(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:   "CREATED_TIMESTAMP",
+			FieldType:   TypeInt,
+			FieldHidden: true,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", 1604400000, "active"},
+		{"db-1", 1604300000, "down"},
+		{"web-2", 1604500000, "active"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	Expect(TableSorter(table.Schema).OrderBy("CREATED_TIMESTAMP").SortErr(table.Data)).To(Succeed())
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("LABEL,STATUS\ndb-1,down\nweb-1,active\nweb-2,active\n"))
+}
@@ -0,0 +1,124 @@
+package tableformatter
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+//PreRenderHook transforms the table before it is rendered, see RenderOptions.PreRender. It is given a copy
+//of the table that it may change and returns the table to render, which can be the same.
+type PreRenderHook func(*Table) (*Table, error)
+
+//PostRenderHook transforms the output of the render, see RenderOptions.PostRender
+type PostRenderHook func(string) (string, error)
+
+//WithPreRender appends hooks to RenderOptions.PreRender
+func WithPreRender(hooks ...PreRenderHook) RenderOption {
+	return func(o *RenderOptions) {
+		o.PreRender = append(o.PreRender, hooks...)
+	}
+}
+
+//WithPostRender appends hooks to RenderOptions.PostRender
+func WithPostRender(hooks ...PostRenderHook) RenderOption {
+	return func(o *RenderOptions) {
+		o.PostRender = append(o.PostRender, hooks...)
+	}
+}
+
+//UppercaseHeaders is a PreRenderHook that writes the names of the fields in upper case
+func UppercaseHeaders(t *Table) (*Table, error) {
+	for i := range t.Schema {
+		t.Schema[i].FieldName = strings.ToUpper(t.Schema[i].FieldName)
+	}
+	return t, nil
+}
+
+//StripColorsHook is a PostRenderHook that removes the ANSI escape sequences from the output. Unlike WithoutColors
+//the cells are measured with their colors so the columns are as wide as when the output is colored.
+func StripColorsHook(s string) (string, error) {
+	return decolorize(s), nil
+}
+
+//getHookName returns the name of the function of a hook, such as tableformatter.UppercaseHeaders, for the errors
+func getHookName(hook interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(hook).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+	name := f.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+//getHookCopy returns a copy of the table with its own schema and rows, given to the pre-render hooks
+func (t *Table) getHookCopy() *Table {
+	c := *t
+	c.Schema = append([]SchemaField(nil), t.Schema...)
+	if t.Data != nil {
+		c.Data = make([][]interface{}, len(t.Data))
+		for k, row := range t.Data {
+			c.Data[k] = append([]interface{}(nil), row...)
+		}
+	}
+	return &c
+}
+
+//applyPreRenderHooks returns the table returned by the pre-render hooks of the options applied in order to a copy
+//of the table, or the table itself if there are none
+func applyPreRenderHooks(t *Table, options *RenderOptions) (*Table, error) {
+	if len(options.PreRender) == 0 {
+		return t, nil
+	}
+
+	t = t.getHookCopy()
+	for i, hook := range options.PreRender {
+		next, err := hook(t)
+		if err != nil {
+			return nil, wrapError(err, "pre-render hook %d (%s) failed", i+1, getHookName(hook))
+		}
+		if next == nil {
+			return nil, fmt.Errorf("pre-render hook %d (%s) returned no table", i+1, getHookName(hook))
+		}
+		t = next
+	}
+	return t, nil
+}
+
+//applyPostRenderHooks returns the output transformed by the post-render hooks of the options applied in order
+func applyPostRenderHooks(s string, options *RenderOptions) (string, error) {
+	for i, hook := range options.PostRender {
+		var err error
+		s, err = hook(s)
+		if err != nil {
+			return "", wrapError(err, "post-render hook %d (%s) failed", i+1, getHookName(hook))
+		}
+	}
+	return s, nil
+}
+
+//renderWithHooks renders the table as set by the options, the pre-render hooks being applied to the table
+//and the post-render hooks to the output, also to the partial output returned with an ErrOutputTruncated
+func (t *Table) renderWithHooks(options *RenderOptions) (string, error) {
+	t, err := applyPreRenderHooks(t, options)
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+	if options.Transposed {
+		s, err = t.renderTransposedTable(options)
+	} else {
+		s, err = t.renderTable(options)
+	}
+	if _, ok := err.(*ErrOutputTruncated); err != nil && !ok {
+		return s, err
+	}
+
+	s, hookErr := applyPostRenderHooks(s, options)
+	if hookErr != nil {
+		return "", hookErr
+	}
+	return s, err
+}
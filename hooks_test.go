@@ -0,0 +1,235 @@
+package tableformatter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderHooksComposition(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	dropFirstRow := func(t *Table) (*Table, error) {
+		t.Data = t.Data[1:]
+		return t, nil
+	}
+	s, err := table.Render(
+		WithFormat("csv"),
+		WithPreRender(UppercaseHeaders, dropFirstRow),
+		WithPostRender(func(s string) (string, error) {
+			return strings.Replace(s, ",", ";", -1), nil
+		}, StripColorsHook))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID;LABEL\n2;web-2\n"))
+
+	//the hooks are given a copy of the table
+	Expect(table.Schema[0].FieldName).To(Equal("id"))
+	Expect(table.Data).To(HaveLen(2))
+}
+
+func TestRenderHooksOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	appendTo := func(suffix string) PostRenderHook {
+		return func(s string) (string, error) {
+			return strings.TrimSpace(s) + suffix, nil
+		}
+	}
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	s, err := table.Render(WithFormat("csv"), WithPostRender(appendTo(" a")), WithPostRender(appendTo(" b")))
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix(" a b"))
+}
+
+func TestStripColorsHook(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	colored, err := table.Render()
+	Expect(err).To(BeNil())
+	s, err := table.Render(WithPostRender(StripColorsHook))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(decolorize(colored)))
+	Expect(s).NotTo(ContainSubstring("\x1b"))
+}
+
+func TestRenderHooksErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+
+	errHook := errors.New("hook error")
+	failing := func(t *Table) (*Table, error) {
+		return nil, errHook
+	}
+	_, err := table.Render(WithPreRender(UppercaseHeaders, failing))
+	Expect(unwrapError(err)).To(BeIdenticalTo(errHook))
+	Expect(err.Error()).To(HavePrefix("pre-render hook 2 (tableformatter.TestRenderHooksErrors.func1) failed"))
+
+	noTable := func(t *Table) (*Table, error) {
+		return nil, nil
+	}
+	_, err = table.Render(WithPreRender(noTable))
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("returned no table"))
+
+	failingPost := func(s string) (string, error) {
+		return s, errHook
+	}
+	s, err := table.Render(WithPostRender(StripColorsHook, failingPost))
+	Expect(s).To(Equal(""))
+	Expect(unwrapError(err)).To(BeIdenticalTo(errHook))
+	Expect(err.Error()).To(HavePrefix("post-render hook 2 (tableformatter.TestRenderHooksErrors.func3) failed"))
+
+	var sb strings.Builder
+	err = table.RenderTo(&sb, WithPreRender(failing))
+	Expect(unwrapError(err)).To(BeIdenticalTo(errHook))
+	Expect(sb.String()).To(Equal(""))
+}
+
+func TestRenderToHooks(t *testing.T) {
+	RegisterTestingT(t)
+
+	opts := []RenderOption{WithFormat("csv"), WithPreRender(UppercaseHeaders), WithPostRender(StripColorsHook)}
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	expected, err := table.Render(opts...)
+	Expect(err).To(BeNil())
+
+	var sb strings.Builder
+	Expect(table.RenderTo(&sb, opts...)).To(BeNil())
+	Expect(sb.String()).To(Equal(expected))
+	Expect(expected).To(Equal("ID,LABEL\n1,web-1\n2,web-2\n"))
+}
+
+func TestRenderHooksTransposed(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	s, err := table.Render(WithTransposed(), WithPreRender(UppercaseHeaders))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("LABEL"))
+	Expect(s).NotTo(ContainSubstring("label"))
+}
+
+func TestPostRenderHooksWithTruncatedOutput(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "id",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "label",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "\x1b[31mweb-1\x1b[0m"},
+		{2, "web-2"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	for i := 3; i <= 50; i++ {
+		table.Data = append(table.Data, []interface{}{i, "web"})
+	}
+	upper := func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	}
+	s, err := table.Render(WithMaxOutputBytes(300), WithPostRender(upper))
+	_, ok := err.(*ErrOutputTruncated)
+	Expect(ok).To(BeTrue())
+	Expect(s).NotTo(BeEmpty())
+	Expect(s).To(Equal(strings.ToUpper(s)))
+}
+
+//unwrapError returns the error wrapped by err
+func unwrapError(err error) error {
+	return err.(interface{ Unwrap() error }).Unwrap()
+}
@@ -0,0 +1,60 @@
+package tableformatter
+
+import (
+	"html"
+	"strings"
+)
+
+//HTMLOptions are the CSS classes of the elements rendered by the html format. Empty classes are omitted.
+type HTMLOptions struct {
+	TableClass  string
+	HeaderClass string
+	RowClass    string
+}
+
+//getHTMLClass returns the class attribute for class or nothing if class is empty
+func getHTMLClass(class string) string {
+	if class == "" {
+		return ""
+	}
+	return ` class="` + html.EscapeString(class) + `"`
+}
+
+//getHTMLCell returns the escaped cell text without colors and with the new lines replaced by <br>
+func getHTMLCell(s string) string {
+	lines := strings.Split(decolorize(s), "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return strings.Join(lines, "<br>")
+}
+
+//getTableAsHTMLString returns the table as a html table element.
+//Cells are formatted like in the text format.
+func getTableAsHTMLString(data [][]interface{}, schema []SchemaField, options *HTMLOptions) string {
+	if options == nil {
+		options = &HTMLOptions{}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("<table" + getHTMLClass(options.TableClass) + ">\n")
+	sb.WriteString("<thead>\n<tr" + getHTMLClass(options.HeaderClass) + ">")
+	for _, field := range schema {
+		sb.WriteString("<th>" + getHTMLCell(field.FieldName) + "</th>")
+	}
+	sb.WriteString("</tr>\n</thead>\n")
+
+	sb.WriteString("<tbody>\n")
+	for _, row := range data {
+		sb.WriteString("<tr" + getHTMLClass(options.RowClass) + ">")
+		for i := range schema {
+			sb.WriteString("<td>" + getHTMLCell(strings.Join(getCellLines(row[i], &schema[i]), "\n")) + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n")
+	sb.WriteString("</table>\n")
+
+	return sb.String()
+}
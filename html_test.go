@@ -0,0 +1,84 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/html"
+)
+
+//getHTMLTableCells parses a html document and returns the text of the th and td elements of each row
+func getHTMLTableCells(s string) ([][]string, error) {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "tr" {
+			rows = append(rows, []string{})
+		}
+		if n.Type == html.ElementNode && (n.Data == "th" || n.Data == "td") {
+			var sb strings.Builder
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.TextNode {
+					sb.WriteString(c.Data)
+				} else if c.Data == "br" {
+					sb.WriteString("\n")
+				}
+			}
+			rows[len(rows)-1] = append(rows[len(rows)-1], sb.String())
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+	}
+	visit(doc)
+
+	return rows, nil
+}
+
+func TestRenderTableAsHTML(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "<script>alert(1)</script>"},
+		{2, "\x1b[31mred\x1b[0m\nsecond & line"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("items", "", "html")
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("<script>"))
+	Expect(s).To(ContainSubstring("&lt;script&gt;alert(1)&lt;/script&gt;"))
+	Expect(s).To(ContainSubstring("<td>red<br>second &amp; line</td>"))
+
+	rows, err := getHTMLTableCells(s)
+	Expect(err).To(BeNil())
+	Expect(rows).To(Equal([][]string{
+		{"ID", "LABEL"},
+		{"1", "<script>alert(1)</script>"},
+		{"2", "red\nsecond & line"},
+	}))
+
+	s, err = table.RenderTable("items", "", "html", WithHTMLOptions(HTMLOptions{TableClass: "items", RowClass: "item"}))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix(`<table class="items">` + "\n<thead>\n<tr><th>ID</th>"))
+	Expect(strings.Count(s, `<tr class="item">`)).To(Equal(2))
+}
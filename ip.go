@@ -0,0 +1,103 @@
+package tableformatter
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+)
+
+//ipHandler handles the addresses of TypeIP fields, rendered as they are in every format
+type ipHandler struct{ stringHandler }
+
+//ipAddress is an IPv4 or IPv6 address with its zone and the length of its prefix, the length of the address if it has none.
+//The ip holds 4 bytes for the IPv4 addresses and 16 bytes for the IPv6 ones.
+type ipAddress struct {
+	ip   net.IP
+	zone string
+	bits int
+}
+
+//parseIPAddress parses an IPv4 or IPv6 address with an optional /prefix suffix, such as 10.0.0.9 or 2a02:c00::1/53.
+//The IPv6 addresses can have a %zone, such as fe80::1%eth0. The addresses written with a colon are IPv6 addresses,
+//::ffff:10.0.0.1 included.
+func parseIPAddress(s string) (ipAddress, bool) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexByte(s, '/')
+	suffix := ""
+	if i >= 0 {
+		s, suffix = s[:i], s[i+1:]
+	}
+
+	zone := ""
+	if k := strings.IndexByte(s, '%'); k >= 0 {
+		s, zone = s[:k], s[k+1:]
+		if zone == "" {
+			return ipAddress{}, false
+		}
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return ipAddress{}, false
+	}
+	if !strings.Contains(s, ":") {
+		if zone != "" {
+			return ipAddress{}, false
+		}
+		ip = ip.To4()
+	}
+	bits := len(ip) * 8
+	if i >= 0 {
+		n, err := strconv.Atoi(suffix)
+		if err != nil || n < 0 || n > bits {
+			return ipAddress{}, false
+		}
+		bits = n
+	}
+	return ipAddress{ip: ip, zone: zone, bits: bits}, true
+}
+
+//compareIPAddresses compares the addresses numerically, the IPv4 addresses before the IPv6 ones,
+//then by their zone and by the length of their prefix. It returns -1 if a is before b, 1 if it is after
+//and 0 if they are equal.
+func compareIPAddresses(a, b ipAddress) int {
+	switch {
+	case len(a.ip) < len(b.ip):
+		return -1
+	case len(a.ip) > len(b.ip):
+		return 1
+	}
+	if c := bytes.Compare(a.ip, b.ip); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.zone, b.zone); c != 0 {
+		return c
+	}
+	switch {
+	case a.bits < b.bits:
+		return -1
+	case a.bits > b.bits:
+		return 1
+	}
+	return 0
+}
+
+//Less orders the addresses numerically, see compareIPAddresses, and the strings that are not addresses
+//after them, compared as strings
+func (ipHandler) Less(a, b interface{}, field *SchemaField) bool {
+	sa, sb := getInterfaceAsString(a), getInterfaceAsString(b)
+	ipA, okA := parseIPAddress(sa)
+	ipB, okB := parseIPAddress(sb)
+
+	switch {
+	case okA && okB:
+		if c := compareIPAddresses(ipA, ipB); c != 0 {
+			return c < 0
+		}
+		return sa < sb
+	case okA != okB:
+		return okA
+	}
+	return sa < sb
+}
@@ -0,0 +1,141 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTypeIPSort(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "ADDRESS",
+			FieldType: TypeIP,
+		},
+	}
+	data := [][]interface{}{
+		{1, "10.0.0.10"},
+		{2, "2a02:c00::1/53"},
+		{3, "10.0.0.9"},
+		{4, "2a02:c00::10"},
+		{5, "2a02:c00::9"},
+		{6, "192.168.0.1"},
+		{7, "10.0.0.0/24"},
+		{8, "::1"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	Expect(TableSorter(table.Schema).OrderBy("ADDRESS").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{7, 3, 1, 6, 8, 2, 5, 4}))
+
+	Expect(TableSorter(table.Schema).OrderBy("-ADDRESS").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{4, 5, 2, 8, 6, 1, 3, 7}))
+}
+
+func TestTypeIPSortInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "ADDRESS",
+			FieldType: TypeIP,
+		},
+	}
+	data := [][]interface{}{
+		{1, "10.0.0.10"},
+		{2, "2a02:c00::1/53"},
+		{3, "10.0.0.9"},
+		{4, "2a02:c00::10"},
+		{5, "2a02:c00::9"},
+		{6, "192.168.0.1"},
+		{7, "10.0.0.0/24"},
+		{8, "::1"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Data = [][]interface{}{
+		{1, "not an address"},
+		{2, "10.0.0.10"},
+		{3, nil},
+		{4, "10.0.0.256"},
+		{5, "10.0.0.9"},
+		{6, "10.0.0.9/33"},
+	}
+	Expect(TableSorter(table.Schema).OrderBy("ADDRESS").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{5, 2, 4, 6, 1, 3}))
+
+	//the values that are not addresses stay last whatever the direction
+	Expect(TableSorter(table.Schema).OrderBy("-ADDRESS").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{2, 5, 4, 6, 1, 3}))
+}
+
+func TestParseIPAddress(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{"10.0.0.9", "10.0.0.0/8", "::1", "2a02:c00::1/53", "fe80::1%eth0", "::ffff:10.0.0.1"} {
+		_, ok := parseIPAddress(s)
+		Expect(ok).To(BeTrue(), s)
+	}
+	for _, s := range []string{"", "10.0.0", "10.0.0.256", "10.0.0.1/", "10.0.0.1/33", "::1/129", "::1/x", "host.example.com", "10.0.0.1%eth0", "fe80::1%"} {
+		_, ok := parseIPAddress(s)
+		Expect(ok).To(BeFalse(), s)
+	}
+	//the IPv4 addresses written as IPv6 addresses are IPv6 addresses, sorted after the IPv4 ones
+	ipv4, _ := parseIPAddress("10.0.0.1")
+	mapped, _ := parseIPAddress("::ffff:10.0.0.1")
+	Expect(ipv4.bits).To(Equal(32))
+	Expect(mapped.bits).To(Equal(128))
+	Expect(compareIPAddresses(ipv4, mapped)).To(Equal(-1))
+}
+
+func TestTypeIPRendersOriginalString(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "ADDRESS",
+			FieldType: TypeIP,
+		},
+	}
+	data := [][]interface{}{
+		{1, "10.0.0.10"},
+		{2, "2a02:c00::1/53"},
+		{3, "10.0.0.9"},
+		{4, "2a02:c00::10"},
+		{5, "2a02:c00::9"},
+		{6, "192.168.0.1"},
+		{7, "10.0.0.0/24"},
+		{8, "::1"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Data = [][]interface{}{
+		{1, "2A02:0C00:0000::0001/53"},
+		{2, "::ffff:10.0.0.1"},
+		{3, "10.0.0.256"},
+	}
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,ADDRESS\n1,2A02:0C00:0000::0001/53\n2,::ffff:10.0.0.1\n3,10.0.0.256\n"))
+
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"ADDRESS": "2A02:0C00:0000::0001/53"`))
+
+	s, err = table.Render(WithoutColors())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 2A02:0C00:0000::0001/53 |"))
+	Expect(s).To(ContainSubstring("| ::ffff:10.0.0.1         |"))
+}
@@ -0,0 +1,77 @@
+package tableformatter
+
+import "fmt"
+
+//outputSizeCheckInterval is the number of rows rendered between two checks of the output size
+const outputSizeCheckInterval = 50
+
+//ErrOutputTruncated is returned together with the partial output when the output of a render call
+//would exceed RenderOptions.MaxOutputBytes. The partial output is a complete document of the requested format
+//holding the first RenderedRows rows, headers included, and never exceeds the limit: if not even the document
+//without rows fits, such as the header and the delimiter of the text format, the output is empty.
+type ErrOutputTruncated struct {
+	//RenderedRows is the number of rows of the partial output, not counting raw rows
+	RenderedRows int
+	//TotalRows is the number of rows of the table, not counting raw rows
+	TotalRows int
+}
+
+func (e *ErrOutputTruncated) Error() string {
+	return fmt.Sprintf("output truncated: rendered %d of %d rows", e.RenderedRows, e.TotalRows)
+}
+
+//limitOutput returns the number of rows of the longest prefix of rows that render renders in at most maxBytes bytes.
+//The size is estimated by rendering the rows in chunks, so that at most one chunk is held in memory.
+func limitOutput(rows [][]interface{}, render func(rows [][]interface{}) (string, error), maxBytes int) (int, error) {
+	//the size of the parts of the output that do not depend on the rows, such as headers and brackets
+	empty, err := render(rows[:0])
+	if err != nil {
+		return 0, err
+	}
+	overhead := len(empty)
+
+	size := overhead
+	n := 0
+	chunkSize := outputSizeCheckInterval
+	for n < len(rows) {
+		end := n + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		s, err := render(rows[n:end])
+		if err != nil {
+			return 0, err
+		}
+
+		if size+len(s)-overhead <= maxBytes {
+			size += len(s) - overhead
+			n = end
+			continue
+		}
+
+		//the limit is inside this chunk, continue one row at a time
+		if chunkSize == 1 {
+			break
+		}
+		chunkSize = 1
+	}
+
+	if n == len(rows) {
+		return n, nil
+	}
+
+	//the estimate ignores the separators between the chunks, drop rows until the real output fits
+	for n > 0 {
+		s, err := render(rows[:n])
+		if err != nil {
+			return 0, err
+		}
+		if len(s) <= maxBytes {
+			break
+		}
+		n--
+	}
+
+	return n, nil
+}
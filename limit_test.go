@@ -0,0 +1,156 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableWithMaxOutputBytes(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for i := 0; i < 1000; i++ {
+		data = append(data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	for _, format := range []string{"", "json", "json-ordered", "csv", "yaml", "yaml-docs", "aligned", "html-pre"} {
+		s, err := table.RenderTable("rows", "top line", format, WithMaxOutputBytes(2000))
+		Expect(len(s)).To(BeNumerically("<=", 2000), format)
+		Expect(len(s)).To(BeNumerically(">", 1500), format)
+
+		truncated, ok := err.(*ErrOutputTruncated)
+		Expect(ok).To(BeTrue(), format)
+		Expect(truncated.TotalRows).To(Equal(1000), format)
+		Expect(truncated.RenderedRows).To(BeNumerically(">", 0), format)
+		Expect(truncated.RenderedRows).To(BeNumerically("<", 1000), format)
+
+		switch format {
+		case "json", "json-ordered":
+			var rows []map[string]interface{}
+			Expect(json.Unmarshal([]byte(s), &rows)).To(BeNil(), format)
+			Expect(rows).To(HaveLen(truncated.RenderedRows), format)
+		case "csv":
+			rows, err := CSVUnmarshal(s)
+			Expect(err).To(BeNil())
+			Expect(rows).To(HaveLen(truncated.RenderedRows + 1))
+		case "":
+			Expect(s).To(HavePrefix("top line\n"))
+			Expect(s).To(HaveSuffix("Total: 1000 rows\n\n"))
+		case "html-pre":
+			Expect(s).To(HavePrefix("<pre"))
+			Expect(s).To(HaveSuffix("Total: 1000 rows\n\n</pre>\n"))
+		}
+	}
+
+	//a table that fits is not truncated
+	s, err := table.RenderTable("rows", "", "json", WithMaxOutputBytes(1000000))
+	Expect(err).To(BeNil())
+	expected, err := table.RenderTable("rows", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+}
+
+func TestRenderTableWithMaxOutputBytesSmallerThanTheHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for i := 0; i < 3; i++ {
+		data = append(data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Schema[1].FieldName = "A_FIELD_NAME_LONGER_THAN_THE_LIMIT_OF_THE_OUTPUT_SIZE"
+
+	for _, format := range []string{"", "text-fixed", "aligned", "md", "html", "html-pre", "csv", "json", "yaml"} {
+		for _, transposed := range []bool{false, true} {
+			opts := []RenderOption{WithFormat(format), WithTableName("rows"), WithMaxOutputBytes(50)}
+			if transposed {
+				opts = append(opts, WithTransposed())
+			}
+			s, err := table.Render(opts...)
+			Expect(len(s)).To(BeNumerically("<=", 50), format)
+			truncated, ok := err.(*ErrOutputTruncated)
+			Expect(ok).To(BeTrue(), format)
+			Expect(truncated.RenderedRows).To(Equal(0), format)
+		}
+	}
+
+	//the text format without rows is empty if its header does not fit
+	s, err := table.Render(WithMaxOutputBytes(50))
+	Expect(s).To(Equal(""))
+	Expect(err).To(Equal(&ErrOutputTruncated{RenderedRows: 0, TotalRows: 3}))
+
+	//and rendered if it does
+	s, err = table.Render(WithMaxOutputBytes(300))
+	Expect(s).To(ContainSubstring("A_FIELD_NAME_LONGER_THAN_THE_LIMIT_OF_THE_OUTPUT_SIZE"))
+	Expect(len(s)).To(BeNumerically("<=", 300))
+	Expect(err).To(BeAssignableToTypeOf(&ErrOutputTruncated{}))
+}
+
+func TestRenderHTMLPreWithMaxOutputBytes(t *testing.T) {
+	RegisterTestingT(t)
+
+	//the colors and the escaped characters make the html longer than the text
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for i := 0; i < 20; i++ {
+		data = append(data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	table := Table{Data: data, Schema: schema}
+	for _, row := range table.Data {
+		row[1] = "\x1b[31m<" + row[1].(string) + ">\x1b[0m"
+	}
+	full, err := table.Render(WithFormat("html-pre"))
+	Expect(err).To(BeNil())
+
+	for _, limit := range []int{60, 200, 700, len(full) / 2, len(full) - 1} {
+		s, err := table.Render(WithFormat("html-pre"), WithMaxOutputBytes(limit))
+		Expect(len(s)).To(BeNumerically("<=", limit), "%d", limit)
+		Expect(err).To(BeAssignableToTypeOf(&ErrOutputTruncated{}), "%d", limit)
+		if s != "" {
+			Expect(s).To(HaveSuffix("</pre>\n"))
+		}
+	}
+
+	s, err := table.Render(WithFormat("html-pre"), WithMaxOutputBytes(len(full)))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(full))
+}
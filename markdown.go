@@ -0,0 +1,69 @@
+package tableformatter
+
+import "strings"
+
+//markdownCellReplacer escapes the characters of a cell that would break a markdown table row
+var markdownCellReplacer = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+//getTableAsMarkdownString returns a GitHub flavored markdown table.
+//Cells are formatted like in the text format, without colors, with | escaped and new lines replaced by <br>.
+func getTableAsMarkdownString(data [][]interface{}, schema []SchemaField) string {
+	var sb strings.Builder
+
+	header := make([]string, len(schema))
+	separator := make([]string, len(schema))
+	for i, field := range schema {
+		header[i] = markdownCellReplacer.Replace(decolorize(field.FieldName))
+		separator[i] = markdownAlignments[field.FieldAlignment]
+	}
+	writeMarkdownRow(&sb, header)
+	writeMarkdownRow(&sb, separator)
+
+	for _, row := range data {
+		cells := make([]string, len(schema))
+		for i := range schema {
+			cells[i] = markdownCellReplacer.Replace(decolorize(strings.Join(getCellLines(row[i], &schema[i]), "\n")))
+		}
+		writeMarkdownRow(&sb, cells)
+	}
+
+	return sb.String()
+}
+
+//writeMarkdownRow writes the already escaped cells as a markdown table row
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		sb.WriteString(" ")
+		sb.WriteString(cell)
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
+}
+
+//getObjectAsMarkdownString returns the first row of the table as a two column KEY and VALUE markdown table
+func getObjectAsMarkdownString(t *Table) (string, error) {
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		return "", err
+	}
+
+	schema := t.getResolvedSchema()
+	keyValueData := [][]interface{}{}
+	for i := range schema {
+		keyValueData = append(keyValueData, []interface{}{schema[i].FieldName, strings.Join(getCellLines(data[0][i], &schema[i]), "\n")})
+	}
+
+	keyValueSchema := []SchemaField{
+		{
+			FieldName: "KEY",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "VALUE",
+			FieldType: TypeString,
+		},
+	}
+
+	return getTableAsMarkdownString(keyValueData, keyValueSchema), nil
+}
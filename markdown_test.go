@@ -0,0 +1,110 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//parseMarkdownTable splits a markdown table into its rows and unescaped cells, skipping the separator line
+func parseMarkdownTable(s string) [][]string {
+	rows := [][]string{}
+	for k, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		if k == 1 {
+			continue
+		}
+		line = strings.TrimSuffix(strings.TrimPrefix(line, "| "), " |")
+
+		cells := []string{}
+		var cell strings.Builder
+		for i := 0; i < len(line); i++ {
+			switch {
+			case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+				cell.WriteByte('|')
+				i++
+			case strings.HasPrefix(line[i:], " | "):
+				cells = append(cells, strings.Replace(cell.String(), "<br>", "\n", -1))
+				cell.Reset()
+				i += 2
+			default:
+				cell.WriteByte(line[i])
+			}
+		}
+		rows = append(rows, append(cells, strings.Replace(cell.String(), "<br>", "\n", -1)))
+	}
+	return rows
+}
+
+func TestRenderTableAsMarkdown(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "a|b", 10.5},
+		{2, "\x1b[31mred\x1b[0m", 1.257},
+		NewRawRow("--- skipped ---"),
+		{3, "two\nlines", 3.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("items", "", "md")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"| ID | LABEL | COST |\n" +
+			"| --- | --- | --- |\n" +
+			"| 1 | a\\|b | 10.50 |\n" +
+			"| 2 | red | 1.26 |\n" +
+			"| 3 | two<br>lines | 3.00 |\n"))
+
+	s2, err := table.RenderTable("items", "", "markdown")
+	Expect(err).To(BeNil())
+	Expect(s2).To(Equal(s))
+
+	rows := parseMarkdownTable(s)
+	Expect(rows).To(HaveLen(4))
+	for _, row := range rows {
+		Expect(row).To(HaveLen(3))
+	}
+	Expect(rows[1][1]).To(Equal("a|b"))
+	Expect(rows[3][1]).To(Equal("two\nlines"))
+}
+
+func TestRenderRawObjectAsMarkdown(t *testing.T) {
+	RegisterTestingT(t)
+
+	type server struct {
+		ServerID    int
+		ServerLabel string
+	}
+
+	s, err := RenderRawObject(server{ServerID: 100, ServerLabel: "db|1"}, "markdown", "Server")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"| KEY | VALUE |\n" +
+			"| --- | --- |\n" +
+			"| Id | 100 |\n" +
+			"| Label | db\\|1 |\n"))
+
+	rows := parseMarkdownTable(s)
+	Expect(rows).To(HaveLen(3))
+	for _, row := range rows {
+		Expect(row).To(HaveLen(2))
+	}
+}
@@ -0,0 +1,39 @@
+package tableformatter
+
+import "fmt"
+
+//applyValueMasks returns a copy of data with the ValueMasks of the options applied to the cells of their fields.
+//data is returned as is if there are no masks.
+func applyValueMasks(data [][]interface{}, schema []SchemaField, options *RenderOptions) ([][]interface{}, error) {
+	if len(options.ValueMasks) == 0 {
+		return data, nil
+	}
+
+	masks := make([][]ValueMask, len(schema))
+	for fieldName, fieldMasks := range options.ValueMasks {
+		i := getFieldIndex(schema, fieldName)
+		if i == -1 {
+			return nil, fmt.Errorf("could not find field with name %s to mask", fieldName)
+		}
+		masks[i] = fieldMasks
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, len(row))
+		copy(newRow, row)
+		for i, fieldMasks := range masks {
+			for _, mask := range fieldMasks {
+				newRow[i] = mask(newRow[i])
+			}
+		}
+		newData[k] = newRow
+	}
+
+	return newData, nil
+}
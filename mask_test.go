@@ -0,0 +1,79 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableWithValueMask(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "PASSWORD",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "secret", 10.5},
+		{2, "a much longer secret", 1.25},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	hide := func(value interface{}) interface{} {
+		return "•••"
+	}
+
+	s, err := table.RenderTable("users", "", "", WithValueMask("PASSWORD", hide), WithValueMask("COST", hide))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+----+----------+------+
+| ID | PASSWORD | COST |
++----+----------+------+
+| 1  | •••      | •••  |
+| 2  | •••      | •••  |
++----+----------+------+
+Total: 2 users
+
+`))
+
+	//the data is not changed and json is not masked
+	Expect(data[0][1]).To(Equal("secret"))
+	s, err = table.RenderTable("users", "", "json", WithValueMask("PASSWORD", hide))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("a much longer secret"))
+
+	s, err = table.RenderTable("users", "", "csv", WithValueMask("PASSWORD", hide))
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("secret"))
+
+	//masks compose in order
+	upper := func(value interface{}) interface{} {
+		return strings.ToUpper(value.(string))
+	}
+	suffix := func(value interface{}) interface{} {
+		return value.(string) + "!"
+	}
+	s, err = table.RenderTransposedTable("users", "", "", WithValueMask("PASSWORD", upper), WithValueMask("PASSWORD", suffix))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| SECRET! "))
+
+	s, err = table.RenderTransposedTableHumanReadable("", "", WithValueMask("PASSWORD", hide))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("PASSWORD: •••\n"))
+
+	_, err = table.RenderTable("users", "", "", WithValueMask("UNKNOWN", hide))
+	Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,101 @@
+package tableformatter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//ColumnOverflow is what happens to the fields left out by RenderOptions.MaxColumns
+type ColumnOverflow int
+
+const (
+	//ColumnOverflowDrop leaves the fields out and writes a notice with their number under the table
+	ColumnOverflowDrop ColumnOverflow = iota
+	//ColumnOverflowFold shows the fields in a last OTHER column as name=value pairs. A MaxColumns under 2 is taken as 2
+	//so that the field with the highest priority is shown besides the OTHER column.
+	ColumnOverflowFold
+)
+
+//overflowFieldName is the name of the column holding the fields folded by ColumnOverflowFold
+const overflowFieldName = "OTHER"
+
+//getOmittedColumnsNotice returns the line written under the table for the fields left out by ColumnOverflowDrop
+func getOmittedColumnsNotice(n int) string {
+	return fmt.Sprintf("… +%d more columns, use the json format to see all of them\n", n)
+}
+
+//limitColumns returns the data and the schema with at most RenderOptions.MaxColumns columns and the number of fields left out.
+//The fields with the highest FieldPriority are kept, in the order of the schema. With ColumnOverflowFold the last column
+//holds the other fields, MaxColumns being at least 2 so that one field is kept besides it. Raw rows are kept as is.
+//data and schema are returned as is if they have few enough fields.
+func limitColumns(data [][]interface{}, schema []SchemaField, options *RenderOptions) ([][]interface{}, []SchemaField, int) {
+	maxColumns := options.MaxColumns
+	if options.ColumnOverflow == ColumnOverflowFold && maxColumns < 2 {
+		maxColumns = 2
+	}
+	if len(schema) <= maxColumns {
+		return data, schema, 0
+	}
+
+	kept := maxColumns
+	if options.ColumnOverflow == ColumnOverflowFold {
+		//one of the columns is the OTHER column
+		kept--
+	}
+
+	byPriority := make([]int, len(schema))
+	for i := range byPriority {
+		byPriority[i] = i
+	}
+	sort.SliceStable(byPriority, func(a, b int) bool {
+		return schema[byPriority[a]].FieldPriority > schema[byPriority[b]].FieldPriority
+	})
+
+	keptFields := byPriority[:kept]
+	sort.Ints(keptFields)
+	isKept := make([]bool, len(schema))
+	for _, i := range keptFields {
+		isKept[i] = true
+	}
+
+	newSchema := make([]SchemaField, 0, maxColumns)
+	for _, i := range keptFields {
+		newSchema = append(newSchema, schema[i])
+	}
+	if options.ColumnOverflow == ColumnOverflowFold {
+		newSchema = append(newSchema, SchemaField{FieldName: overflowFieldName, FieldType: TypeString})
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, 0, len(newSchema))
+		for _, i := range keptFields {
+			newRow = append(newRow, row[i])
+		}
+		if options.ColumnOverflow == ColumnOverflowFold {
+			newRow = append(newRow, getOverflowCell(row, schema, isKept))
+		}
+		newData[k] = newRow
+	}
+
+	return newData, newSchema, len(schema) - kept
+}
+
+//getOverflowCell returns the fields of a row that are not kept as space separated name=value pairs, without the nil cells
+func getOverflowCell(row []interface{}, schema []SchemaField, isKept []bool) string {
+	var pairs []string
+	for i := range schema {
+		if isKept[i] || row[i] == nil {
+			continue
+		}
+		value := strings.Replace(getColumnCellString(row[i], &schema[i]), "\n", " ", -1)
+		pairs = append(pairs, fmt.Sprintf("%s=%s", schema[i].FieldName, value))
+	}
+	return strings.Join(pairs, " ")
+}
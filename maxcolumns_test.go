@@ -0,0 +1,209 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMaxColumnsDrop(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:     "ID",
+			FieldType:     TypeInt,
+			FieldPriority: 2,
+		},
+		{
+			FieldName: "VENDOR",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "NAME",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+		{
+			FieldName: "PORTS",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:     "STATUS",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "dell", "sw-1", 48, "active"},
+		{2, nil, "sw-2", 24, "down"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	s, err := table.Render(WithTableName("switches"), WithMaxColumns(3, ColumnOverflowDrop))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		`+----+------+--------+
+| ID | NAME | STATUS |
++----+------+--------+
+| 1  | sw-1 | active |
+| 2  | sw-2 | down   |
++----+------+--------+
+… +2 more columns, use the json format to see all of them
+Total: 2 switches
+
+`))
+
+	//the sizes of the fields of the table are left as they are
+	Expect(table.Schema[1].FieldSize).To(Equal(0))
+
+	//tables with few enough fields are rendered as usual
+	expected, err := table.Render()
+	Expect(err).To(BeNil())
+	s, err = table.Render(WithMaxColumns(5, ColumnOverflowDrop))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+}
+
+func TestMaxColumnsFold(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:     "ID",
+			FieldType:     TypeInt,
+			FieldPriority: 2,
+		},
+		{
+			FieldName: "VENDOR",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "NAME",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+		{
+			FieldName: "PORTS",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:     "STATUS",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "dell", "sw-1", 48, "active"},
+		{2, nil, "sw-2", 24, "down"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = append(table.Data, NewRawRow("--- raw ---"))
+	s, err := table.Render(WithFormat("aligned"), WithMaxColumns(3, ColumnOverflowFold))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID  NAME  OTHER\n"))
+	Expect(s).To(ContainSubstring("VENDOR=dell PORTS=48 STATUS=active"))
+	//nil cells are left out
+	Expect(s).To(ContainSubstring("PORTS=24 STATUS=down"))
+	Expect(s).NotTo(ContainSubstring("more columns"))
+}
+
+func TestMaxColumnsFoldKeepsOneField(t *testing.T) {
+	RegisterTestingT(t)
+
+	//the OTHER column and the field with the highest priority are shown even with fewer columns
+	for _, maxColumns := range []int{1, 2} {
+		schema := []SchemaField{
+			{
+				FieldName:     "ID",
+				FieldType:     TypeInt,
+				FieldPriority: 2,
+			},
+			{
+				FieldName: "VENDOR",
+				FieldType: TypeString,
+			},
+			{
+				FieldName:     "NAME",
+				FieldType:     TypeString,
+				FieldPriority: 1,
+			},
+			{
+				FieldName: "PORTS",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName:     "STATUS",
+				FieldType:     TypeString,
+				FieldPriority: 1,
+			},
+		}
+
+		data := [][]interface{}{
+			{1, "dell", "sw-1", 48, "active"},
+			{2, nil, "sw-2", 24, "down"},
+		}
+
+		table := Table{Data: data, Schema: schema}
+		s, err := table.Render(WithFormat("aligned"), WithMaxColumns(maxColumns, ColumnOverflowFold))
+		Expect(err).To(BeNil())
+		Expect(s).To(HavePrefix("ID  OTHER\n"), "%d", maxColumns)
+		Expect(s).To(ContainSubstring("1   VENDOR=dell NAME=sw-1 PORTS=48 STATUS=active"), "%d", maxColumns)
+	}
+
+	//a table with two fields is not folded
+	table := Table{
+		Data:   [][]interface{}{{1, "sw-1"}},
+		Schema: []SchemaField{{FieldName: "ID", FieldType: TypeInt}, {FieldName: "NAME", FieldType: TypeString}},
+	}
+	s, err := table.Render(WithFormat("aligned"), WithMaxColumns(1, ColumnOverflowFold))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID  NAME\n"))
+}
+
+func TestMaxColumnsMachineFormats(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:     "ID",
+			FieldType:     TypeInt,
+			FieldPriority: 2,
+		},
+		{
+			FieldName: "VENDOR",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "NAME",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+		{
+			FieldName: "PORTS",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:     "STATUS",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "dell", "sw-1", 48, "active"},
+		{2, nil, "sw-2", 24, "down"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	for _, format := range []string{"json", "csv", "yaml", "md", "html"} {
+		expected, err := table.Render(WithFormat(format))
+		Expect(err).To(BeNil())
+		s, err := table.Render(WithFormat(format), WithMaxColumns(2, ColumnOverflowFold))
+		Expect(err).To(BeNil())
+		Expect(s).To(Equal(expected), format)
+	}
+}
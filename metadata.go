@@ -0,0 +1,79 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+//metadataFormats are the formats to which RenderOptions.Metadata adds the table name and the row counts
+var metadataFormats = map[string]bool{
+	"json":         true,
+	"json-ordered": true,
+	"yaml":         true,
+	"csv":          true,
+}
+
+//withMetadata returns a render function that adds the table name, the number of rows of the table and the number of rows rendered
+//to the output of render in one of the metadataFormats, together with the error messages of the table if any:
+//json renders {"table": ..., "total": ..., "shown": ..., "rows": [...], "errors": [...]}, yaml the same mapping
+//and csv appends the error comment lines followed by a "# total: " comment line.
+func withMetadata(render func(rows [][]interface{}) (string, error), format string, tableName string, total int, messages []string) func(rows [][]interface{}) (string, error) {
+	if format == "csv" {
+		if len(messages) > 0 {
+			render = withErrorRows(render, format, messages)
+		}
+		return func(rows [][]interface{}) (string, error) {
+			s, err := render(rows)
+			if err != nil {
+				return "", err
+			}
+			return s + fmt.Sprintf("# total: %d\n", total), nil
+		}
+	}
+
+	return func(rows [][]interface{}) (string, error) {
+		s, err := render(rows)
+		if err != nil {
+			return "", err
+		}
+		shown := len(withoutRawRows(rows))
+
+		if format == "yaml" {
+			var data interface{}
+			if err := yaml.Unmarshal([]byte(s), &data); err != nil {
+				return "", err
+			}
+			doc := yaml.MapSlice{
+				{Key: "table", Value: tableName},
+				{Key: "total", Value: total},
+				{Key: "shown", Value: shown},
+				{Key: "rows", Value: data},
+			}
+			if len(messages) > 0 {
+				doc = append(doc, yaml.MapItem{Key: "errors", Value: messages})
+			}
+
+			ret, err := yaml.Marshal(doc)
+			if err != nil {
+				return "", err
+			}
+			return string(ret), nil
+		}
+
+		doc := struct {
+			Table  string          `json:"table"`
+			Total  int             `json:"total"`
+			Shown  int             `json:"shown"`
+			Rows   json.RawMessage `json:"rows"`
+			Errors []string        `json:"errors,omitempty"`
+		}{tableName, total, shown, json.RawMessage(s), messages}
+
+		ret, err := json.MarshalIndent(doc, "", "\t")
+		if err != nil {
+			return "", err
+		}
+		return string(ret), nil
+	}
+}
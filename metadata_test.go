@@ -0,0 +1,157 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRenderWithMetadata(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.Render(WithFormat("json"), WithTableName("servers"), WithMetadata())
+	Expect(err).To(BeNil())
+	var doc struct {
+		Table string                   `json:"table"`
+		Total int                      `json:"total"`
+		Shown int                      `json:"shown"`
+		Rows  []map[string]interface{} `json:"rows"`
+	}
+	Expect(json.Unmarshal([]byte(s), &doc)).To(Succeed())
+	Expect(doc.Table).To(Equal("servers"))
+	Expect(doc.Total).To(Equal(3))
+	Expect(doc.Shown).To(Equal(3))
+	Expect(doc.Rows).To(HaveLen(3))
+	Expect(s).To(HavePrefix("{\n\t\"table\": \"servers\",\n\t\"total\": 3,\n\t\"shown\": 3,\n\t\"rows\": [\n\t\t{\n"))
+
+	s, err = table.Render(WithFormat("yaml"), WithTableName("servers"), WithMetadata())
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("table: servers\ntotal: 3\nshown: 3\nrows:\n- "))
+
+	s, err = table.Render(WithFormat("csv"), WithMetadata())
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("db-1,3.000000,2020-11-05\n# total: 3\n"))
+
+	//the other formats are rendered as usual
+	expected, err := table.Render()
+	Expect(err).To(BeNil())
+	s, err = table.Render(WithMetadata())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	//the default output is a bare array
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("["))
+}
+
+func TestRenderWithMetadataTruncated(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	full, err := table.Render(WithFormat("json"), WithMetadata())
+	Expect(err).To(BeNil())
+
+	s, err := table.Render(WithFormat("json"), WithMetadata(), WithMaxOutputBytes(len(full)-1))
+	Expect(err).To(Equal(&ErrOutputTruncated{RenderedRows: 2, TotalRows: 3}))
+	Expect(len(s)).To(BeNumerically("<", len(full)))
+	Expect(s).To(ContainSubstring("\"total\": 3,\n\t\"shown\": 2,"))
+}
+
+func TestRenderWithMetadataAndErrorRows(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.AddErrorRow("page 2 failed")
+
+	s, err := table.Render(WithFormat("yaml"), WithMetadata())
+	Expect(err).To(BeNil())
+	var doc map[string]interface{}
+	Expect(yaml.Unmarshal([]byte(s), &doc)).To(Succeed())
+	Expect(doc["errors"]).To(Equal([]interface{}{"page 2 failed"}))
+	Expect(doc["total"]).To(Equal(3))
+
+	s, err = table.Render(WithFormat("json-ordered"), WithMetadata())
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("\t],\n\t\"errors\": [\n\t\t\"page 2 failed\"\n\t]\n}"))
+
+	s, err = table.Render(WithFormat("csv"), WithMetadata())
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("# ERROR: page 2 failed\n# total: 3\n"))
+}
@@ -0,0 +1,54 @@
+package tableformatter
+
+import (
+	"io"
+	"sort"
+)
+
+//RenderMulti renders the table once for each format of targets and writes the output to the writer of the format.
+//The computed fields are computed once for all the formats. The targets are rendered in the order of their formats
+//and a failed target does not stop the others: the errors of all the targets are returned joined.
+//A target whose output is truncated by RenderOptions.MaxOutputBytes receives the partial output.
+func (t *Table) RenderMulti(targets map[string]io.Writer, opts ...RenderOption) error {
+	prepared, err := t.getMaterializedTable()
+	if err != nil {
+		return err
+	}
+
+	formats := make([]string, 0, len(targets))
+	for format := range targets {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	var errs []error
+	for _, format := range formats {
+		s, err := prepared.Render(append(opts, WithFormat(format))...)
+		if err != nil {
+			errs = append(errs, wrapError(err, "could not render format %s", format))
+			if s == "" {
+				continue
+			}
+		}
+		if _, err := io.WriteString(targets[format], s); err != nil {
+			errs = append(errs, wrapError(err, "could not write format %s", format))
+		}
+	}
+
+	return joinErrors(errs...)
+}
+
+//getMaterializedTable returns a copy of the table with the cells of the computed fields stored in its data
+//and the fields no longer computed
+func (t *Table) getMaterializedTable() (*Table, error) {
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return nil, err
+	}
+	data, err := computeColumns(t.getData(getFieldNames(t.Schema)...), t.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{Data: data, Schema: getMaterializedSchema(t.Schema), TimeFormat: t.TimeFormat, errorRows: t.errorRows}, nil
+}
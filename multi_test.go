@@ -0,0 +1,92 @@
+package tableformatter
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRenderMulti(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+	table := Table{Data: [][]interface{}{{1, 22, 22}, {2, 8000, 8080}}, Schema: schema}
+
+	var text, json strings.Builder
+	err := table.RenderMulti(map[string]io.Writer{"": &text, "json": &json}, WithTableName("rules"))
+	Expect(err).To(BeNil())
+
+	expectedText, err := table.RenderTable("rules", "", "")
+	Expect(err).To(BeNil())
+	Expect(text.String()).To(Equal(expectedText))
+
+	expectedJSON, err := table.RenderTable("rules", "", "json")
+	Expect(err).To(BeNil())
+	Expect(json.String()).To(Equal(expectedJSON))
+}
+
+func TestRenderMultiCollectsErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "START",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "END",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "PORT",
+			FieldType:        TypeString,
+			FieldComputeFrom: []string{"START", "END"},
+			FieldCompute:     portRange,
+		},
+	}
+	table := Table{Data: [][]interface{}{{1, 22, 22}, {2, 8000, 8080}}, Schema: schema}
+
+	var csv strings.Builder
+	err := table.RenderMulti(map[string]io.Writer{"csv": &csv, "": &strings.Builder{}}, WithValueMask("UNKNOWN", nil))
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("could not render format csv"))
+	Expect(err.Error()).To(ContainSubstring("could not render format :"))
+
+	err = table.RenderMulti(map[string]io.Writer{"csv": &csv, "json": failingWriter{}})
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("disk full"))
+	Expect(csv.String()).To(HavePrefix("ID,START,END,PORT\n"))
+}
@@ -0,0 +1,41 @@
+package tableformatter
+
+import "strings"
+
+//narrowLayoutWidth is the RenderOptions.WrapWidth below which the text format switches to the narrow layout
+const narrowLayoutWidth = 60
+
+//isNarrowLayout returns true if the text format should render the narrow layout
+func isNarrowLayout(options *RenderOptions) bool {
+	return options.NarrowLayout || (options.WrapWidth > 0 && options.WrapWidth < narrowLayoutWidth)
+}
+
+//getTableAsNarrowString renders each row as a block: the value of the first field as a heading
+//followed by indented "key: value" lines for the other fields. Blocks are separated by empty lines.
+//Raw rows are written as they are.
+func getTableAsNarrowString(data [][]interface{}, schema []SchemaField, options *RenderOptions) string {
+	var sb strings.Builder
+
+	for k, row := range data {
+		if k > 0 {
+			sb.WriteString("\n")
+		}
+		if isRawRow(row) {
+			sb.WriteString(string(row[0].(RawRow)))
+			sb.WriteString("\n")
+			continue
+		}
+		if len(schema) == 0 {
+			continue
+		}
+
+		heading := getHumanReadableValue(row[0], &schema[0])
+		for _, line := range WrapToWidth(heading, options.WrapWidth) {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		writeKeyValueLines(&sb, row[1:], schema[1:], options, "  ")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableNarrowLayout(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{"test-infrastructure", 10, "alex@alex.com"},
+		{"production", 20, nil},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	expected := "Servers:\n" +
+		"test-infrastructure\n" +
+		"  ID: 10\n" +
+		"  OWNER: alex@alex.com\n" +
+		"\n" +
+		"production\n" +
+		"  ID: 20\n" +
+		"  OWNER: \n" +
+		"Total: 2 servers\n\n"
+
+	s, err := table.RenderTable("servers", "Servers:", "", WithNarrowLayout())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	//the narrow layout is used automatically on narrow terminals
+	s, err = table.RenderTable("servers", "Servers:", "", WithWrapWidth(40))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	s, err = table.RenderTable("servers", "", "", WithWrapWidth(16), WithAlignedKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"test-infrastruct\n" +
+			"ure\n" +
+			"  ID   : 10\n" +
+			"  OWNER: alex@al\n" +
+			"         ex.com\n" +
+			"\n" +
+			"production\n" +
+			"  ID   : 20\n" +
+			"  OWNER: \n" +
+			"Total: 2 servers\n\n"))
+}
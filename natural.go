@@ -0,0 +1,78 @@
+package tableformatter
+
+import (
+	"strings"
+)
+
+//naturalLess returns a less function that compares strings in natural order, see compareNatural,
+//and the strings that are equal in natural order, such as node2 and node002, with less
+func naturalLess(less lessFunc) lessFunc {
+	return func(a, b interface{}, field *SchemaField) bool {
+		sa, okA := a.(string)
+		sb, okB := b.(string)
+		if okA && okB {
+			if c := compareNatural(sa, sb); c != 0 {
+				return c < 0
+			}
+		}
+		return less(a, b, field)
+	}
+}
+
+//compareNatural compares a and b split into runs of digits and runs of other characters. Runs of digits compare
+//as numbers, so node2 is before node10 and node002 is equal to node2, and the other runs compare ignoring case.
+//It returns -1 if a is before b, 1 if it is after and 0 if they are equal.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		runA, restA := splitNaturalRun(a)
+		runB, restB := splitNaturalRun(b)
+
+		var c int
+		if isDigit(runA[0]) && isDigit(runB[0]) {
+			c = compareDigits(runA, runB)
+		} else {
+			c = strings.Compare(strings.ToLower(runA), strings.ToLower(runB))
+		}
+		if c != 0 {
+			return c
+		}
+
+		a, b = restA, restB
+	}
+
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+//splitNaturalRun returns the run of digits or of other characters at the start of s and the rest of s
+func splitNaturalRun(s string) (string, string) {
+	digits := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+//compareDigits compares two runs of digits as numbers of any size
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+//isDigit returns true if c is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
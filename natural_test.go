@@ -0,0 +1,74 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCompareNatural(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(compareNatural("node2", "node10")).To(Equal(-1))
+	Expect(compareNatural("node10", "node2")).To(Equal(1))
+	Expect(compareNatural("node002", "node2")).To(Equal(0))
+	Expect(compareNatural("Node1", "node1")).To(Equal(0))
+	Expect(compareNatural("node", "node1")).To(Equal(-1))
+	Expect(compareNatural("10", "9a")).To(Equal(1))
+	Expect(compareNatural("99999999999999999999999", "100000000000000000000000")).To(Equal(-1))
+}
+
+func TestFieldSortNatural(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:        "HOSTNAME",
+			FieldType:        TypeString,
+			FieldSortNatural: true,
+		},
+	}
+
+	hostnames := []string{
+		"node10.dc2.example.com",
+		"node2.dc10.example.com",
+		"Node1.dc2.example.com",
+		"node002.dc2.example.com",
+		"node2.dc2.example.com",
+		"db-1",
+		"node1.dc10.example.com",
+		"DB-12",
+		"db-3",
+		"node",
+	}
+
+	data := [][]interface{}{}
+	for _, hostname := range hostnames {
+		data = append(data, []interface{}{hostname})
+	}
+
+	Expect(TableSorter(schema).OrderBy("HOSTNAME").SortErr(data)).To(Succeed())
+
+	sorted := []string{}
+	for _, row := range data {
+		sorted = append(sorted, row[0].(string))
+	}
+	Expect(sorted).To(Equal([]string{
+		"db-1",
+		"db-3",
+		"DB-12",
+		"node",
+		"Node1.dc2.example.com",
+		"node1.dc10.example.com",
+		//equal in natural order, the tie is broken by the plain comparison
+		"node002.dc2.example.com",
+		"node2.dc2.example.com",
+		"node2.dc10.example.com",
+		"node10.dc2.example.com",
+	}))
+
+	//without the flag the strings compare byte by byte
+	schema[0].FieldSortNatural = false
+	Expect(TableSorter(schema).OrderBy("HOSTNAME").SortErr(data)).To(Succeed())
+	Expect(data[0][0]).To(Equal("DB-12"))
+}
@@ -0,0 +1,102 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableNilAsNull(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "COUNT",
+			FieldType: TypeInt,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, nil, "", 0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("items", "", "json-ordered")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("[\n\t{\n\t\t\"ID\": 1,\n\t\t\"OWNER\": null,\n\t\t\"LABEL\": \"\",\n\t\t\"COUNT\": 0\n\t}\n]"))
+
+	s, err = table.RenderTable("items", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("[\n\t{\n\t\t\"COUNT\": 0,\n\t\t\"ID\": 1,\n\t\t\"LABEL\": \"\",\n\t\t\"OWNER\": null\n\t}\n]"))
+
+	s, err = table.RenderTable("items", "", "yaml")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("- count: 0\n  id: 1\n  label: \"\"\n  owner: null\n"))
+
+	s, err = table.RenderTable("items", "", "yaml-docs")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("count: 0\nid: 1\nlabel: \"\"\nowner: null\n"))
+}
+
+func TestRenderTableWithOmittedNilKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "COUNT",
+			FieldType: TypeInt,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, nil, "", 0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTable("items", "", "json-ordered", WithOmittedNilKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("[\n\t{\n\t\t\"ID\": 1,\n\t\t\"LABEL\": \"\",\n\t\t\"COUNT\": 0\n\t}\n]"))
+
+	s, err = table.RenderTable("items", "", "json", WithOmittedNilKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("[\n\t{\n\t\t\"COUNT\": 0,\n\t\t\"ID\": 1,\n\t\t\"LABEL\": \"\"\n\t}\n]"))
+
+	s, err = table.RenderTable("items", "", "yaml", WithOmittedNilKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("- count: 0\n  id: 1\n  label: \"\"\n"))
+
+	s, err = table.RenderTable("items", "", "yaml-docs", WithOmittedNilKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("count: 0\nid: 1\nlabel: \"\"\n"))
+
+	//the nil cells are still rendered by the text format
+	s, err = table.RenderTable("items", "", "", WithOmittedNilKeys())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| OWNER |"))
+}
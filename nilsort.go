@@ -0,0 +1,42 @@
+package tableformatter
+
+//isNilCell returns true for the nil cells and the nil *time.Time cells
+func isNilCell(d interface{}) bool {
+	return d == nil || isNilTime(d)
+}
+
+//nilLess returns a less function that orders the nil cells after all the other cells, the cells with a severity
+//and a nil value and the cells that cannot be parsed included, and compares the other cells with less
+func nilLess(less lessFunc) lessFunc {
+	return func(a, b interface{}, field *SchemaField) bool {
+		if isNilCell(a) || isNilCell(b) {
+			return !isNilCell(a) && isNilCell(b)
+		}
+		return less(a, b, field)
+	}
+}
+
+//nilsFirstLess returns a less function that orders the nil cells before all the other cells, whatever the direction
+//of less, and compares the other cells with less
+func nilsFirstLess(less lessFunc) lessFunc {
+	return func(a, b interface{}, field *SchemaField) bool {
+		a, b = getSeverityValue(a), getSeverityValue(b)
+		if isNilCell(a) || isNilCell(b) {
+			return isNilCell(a) && !isNilCell(b)
+		}
+		return less(a, b, field)
+	}
+}
+
+//NilsFirst makes Sort place the nil cells before the other cells of each field, instead of after them.
+//The nil cells stay first when the field sorts descending. It can be called before or after OrderBy.
+func (ms *MultiSorter) NilsFirst() *MultiSorter {
+	if ms.nilsFirst {
+		return ms
+	}
+	ms.nilsFirst = true
+	for k := range ms.less {
+		ms.less[k] = nilsFirstLess(ms.less[k])
+	}
+	return ms
+}
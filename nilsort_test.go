@@ -0,0 +1,129 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func getIDs(data [][]interface{}) []int {
+	ids := []int{}
+	for _, row := range data {
+		ids = append(ids, row[0].(int))
+	}
+	return ids
+}
+
+func TestSortNilsLast(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, order := range []struct {
+		field    string
+		expected []int
+	}{
+		{"CORES", []int{4, 5, 2, 1, 3}},
+		{"-CORES", []int{2, 5, 4, 1, 3}},
+		{"OWNER", []int{3, 5, 1, 2, 4}},
+		{"OWNER:desc", []int{1, 5, 3, 2, 4}},
+		{"LOAD", []int{3, 2, 5, 1, 4}},
+		{"CREATED", []int{4, 2, 1, 3, 5}},
+		{"-CREATED", []int{2, 4, 1, 3, 5}},
+		{"ACTIVE", []int{5, 2, 1, 3, 4}},
+	} {
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "CORES",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "OWNER",
+				FieldType: TypeString,
+			},
+			{
+				FieldName: "LOAD",
+				FieldType: TypeFloat,
+			},
+			{
+				FieldName: "CREATED",
+				FieldType: TypeDateTime,
+			},
+			{
+				FieldName: "ACTIVE",
+				FieldType: TypeBool,
+			},
+		}
+		data := [][]interface{}{
+			{1, nil, "carol", nil, nil, nil},
+			{2, 8, nil, 0.5, time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC), true},
+			{3, nil, "alice", 0.25, nil, nil},
+			{4, 2, nil, nil, time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), nil},
+			{5, 4, "bob", 0.75, nil, false},
+		}
+		table := Table{Data: data, Schema: schema}
+		Expect(TableSorter(table.Schema).OrderBy(order.field, "ID").SortErr(table.Data)).To(BeNil(), order.field)
+		Expect(getIDs(table.Data)).To(Equal(order.expected), order.field)
+	}
+}
+
+func TestSortNilsFirst(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "CORES",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "LOAD",
+			FieldType: TypeFloat,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+	}
+	data := [][]interface{}{
+		{1, nil, "carol", nil, nil, nil},
+		{2, 8, nil, 0.5, time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC), true},
+		{3, nil, "alice", 0.25, nil, nil},
+		{4, 2, nil, nil, time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), nil},
+		{5, 4, "bob", 0.75, nil, false},
+	}
+	table := Table{Data: data, Schema: schema}
+
+	for _, order := range []struct {
+		field    string
+		expected []int
+	}{
+		{"CORES", []int{1, 3, 4, 5, 2}},
+		{"-CORES", []int{1, 3, 2, 5, 4}},
+		{"OWNER", []int{2, 4, 3, 5, 1}},
+		{"LOAD", []int{1, 4, 3, 2, 5}},
+		{"CREATED", []int{1, 3, 5, 4, 2}},
+		{"ACTIVE", []int{1, 3, 4, 5, 2}},
+	} {
+		Expect(TableSorter(table.Schema).NilsFirst().OrderBy(order.field, "ID").SortErr(table.Data)).To(BeNil(), order.field)
+		Expect(getIDs(table.Data)).To(Equal(order.expected), order.field)
+
+		//NilsFirst can also follow OrderBy
+		Expect(TableSorter(table.Schema).OrderBy(order.field, "ID").NilsFirst().SortErr(table.Data)).To(BeNil(), order.field)
+		Expect(getIDs(table.Data)).To(Equal(order.expected), order.field)
+	}
+}
@@ -0,0 +1,43 @@
+package tableformatter
+
+//applyWithoutColors returns a copy of data with the ANSI escape sequences removed from the string cells, the AsString of the Cell values and raw rows
+//if RenderOptions.NoColor is set. data is returned as is otherwise.
+func applyWithoutColors(data [][]interface{}, options *RenderOptions) [][]interface{} {
+	if !options.NoColor {
+		return data
+	}
+	return withoutColors(data)
+}
+
+//withoutColors returns a copy of data with the ANSI escape sequences removed from the string cells, the AsString of the Cell values and raw rows
+func withoutColors(data [][]interface{}) [][]interface{} {
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		newRow := make([]interface{}, len(row))
+		for i, d := range row {
+			switch v := d.(type) {
+			case string:
+				newRow[i] = decolorize(v)
+			case Cell:
+				newRow[i] = Cell{Value: v.Value, AsString: decolorize(v.AsString)}
+			case RawRow:
+				newRow[i] = RawRow(decolorize(string(v)))
+			default:
+				newRow[i] = d
+			}
+		}
+		newData[k] = newRow
+	}
+
+	return newData
+}
+
+//getSchemaWithoutColors returns a copy of the schema with the ANSI escape sequences removed from the field names
+func getSchemaWithoutColors(schema []SchemaField) []SchemaField {
+	newSchema := make([]SchemaField, len(schema))
+	copy(newSchema, schema)
+	for i := range newSchema {
+		newSchema[i].FieldName = decolorize(newSchema[i].FieldName)
+	}
+	return newSchema
+}
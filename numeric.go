@@ -0,0 +1,138 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+//number is a numeric cell of any type, held as an int64 if it is an integer so that large integers compare exactly
+type number struct {
+	i     int64
+	f     float64
+	isInt bool
+}
+
+//getNumber returns the number held by a cell of any integer or float type or by a json.Number,
+//such as the float64 cells of an int field decoded by json.Unmarshal
+func getNumber(d interface{}) (number, bool) {
+	switch v := d.(type) {
+	case int:
+		return intNumber(int64(v)), true
+	case int8:
+		return intNumber(int64(v)), true
+	case int16:
+		return intNumber(int64(v)), true
+	case int32:
+		return intNumber(int64(v)), true
+	case int64:
+		return intNumber(v), true
+	case uint:
+		return uintNumber(uint64(v)), true
+	case uint8:
+		return uintNumber(uint64(v)), true
+	case uint16:
+		return uintNumber(uint64(v)), true
+	case uint32:
+		return uintNumber(uint64(v)), true
+	case uint64:
+		return uintNumber(v), true
+	case float32:
+		return number{f: float64(v)}, true
+	case float64:
+		return number{f: v}, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return intNumber(i), true
+		}
+		if f, err := v.Float64(); err == nil {
+			return number{f: f}, true
+		}
+	}
+	return number{}, false
+}
+
+//intNumber returns the number of an integer
+func intNumber(i int64) number {
+	return number{i: i, f: float64(i), isInt: true}
+}
+
+//uintNumber returns the number of an unsigned integer, as a float if it does not fit in an int64
+func uintNumber(u uint64) number {
+	if u > math.MaxInt64 {
+		return number{f: float64(u)}
+	}
+	return intNumber(int64(u))
+}
+
+//compareNumbers returns -1 if a is less than b, 1 if it is greater and 0 if they are equal. NaN is greater than
+//the other numbers.
+func compareNumbers(a, b number) int {
+	if a.isInt && b.isInt {
+		switch {
+		case a.i < b.i:
+			return -1
+		case a.i > b.i:
+			return 1
+		}
+		return 0
+	}
+
+	switch nanA, nanB := math.IsNaN(a.f), math.IsNaN(b.f); {
+	case nanA && nanB:
+		return 0
+	case nanA:
+		return 1
+	case nanB:
+		return -1
+	case a.f < b.f:
+		return -1
+	case a.f > b.f:
+		return 1
+	}
+	return 0
+}
+
+//numberLess orders the cells of the int, float and bytes fields by their number whatever their type, see getNumber.
+//The cells that are not numbers, which SortErr reports as errors, go last.
+func numberLess(a, b interface{}) bool {
+	na, okA := getNumber(a)
+	nb, okB := getNumber(b)
+	switch {
+	case okA && okB:
+		return compareNumbers(na, nb) < 0
+	case okA != okB:
+		return okA
+	}
+	return false
+}
+
+//isNumberField returns true for the fields whose cells are compared as numbers by their type
+func isNumberField(field *SchemaField) bool {
+	switch field.FieldType {
+	case TypeInt, TypeFloat, TypeBytes:
+		return !field.FieldNormalizeUnits
+	}
+	return false
+}
+
+//checkNumberCells returns an error for the first cell of the int, float and bytes fields sorted by their type that is not a number.
+//The nil cells and the Cell values, which sort last, are not checked.
+func (ms *MultiSorter) checkNumberCells() error {
+	for k, index := range ms.indexes {
+		field := &ms.fields[k]
+		if ms.byFunc[k] || !isNumberField(field) {
+			continue
+		}
+		for r, row := range ms.data {
+			d := getSeverityValue(row[index])
+			if _, ok := d.(Cell); d == nil || ok {
+				continue
+			}
+			if _, ok := getNumber(d); !ok {
+				return fmt.Errorf("row %d: cannot sort %v (%T) as a number in field %s", r, d, d, field.FieldName)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSortMixedNumericCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, json.Number("6")},
+		{2, 4},
+		{3, 5.0},
+		{4, nil},
+		{5, int64(-1)},
+		{6, json.Number("4.5")},
+	}
+
+	for _, fieldType := range []int{TypeInt, TypeFloat} {
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "VALUE",
+				FieldType: fieldType,
+			},
+		}
+		Expect(TableSorter(schema).OrderBy("VALUE").SortErr(data)).To(Succeed(), fieldTypeNames[fieldType])
+		Expect(getIDs(data)).To(Equal([]int{5, 2, 6, 3, 1, 4}), fieldTypeNames[fieldType])
+
+		Expect(TableSorter(schema).OrderBy("-VALUE").SortErr(data)).To(Succeed(), fieldTypeNames[fieldType])
+		Expect(getIDs(data)).To(Equal([]int{1, 3, 6, 2, 5, 4}), fieldTypeNames[fieldType])
+	}
+
+	//the columns without a schema are compared as numbers too
+	Expect(TableSorter(nil).OrderByIndex(1).SortErr(data)).To(Succeed())
+	Expect(getIDs(data)).To(Equal([]int{5, 2, 6, 3, 1, 4}))
+}
+
+func TestSortNonNumericCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "VALUE",
+			FieldType: TypeFloat,
+		},
+	}
+	data := [][]interface{}{
+		{1, json.Number("6")},
+		{2, 4},
+		{3, 5.0},
+		{4, nil},
+		{5, int64(-1)},
+		{6, json.Number("4.5")},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Data[2][1] = "five"
+	err := TableSorter(table.Schema).OrderBy("VALUE").SortErr(table.Data)
+	Expect(err).To(MatchError("row 2: cannot sort five (string) as a number in field VALUE"))
+	Expect(getIDs(table.Data)).To(Equal([]int{1, 2, 3, 4, 5, 6}))
+
+	table.Data[2][1] = json.Number("not a number")
+	Expect(TableSorter(table.Schema).OrderBy("VALUE").SortErr(table.Data)).NotTo(Succeed())
+
+	//the cells compared by OrderByFunc are not checked
+	Expect(TableSorter(table.Schema).OrderByFunc("VALUE", func(a, b interface{}) bool {
+		return false
+	}).SortErr(table.Data)).To(Succeed())
+}
+
+func TestCompareNumbers(t *testing.T) {
+	RegisterTestingT(t)
+
+	//each number is less than the next one
+	numbers := []interface{}{
+		math.Inf(-1),
+		int64(math.MinInt64),
+		-1.5,
+		json.Number("-1"),
+		uint8(0),
+		float32(0.5),
+		json.Number("1e3"),
+		int64(math.MaxInt64 - 1),
+		int64(math.MaxInt64),
+		uint64(math.MaxUint64),
+		math.Inf(1),
+		math.NaN(),
+	}
+	for i := 1; i < len(numbers); i++ {
+		a, ok := getNumber(numbers[i-1])
+		Expect(ok).To(BeTrue())
+		b, ok := getNumber(numbers[i])
+		Expect(ok).To(BeTrue())
+		Expect(compareNumbers(a, b)).To(Equal(-1), "%v < %v", numbers[i-1], numbers[i])
+		Expect(compareNumbers(b, a)).To(Equal(1), "%v > %v", numbers[i], numbers[i-1])
+	}
+
+	a, _ := getNumber(5)
+	b, _ := getNumber(json.Number("5.0"))
+	Expect(compareNumbers(a, b)).To(Equal(0))
+}
@@ -0,0 +1,199 @@
+package tableformatter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+//ObjectToTableOptions holds the optional settings of ObjectToTableWithOptions
+type ObjectToTableOptions struct {
+	//FieldNameFormatter formats the names of the struct fields into column names. NewHumanReadableFormatter is used if nil.
+	FieldNameFormatter FieldNameFormatter
+	//ExpandMapFields are the names of map fields of the struct rendered as one column per key instead of a single yaml column.
+	//The columns are named PREFIX:key, see MapColumnPrefixes, and hold the union of the keys of all the objects,
+	//sorted by key. Cells of keys missing from an object are nil.
+	ExpandMapFields []string
+	//MapColumnPrefixes are the prefixes of the column names of the ExpandMapFields by struct field name,
+	//such as TAG for a Tags field expanded into columns named TAG:env and TAG:team.
+	//The fields without a prefix use their formatted field name, such as tags:env with NewHumanReadableFormatter.
+	MapColumnPrefixes map[string]string
+	//LazyCells makes the table hold the objects as its RowSources, the cells being extracted by the FieldExtract
+	//of the fields only for the fields that are rendered, instead of filling Data. A cell that cannot be extracted
+	//is rendered like the cell of a FieldExtract that panics.
+	LazyCells bool
+}
+
+//ObjectToTableWithOptions converts a struct, or a slice of structs with one row per element, into a table
+func ObjectToTableWithOptions(obj interface{}, options ObjectToTableOptions) (*Table, error) {
+	formatter := options.FieldNameFormatter
+	if formatter == nil {
+		formatter = NewHumanReadableFormatter()
+	}
+
+	var objects []reflect.Value
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Struct:
+		objects = append(objects, v)
+	case reflect.Slice, reflect.Array:
+		for k := 0; k < v.Len(); k++ {
+			objects = append(objects, v.Index(k))
+		}
+	default:
+		return nil, fmt.Errorf("only structs and slices of structs are supported, this is %v", v.Kind())
+	}
+
+	t := v.Type()
+	if t.Kind() != reflect.Struct {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("only slices of structs are supported, this is a slice of %v", t.Kind())
+	}
+
+	//the sorted keys of each expanded map field, by field index
+	expandedKeys := map[int][]string{}
+	for _, name := range options.ExpandMapFields {
+		field, ok := t.FieldByName(name)
+		if !ok || len(field.Index) != 1 {
+			return nil, fmt.Errorf("could not find field with name %s", name)
+		}
+		if field.Type.Kind() != reflect.Map {
+			return nil, fmt.Errorf("field %s is not a map", name)
+		}
+
+		keySet := map[string]bool{}
+		for _, o := range objects {
+			for _, key := range o.Field(field.Index[0]).MapKeys() {
+				keySet[fmt.Sprintf("%v", key.Interface())] = true
+			}
+		}
+		keys := []string{}
+		for key := range keySet {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		expandedKeys[field.Index[0]] = keys
+	}
+
+	var schema []SchemaField
+	//extract returns the cell of each field of the schema for an object
+	var extract []func(o reflect.Value) (interface{}, error)
+	for i := 0; i < t.NumField(); i++ {
+		fieldName := formatter.Format(t.Field(i).Name)
+		index := i
+
+		if keys, ok := expandedKeys[i]; ok {
+			prefix := fieldName
+			if p, ok := options.MapColumnPrefixes[t.Field(i).Name]; ok {
+				prefix = p
+			}
+			for _, key := range keys {
+				schema = append(schema, SchemaField{
+					FieldName: prefix + ":" + key,
+					FieldType: TypeInterface,
+				})
+				mapKey := key
+				extract = append(extract, func(o reflect.Value) (interface{}, error) {
+					iter := o.Field(index).MapRange()
+					for iter.Next() {
+						if fmt.Sprintf("%v", iter.Key().Interface()) == mapKey {
+							return iter.Value().Interface(), nil
+						}
+					}
+					return nil, nil
+				})
+			}
+			continue
+		}
+
+		schema = append(schema, SchemaField{
+			FieldName: fieldName,
+			FieldType: getStructFieldType(t.Field(i).Type),
+		})
+		extract = append(extract, func(o reflect.Value) (interface{}, error) {
+			return getStructFieldCell(o.Field(index))
+		})
+	}
+
+	if options.LazyCells {
+		sources := make([]interface{}, len(objects))
+		for k, o := range objects {
+			sources[k] = o.Interface()
+		}
+		for i := range schema {
+			e := extract[i]
+			schema[i].FieldExtract = func(source interface{}) interface{} {
+				cell, err := e(reflect.ValueOf(source))
+				if err != nil {
+					return Cell{AsString: fmt.Sprintf(extractErrorFormat, err)}
+				}
+				return cell
+			}
+		}
+		return &Table{RowSources: sources, Schema: schema}, nil
+	}
+
+	data := [][]interface{}{}
+	for _, o := range objects {
+		var row []interface{}
+		for _, e := range extract {
+			cell, err := e(o)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, cell)
+		}
+		data = append(data, row)
+	}
+
+	return &Table{Data: data, Schema: schema}, nil
+}
+
+//timeType and timePointerType are the types of the struct fields rendered as date time columns
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	timePointerType = reflect.TypeOf(&time.Time{})
+)
+
+//getStructFieldType returns the type of the column of a struct field. time.Time and *time.Time fields are date times
+//and the other fields that are not numbers or strings are yaml strings.
+func getStructFieldType(t reflect.Type) int {
+	if t == timeType || t == timePointerType {
+		return TypeDateTime
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+		return TypeInt
+	case reflect.Float32, reflect.Float64:
+		return TypeFloat
+	default:
+		return TypeString
+	}
+}
+
+//getStructFieldCell returns the cell of a struct field, in the type returned by getStructFieldType
+func getStructFieldCell(v reflect.Value) (interface{}, error) {
+	if v.Type() == timeType || v.Type() == timePointerType {
+		return v.Interface(), nil
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	default:
+		s, err := yaml.Marshal(v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return strings.TrimSpace(string(s)), nil
+	}
+}
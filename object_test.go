@@ -0,0 +1,140 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type taggedResource struct {
+	ID    int
+	Label string
+	Tags  map[string]string
+}
+
+func TestObjectToTableWithExpandedMapFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	objects := []taggedResource{
+		{1, "server-1", map[string]string{"team": "storage", "env": "prod"}},
+		{2, "server-2", map[string]string{"env": "dev", "owner": "john"}},
+		{3, "server-3", nil},
+	}
+
+	table, err := ObjectToTableWithOptions(objects, ObjectToTableOptions{
+		FieldNameFormatter: NewPassThroughFormatter(),
+		ExpandMapFields:    []string{"Tags"},
+	})
+	Expect(err).To(BeNil())
+
+	names := []string{}
+	for _, field := range table.Schema {
+		names = append(names, field.FieldName)
+	}
+	Expect(names).To(Equal([]string{"ID", "Label", "Tags:env", "Tags:owner", "Tags:team"}))
+
+	Expect(table.Data).To(Equal([][]interface{}{
+		{1, "server-1", "prod", nil, "storage"},
+		{2, "server-2", "dev", "john", nil},
+		{3, "server-3", nil, nil, nil},
+	}))
+
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| Tags:env "))
+	Expect(s).To(ContainSubstring("| john "))
+
+	table, err = ObjectToTableWithOptions(objects, ObjectToTableOptions{
+		ExpandMapFields:   []string{"Tags"},
+		MapColumnPrefixes: map[string]string{"Tags": "TAG"},
+	})
+	Expect(err).To(BeNil())
+	Expect(table.Schema[2].FieldName).To(Equal("TAG:env"))
+	Expect(table.Schema[3].FieldName).To(Equal("TAG:owner"))
+	Expect(table.Schema[4].FieldName).To(Equal("TAG:team"))
+	Expect(table.Data[1]).To(Equal([]interface{}{2, "server-2", "dev", "john", nil}))
+
+	//without a prefix the columns are named after the formatted field name
+	table, err = ObjectToTableWithOptions(objects, ObjectToTableOptions{ExpandMapFields: []string{"Tags"}})
+	Expect(err).To(BeNil())
+	Expect(table.Schema[2].FieldName).To(Equal("tags:env"))
+}
+
+func TestObjectToTableWithOptionsErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	obj := taggedResource{1, "server-1", nil}
+
+	_, err := ObjectToTableWithOptions(obj, ObjectToTableOptions{ExpandMapFields: []string{"Unknown"}})
+	Expect(err).NotTo(BeNil())
+
+	_, err = ObjectToTableWithOptions(obj, ObjectToTableOptions{ExpandMapFields: []string{"Label"}})
+	Expect(err).NotTo(BeNil())
+
+	_, err = ObjectToTableWithOptions(10, ObjectToTableOptions{})
+	Expect(err).NotTo(BeNil())
+
+	_, err = ObjectToTable(10)
+	Expect(err).To(MatchError("Only struct types are supported. This is int"))
+
+	_, err = ObjectToTable(nil)
+	Expect(err).NotTo(BeNil())
+
+	table, err := ObjectToTableWithOptions(obj, ObjectToTableOptions{})
+	Expect(err).To(BeNil())
+	Expect(table.Schema[2].FieldType).To(Equal(TypeString))
+	Expect(table.Data[0][2]).To(Equal("{}"))
+}
+
+func TestObjectToTableWithLazyCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	objects := []taggedResource{
+		{1, "server-1", map[string]string{"team": "storage", "env": "prod"}},
+		{2, "server-2", map[string]string{"env": "dev", "owner": "john"}},
+	}
+	options := ObjectToTableOptions{ExpandMapFields: []string{"Tags"}}
+
+	eager, err := ObjectToTableWithOptions(objects, options)
+	Expect(err).To(BeNil())
+
+	options.LazyCells = true
+	lazy, err := ObjectToTableWithOptions(objects, options)
+	Expect(err).To(BeNil())
+	Expect(lazy.Data).To(BeNil())
+	Expect(lazy.RowSources).To(HaveLen(2))
+	Expect(lazy.ValidateExtractors()).To(Succeed())
+
+	for _, format := range []string{"", "json", "csv", "yaml"} {
+		expected, err := eager.RenderTable("servers", "", format)
+		Expect(err).To(BeNil())
+		s, err := lazy.RenderTable("servers", "", format)
+		Expect(err).To(BeNil())
+		Expect(s).To(Equal(expected), format)
+	}
+}
+
+func TestObjectToTableWithTimeFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	type server struct {
+		ID       int
+		Created  time.Time
+		Deployed *time.Time
+	}
+	deployed := time.Date(2020, 11, 4, 12, 0, 0, 0, time.UTC)
+	table, err := ObjectToTableWithOptions([]server{
+		{ID: 1, Created: time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC), Deployed: &deployed},
+		{ID: 2, Created: time.Date(2020, 11, 2, 9, 30, 0, 0, time.UTC)},
+	}, ObjectToTableOptions{})
+	Expect(err).To(BeNil())
+	Expect(table.Schema[1].FieldType).To(Equal(TypeDateTime))
+	Expect(table.Schema[2].FieldType).To(Equal(TypeDateTime))
+
+	s, err := table.RenderTableAsCSV()
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("id,created,deployed\n" +
+		"1,2020-11-03T10:00:00Z,2020-11-04T12:00:00Z\n" +
+		"2,2020-11-02T09:30:00Z,\n"))
+}
@@ -0,0 +1,174 @@
+package tableformatter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+//PageFetchFunc returns the rows of a page, the first page being 0, and whether more pages follow.
+//The rows hold the cells of the fields that are not computed, like the rows of Table.Data.
+type PageFetchFunc func(page int) (rows [][]interface{}, more bool, err error)
+
+//PageProgressFunc is called after each page is fetched with the page and the number of rows fetched so far
+type PageProgressFunc func(page int, rows int)
+
+//WithPageProgress sets RenderOptions.PageProgress
+func WithPageProgress(progress PageProgressFunc) RenderOption {
+	return func(o *RenderOptions) {
+		o.PageProgress = progress
+	}
+}
+
+//BuildTablePaged returns a table with the rows of all the pages returned by fetch. The rows of each page are checked
+//against the schema as they arrive and the first page with a fetch error or an invalid row stops the loop.
+//The only option used is PageProgress.
+func BuildTablePaged(schema []SchemaField, fetch PageFetchFunc, opts ...RenderOption) (*Table, error) {
+	table := &Table{Schema: schema, Data: [][]interface{}{}}
+	err := fetchPages(schema, fetch, newRenderOptions(opts...), func(rows [][]interface{}) error {
+		table.Data = append(table.Data, rows...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+//RenderPaged renders the rows of all the pages returned by fetch to w. The text format writes each page as soon as it
+//arrives: the fields are sized on the first page and the cells of the later pages that are wider only widen their row.
+//The text format written page by page is never folded. The other formats, and the text format with the options
+//that need all the rows such as MaxOutputBytes or AggregateRow, are rendered like RenderTo once all the pages are fetched.
+func RenderPaged(w io.Writer, schema []SchemaField, fetch PageFetchFunc, opts ...RenderOption) error {
+	options := newRenderOptions(opts...)
+	format, err := resolveFormat(options.Format)
+	if err != nil {
+		return err
+	}
+
+	if format != "text" || !isPageable(options) {
+		table, err := BuildTablePaged(schema, fetch, opts...)
+		if err != nil {
+			return err
+		}
+		return table.RenderTo(w, opts...)
+	}
+
+	bw := bufio.NewWriter(w)
+	writeLine := func(line string) {
+		bw.WriteString(line)
+		bw.WriteString("\n")
+	}
+
+	table := &Table{Schema: schema}
+	//visible is the schema of the first page, sized for its rows
+	var visible []SchemaField
+
+	count := 0
+	delimiter := ""
+	writeHeader := func(rows [][]interface{}) {
+		adjustFieldSizes(withoutRawRows(rows), visible)
+		delimiter = getTableDelimiter(visible, options)
+		if options.TopLine != "" {
+			writeLine(options.TopLine)
+		}
+		writeLine(delimiter)
+		writeLine(getTableHeader(visible, options))
+		writeLine(delimiter)
+	}
+
+	err = fetchPages(schema, fetch, options, func(rows [][]interface{}) error {
+		data, resolved, err := table.getPreparedData(rows, format, options)
+		if err != nil {
+			return err
+		}
+		resolved = getProfileSchema(resolved, format, options)
+		data = withoutHiddenFields(data, resolved)
+		if delimiter == "" {
+			visible = getVisibleSchema(resolved)
+			writeHeader(data)
+		}
+		if err := checkPadding(visible); err != nil {
+			return err
+		}
+		for _, row := range data {
+			if isRawRow(row) {
+				writeLine(string(row[0].(RawRow)))
+				continue
+			}
+			color := ""
+			if options.RowColor != nil && !options.NoColor {
+				color = options.RowColor(count, row)
+			}
+			writeLine(getColoredTableRow(row, visible, color, options))
+			count++
+		}
+		return bw.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	writeLine(delimiter)
+	writeLine(getTrailer(count, options.TableName, options))
+	writeLine("")
+	return bw.Flush()
+}
+
+//isPageable returns true if the text format can be written one page at a time with the options
+func isPageable(options *RenderOptions) bool {
+	return !options.Transposed && !isNarrowLayout(options) && options.MaxOutputBytes == 0 && len(options.ValueMasks) == 0 &&
+		options.CollapseRunsField == "" && options.MaxColumns == 0 && !options.RowNumbers && !options.AggregateRow
+}
+
+//fetchPages calls fetch until it returns no more pages and calls add with the rows of each page once they are checked
+func fetchPages(schema []SchemaField, fetch PageFetchFunc, options *RenderOptions, add func(rows [][]interface{}) error) error {
+	total := 0
+	for page := 0; ; page++ {
+		rows, more, err := fetch(page)
+		if err != nil {
+			return fmt.Errorf("could not fetch page %d: %s", page, err)
+		}
+		if err := checkPageRows(rows, schema); err != nil {
+			return fmt.Errorf("page %d: %s", page, err)
+		}
+		if err := add(rows); err != nil {
+			return err
+		}
+
+		total += len(rows)
+		if options.PageProgress != nil {
+			options.PageProgress(page, total)
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+//checkPageRows returns an error for the first row of a page that does not have one cell of the type of its field
+//for each field that is not computed. Raw rows and nil cells are accepted.
+func checkPageRows(rows [][]interface{}, schema []SchemaField) error {
+	var fields []SchemaField
+	for _, field := range schema {
+		if !isComputed(&field) {
+			fields = append(fields, field)
+		}
+	}
+
+	for k, row := range rows {
+		if isRawRow(row) {
+			continue
+		}
+		if len(row) != len(fields) {
+			return fmt.Errorf("row %d has %d cells, expected %d", k, len(row), len(fields))
+		}
+		for i, d := range row {
+			if d != nil && !hasCellType(d, &fields[i]) {
+				return fmt.Errorf("row %d: %T cell in a %s field %s", k, d, fieldTypeNames[fields[i].FieldType], fields[i].FieldName)
+			}
+		}
+	}
+	return nil
+}
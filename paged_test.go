@@ -0,0 +1,163 @@
+package tableformatter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//getPageFetch returns a fetch function serving pages and recording the pages fetched
+func getPageFetch(pages [][][]interface{}, fetched *[]int) PageFetchFunc {
+	return func(page int) ([][]interface{}, bool, error) {
+		*fetched = append(*fetched, page)
+		return pages[page], page < len(pages)-1, nil
+	}
+}
+
+func TestBuildTablePaged(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	pages := [][][]interface{}{
+		{{1, "production"}, {2, "test"}},
+		{{3, "staging"}},
+		{},
+	}
+
+	fetched := []int{}
+	progress := []int{}
+	table, err := BuildTablePaged(schema, getPageFetch(pages, &fetched), WithPageProgress(func(page int, rows int) {
+		progress = append(progress, rows)
+	}))
+	Expect(err).To(BeNil())
+	Expect(fetched).To(Equal([]int{0, 1, 2}))
+	Expect(progress).To(Equal([]int{2, 3, 3}))
+	Expect(table.Data).To(Equal([][]interface{}{{1, "production"}, {2, "test"}, {3, "staging"}}))
+
+	//the invalid page stops the loop
+	pages[1] = [][]interface{}{{3, "staging"}, {"4", "qa"}}
+	fetched = []int{}
+	_, err = BuildTablePaged(schema, getPageFetch(pages, &fetched))
+	Expect(err).To(MatchError("page 1: row 1: string cell in a int field ID"))
+	Expect(fetched).To(Equal([]int{0, 1}))
+
+	pages[1] = [][]interface{}{{3}}
+	_, err = BuildTablePaged(schema, getPageFetch(pages, &fetched))
+	Expect(err).To(MatchError("page 1: row 0 has 1 cells, expected 2"))
+
+	_, err = BuildTablePaged(schema, func(page int) ([][]interface{}, bool, error) {
+		return nil, false, errors.New("timeout")
+	})
+	Expect(err).To(MatchError("could not fetch page 0: timeout"))
+}
+
+func TestRenderPaged(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	pages := [][][]interface{}{
+		{{1, "production"}, {2, "test"}},
+		{{3, "staging"}},
+	}
+
+	//the text format is written as the pages arrive
+	var sb strings.Builder
+	fetched := []int{}
+	fetch := getPageFetch(pages, &fetched)
+	err := RenderPaged(&sb, schema, func(page int) ([][]interface{}, bool, error) {
+		if page == 1 {
+			Expect(sb.String()).To(ContainSubstring("| 2  | test       |"))
+		}
+		return fetch(page)
+	}, WithTableName("servers"), WithTopLine("Servers:"))
+	Expect(err).To(BeNil())
+
+	table := Table{Data: [][]interface{}{{1, "production"}, {2, "test"}, {3, "staging"}}, Schema: schema}
+	expected, err := table.Render(WithTableName("servers"), WithTopLine("Servers:"))
+	Expect(err).To(BeNil())
+	Expect(sb.String()).To(Equal(expected))
+
+	//the cells of later pages that are wider than the first page widen their row only
+	pages[1] = [][]interface{}{{3, "pre-production"}}
+	sb.Reset()
+	Expect(RenderPaged(&sb, schema, getPageFetch(pages, &fetched), WithTableName("servers"))).To(Succeed())
+	Expect(sb.String()).To(Equal(`+----+------------+
+| ID | LABEL      |
++----+------------+
+| 1  | production |
+| 2  | test       |
+| 3  | pre-production|
++----+------------+
+Total: 3 servers
+
+`))
+
+	//the other formats are rendered once all the pages are fetched
+	sb.Reset()
+	Expect(RenderPaged(&sb, schema, getPageFetch(pages, &fetched), WithFormat("json"))).To(Succeed())
+	table.Data[2][1] = "pre-production"
+	expected, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(sb.String()).To(Equal(expected))
+
+	//an empty listing still has a header, sized for the names of the fields
+	sb.Reset()
+	schema = []SchemaField{{FieldName: "ID", FieldType: TypeInt}, {FieldName: "LABEL", FieldType: TypeString}}
+	Expect(RenderPaged(&sb, schema, func(page int) ([][]interface{}, bool, error) {
+		return nil, false, nil
+	}, WithTableName("servers"))).To(Succeed())
+	Expect(sb.String()).To(HavePrefix("+----+-------+\n| ID | LABEL |\n"))
+	Expect(sb.String()).To(HaveSuffix("Total: 0 servers\n\n"))
+}
+
+func TestRenderPagedPreparesTheCellsLikeRenderTo(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:           "RAM",
+			FieldType:           TypeString,
+			FieldNormalizeUnits: true,
+		},
+	}
+	pages := [][][]interface{}{
+		{{"\x1b[31ma\x1b[0m", "2 GB"}, {"b", "512 MB"}},
+		{{"c", "1024 MB"}},
+	}
+
+	var sb strings.Builder
+	fetched := []int{}
+	Expect(RenderPaged(&sb, schema, getPageFetch(pages, &fetched), WithTableName("servers"), WithoutColors())).To(Succeed())
+
+	table := Table{Data: [][]interface{}{pages[0][0], pages[0][1], pages[1][0]}, Schema: schema}
+	expected, err := table.Render(WithTableName("servers"), WithoutColors())
+	Expect(err).To(BeNil())
+	Expect(sb.String()).To(Equal(expected))
+	Expect(sb.String()).To(ContainSubstring("| a    | 2.0 GB   |"))
+}
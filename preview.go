@@ -0,0 +1,77 @@
+package tableformatter
+
+import "fmt"
+
+//previewNoticeFormat is the line the text formats of RenderPreview end with when rows are left out
+const previewNoticeFormat = "(+%d more rows)\n"
+
+//previewNoticeFormats are the formats in which RenderPreview writes the number of rows left out
+var previewNoticeFormats = map[string]bool{
+	"text":       true,
+	"text-fixed": true,
+	"aligned":    true,
+}
+
+//RenderPreview renders the leading rows of the table that fit in maxBytes bytes in the given format, such as a preview
+//posted to a chat or a webhook with a payload limit, and whether rows were left out. The text, text-fixed and aligned
+//formats end with a "(+N more rows)" line when they were. The output never exceeds maxBytes: if not even
+//the first row fits only the header and the notice are rendered, and if the header does not fit either only the notice.
+//The options are applied like in Render, MaxOutputBytes being set by RenderPreview.
+func (t *Table) RenderPreview(maxBytes int, format string, opts ...RenderOption) (string, bool, error) {
+	if maxBytes <= 0 {
+		return "", false, fmt.Errorf("invalid preview size %d", maxBytes)
+	}
+	name, err := resolveFormat(format)
+	if err != nil {
+		return "", false, err
+	}
+
+	rowCount := len(withoutRawRows(t.Data))
+	if t.RowSources != nil {
+		rowCount = len(t.RowSources)
+	}
+
+	//the notice is given room for the number of rows of the whole table
+	budget := maxBytes
+	if previewNoticeFormats[name] {
+		budget -= len(fmt.Sprintf(previewNoticeFormat, rowCount))
+	}
+
+	s := ""
+	var truncated *ErrOutputTruncated
+	for budget > 0 {
+		s, err = t.Render(append(opts, WithFormat(format), WithMaxOutputBytes(budget))...)
+		truncated = nil
+		if e, ok := err.(*ErrOutputTruncated); ok {
+			truncated = e
+		} else if err != nil {
+			return "", false, err
+		}
+
+		if len(s) <= budget {
+			break
+		}
+		//the header alone does not fit
+		if truncated != nil && truncated.RenderedRows == 0 {
+			s = ""
+			break
+		}
+		//the size of the rows is an estimate, try again with less room for them
+		budget -= len(s) - budget
+		s = ""
+	}
+
+	if truncated == nil && budget > 0 {
+		return s, false, nil
+	}
+	if previewNoticeFormats[name] {
+		left := rowCount
+		if truncated != nil && s != "" {
+			left = truncated.TotalRows - truncated.RenderedRows
+		}
+		if notice := fmt.Sprintf(previewNoticeFormat, left); len(s)+len(notice) <= maxBytes {
+			s += notice
+		}
+	}
+	return s, true, nil
+}
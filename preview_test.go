@@ -0,0 +1,131 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderPreview(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for i := 0; i < 1000; i++ {
+		data = append(data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	table := Table{Data: data, Schema: schema}
+	for _, format := range getFormats() {
+		for _, maxBytes := range []int{100, 500, 2000, 10000} {
+			s, truncated, err := table.RenderPreview(maxBytes, format)
+			Expect(err).To(BeNil(), format)
+			Expect(truncated).To(BeTrue(), format)
+			Expect(len(s)).To(BeNumerically("<=", maxBytes), format)
+		}
+	}
+
+	s, truncated, err := table.RenderPreview(1000, "json")
+	Expect(err).To(BeNil())
+	Expect(truncated).To(BeTrue())
+	var rows []map[string]interface{}
+	Expect(json.Unmarshal([]byte(s), &rows)).To(Succeed())
+	Expect(rows).NotTo(BeEmpty())
+}
+
+func TestRenderPreviewText(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for i := 0; i < 3; i++ {
+		data = append(data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	table := Table{Data: data, Schema: schema}
+	s, truncated, err := table.RenderPreview(10000, "")
+	Expect(err).To(BeNil())
+	Expect(truncated).To(BeFalse())
+	Expect(s).NotTo(ContainSubstring("more rows"))
+
+	full := s
+	s, truncated, err = table.RenderPreview(len(full)-1, "")
+	Expect(err).To(BeNil())
+	Expect(truncated).To(BeTrue())
+	Expect(s).To(Equal(
+		"+----+---------+\n" +
+			"| ID | LABEL   |\n" +
+			"+----+---------+\n" +
+			"| 0  | label-0 |\n" +
+			"| 1  | label-1 |\n" +
+			"+----+---------+\n" +
+			"Total: 3 \n\n" +
+			"(+1 more rows)\n"))
+}
+
+func TestRenderPreviewRowLargerThanBudget(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{}
+	for i := 0; i < 2; i++ {
+		data = append(data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data[0][1] = strings.Repeat("x", 1000)
+	s, truncated, err := table.RenderPreview(200, "")
+	Expect(err).To(BeNil())
+	Expect(truncated).To(BeTrue())
+	//the table is folded because of the long row, only the header of the folded table is rendered
+	Expect(s).To(Equal("+--------+\n| Values |\n+--------+\n+--------+\nTotal: 2 \n\n(+2 more rows)\n"))
+
+	//the header does not fit either
+	s, truncated, err = table.RenderPreview(20, "")
+	Expect(err).To(BeNil())
+	Expect(truncated).To(BeTrue())
+	Expect(s).To(Equal("(+2 more rows)\n"))
+
+	s, truncated, err = table.RenderPreview(5, "")
+	Expect(err).To(BeNil())
+	Expect(truncated).To(BeTrue())
+	Expect(s).To(BeEmpty())
+
+	_, _, err = table.RenderPreview(0, "")
+	Expect(err).To(HaveOccurred())
+	_, _, err = table.RenderPreview(100, "xml")
+	Expect(err).To(Equal(&ErrInvalidFormat{Format: "xml"}))
+}
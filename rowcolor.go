@@ -0,0 +1,19 @@
+package tableformatter
+
+//RowColorFunc returns the ANSI escape sequence the text format starts every cell of a row with, such as "\x1b[1m",
+//or an empty string to leave the row as is. rowIndex counts the rows of the table, starting at 0, without the raw rows.
+type RowColorFunc func(rowIndex int, row []interface{}) string
+
+//rowColorReset ends the color of each cell of a row colored by RenderOptions.RowColor
+const rowColorReset = "\x1b[0m"
+
+//zebraStripeColor is the dim background of the odd rows colored by ZebraStripe
+const zebraStripeColor = "\x1b[48;5;236m"
+
+//ZebraStripe is a RowColorFunc giving every other row, starting with the second, a dim background
+func ZebraStripe(rowIndex int, row []interface{}) string {
+	if rowIndex%2 == 1 {
+		return zebraStripeColor
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderWithZebraStripe(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	plain, err := table.Render()
+	Expect(err).To(BeNil())
+	striped, err := table.Render(WithRowColorFunc(ZebraStripe))
+	Expect(err).To(BeNil())
+	Expect(decolorize(striped)).To(Equal(decolorize(plain)))
+
+	lines := strings.Split(striped, "\n")
+	Expect(lines[3]).To(Equal(strings.Split(plain, "\n")[3]))
+	Expect(lines[4]).To(Equal("--- raw ---"))
+	//the raw row is not counted
+	Expect(lines[5]).To(Equal("|" + zebraStripeColor + "       " + rowColorReset + "|" + zebraStripeColor + " 1.26  " + rowColorReset + "|" + zebraStripeColor + " 2020-11-04 " + rowColorReset + "|"))
+	Expect(lines[6]).NotTo(ContainSubstring(zebraStripeColor))
+
+	AssertWidthStable(t, table, WithRowColorFunc(ZebraStripe))
+}
+
+func TestRenderWithRowColorFunc(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	bold := func(rowIndex int, row []interface{}) string {
+		if row[0] == "db-1" {
+			return "\x1b[1m"
+		}
+		return ""
+	}
+
+	s, err := table.Render(WithRowColorFunc(bold))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("|\x1b[1m db-1  \x1b[0m|"))
+	Expect(strings.Count(s, "\x1b[1m")).To(Equal(3))
+
+	//the other formats and NoColor are not colored
+	for _, opts := range [][]RenderOption{{WithFormat("csv")}, {WithFormat("json")}, {WithFormat("yaml")}, {WithoutColors()}} {
+		s, err := table.Render(append(opts, WithRowColorFunc(bold))...)
+		Expect(err).To(BeNil())
+		Expect(s).NotTo(ContainSubstring("\x1b[1m"))
+	}
+}
@@ -0,0 +1,36 @@
+package tableformatter
+
+import "math"
+
+//rowNumbersFieldName is the name of the field added by RenderOptions.RowNumbers
+const rowNumbersFieldName = "#"
+
+//getSchemaWithRowNumbers returns a copy of the schema with the field of the row numbers first.
+//The field has the highest priority so that RenderOptions.MaxColumns keeps it.
+func getSchemaWithRowNumbers(schema []SchemaField) []SchemaField {
+	newSchema := make([]SchemaField, 0, len(schema)+1)
+	newSchema = append(newSchema, SchemaField{
+		FieldName:     rowNumbersFieldName,
+		FieldType:     TypeInt,
+		FieldPriority: math.MaxInt32,
+	})
+	return append(newSchema, schema...)
+}
+
+//withRowNumbers returns a copy of data with the number of each row, starting at 1, as its first cell. Raw rows are kept as is and not numbered.
+func withRowNumbers(data [][]interface{}) [][]interface{} {
+	newData := make([][]interface{}, len(data))
+	n := 0
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		n++
+		newRow := make([]interface{}, 0, len(row)+1)
+		newRow = append(newRow, n)
+		newData[k] = append(newRow, row...)
+	}
+	return newData
+}
@@ -0,0 +1,104 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderWithRowNumbers(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := Table{
+		Schema: []SchemaField{
+			{
+				FieldName: "LABEL",
+				FieldType: TypeString,
+			},
+			{
+				FieldName:      "COST",
+				FieldType:      TypeFloat,
+				FieldPrecision: 2,
+			},
+			{
+				FieldName:   "CREATED",
+				FieldType:   TypeDateTime,
+				FieldFormat: "2006-01-02",
+			},
+		},
+		Data: [][]interface{}{
+			{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+			NewRawRow("--- raw ---"),
+			{nil, 1.257, "2020-11-04"},
+			{"db-1", 3.0, "2020-11-05"},
+		},
+	}
+	schema := make([]SchemaField, len(table.Schema))
+	copy(schema, table.Schema)
+	data := make([][]interface{}, len(table.Data))
+	copy(data, table.Data)
+
+	s, err := table.Render(WithRowNumbers(), WithTableName("servers"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+---+-------+-------+------------+\n" +
+			"| # | LABEL | COST  | CREATED    |\n" +
+			"+---+-------+-------+------------+\n" +
+			"| 1 | \x1b[32mweb-1\x1b[0m | 10.50 | 2020-11-03 |\n" +
+			"--- raw ---\n" +
+			"| 2 |       | 1.26  | 2020-11-04 |\n" +
+			"| 3 | db-1  | 3.00  | 2020-11-05 |\n" +
+			"+---+-------+-------+------------+\n" +
+			"Total: 3 servers\n\n"))
+
+	s, err = table.Render(WithRowNumbers(), WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(strings.Split(s, "\n")[:3]).To(Equal([]string{"#,LABEL,COST,CREATED", "1,\x1b[32mweb-1\x1b[0m,10.500000,2020-11-03", "2,,1.257000,2020-11-04"}))
+
+	s, err = table.Render(WithRowNumbers(), WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"#": 3`))
+
+	//the table is not changed
+	Expect(table.Schema).To(Equal(schema))
+	Expect(table.Data).To(Equal(data))
+
+	s, err = table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("#"))
+}
+
+func TestRenderWithRowNumbersWidth(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := Table{
+		Schema: []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "LABEL",
+				FieldType: TypeString,
+			},
+		},
+	}
+	for i := 0; i < 120; i++ {
+		table.Data = append(table.Data, []interface{}{i, fmt.Sprintf("label-%d", i)})
+	}
+
+	s, err := table.Render(WithRowNumbers())
+	Expect(err).To(BeNil())
+	lines := strings.Split(s, "\n")
+	Expect(lines[1]).To(HavePrefix("| #   | ID  |"))
+	Expect(lines[3]).To(HavePrefix("| 1   | 0   |"))
+	Expect(lines[122]).To(HavePrefix("| 120 | 119 |"))
+
+	//the numbers follow the order of the sorted data
+	Expect(TableSorter(table.Schema).OrderBy("LABEL").SortErr(table.Data)).To(Succeed())
+	s, err = table.Render(WithRowNumbers())
+	Expect(err).To(BeNil())
+	Expect(strings.Split(s, "\n")[5]).To(HavePrefix("| 3   | 10  | label-10 "))
+}
@@ -0,0 +1,96 @@
+package tableformatter
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+//sampleWords are the words the strings of GenerateSampleTable are made of
+var sampleWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua",
+}
+
+//sampleStringSize is the size of the strings of GenerateSampleTable in fields without a FieldSize
+const sampleStringSize = 12
+
+//sampleTimeStart is the earliest date time of GenerateSampleTable, the others are spread over the year that follows
+var sampleTimeStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+//GenerateSampleTable returns a table with rows of made up cells for the schema, such as to preview a layout
+//before the real data is available. The same seed always produces the same cells.
+//Int fields hold values from 0 to 9999, float fields values from 0 to 1000 rounded to their FieldPrecision,
+//string and interface fields words sized close to their FieldSize and date time fields time.Time values over a year.
+//Computed fields are computed as usual and the fields of custom types are left nil.
+func GenerateSampleTable(schema []SchemaField, rows int, seed int64) *Table {
+	r := rand.New(rand.NewSource(seed))
+
+	data := make([][]interface{}, rows)
+	for k := range data {
+		row := []interface{}{}
+		for i := range schema {
+			if isComputed(&schema[i]) {
+				continue
+			}
+			row = append(row, getSampleCell(r, &schema[i]))
+		}
+		data[k] = row
+	}
+
+	newSchema := make([]SchemaField, len(schema))
+	copy(newSchema, schema)
+
+	return &Table{Data: data, Schema: newSchema}
+}
+
+//getSampleCell returns a made up cell of the type of the field
+func getSampleCell(r *rand.Rand, field *SchemaField) interface{} {
+	switch field.FieldType {
+	case TypeInt:
+		return r.Intn(10000)
+	case TypeFloat:
+		f := r.Float64() * 1000
+		if field.FieldPrecision > 0 {
+			scale := math.Pow(10, float64(field.FieldPrecision))
+			f = math.Round(f*scale) / scale
+		}
+		return f
+	case TypeString, TypeInterface:
+		return getSampleString(r, field.FieldSize)
+	case TypeDateTime:
+		return sampleTimeStart.Add(time.Duration(r.Int63n(365*24*60*60)) * time.Second)
+	case TypeBool:
+		return r.Intn(2) == 1
+	case TypeDuration:
+		return time.Duration(r.Int63n(72*60*60)) * time.Second
+	case TypeVersion:
+		return fmt.Sprintf("%d.%d.%d", r.Intn(5), r.Intn(20), r.Intn(10))
+	case TypeIP:
+		return fmt.Sprintf("10.%d.%d.%d", r.Intn(256), r.Intn(256), 1+r.Intn(254))
+	case TypeBytes:
+		return r.Int63n(1 << 40)
+	default:
+		return nil
+	}
+}
+
+//getSampleString returns words separated by spaces, between three quarters of size and size characters long
+func getSampleString(r *rand.Rand, size int) string {
+	if size <= 0 {
+		size = sampleStringSize
+	}
+	length := size - r.Intn(size/4+1)
+
+	var sb strings.Builder
+	for sb.Len() < length {
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(sampleWords[r.Intn(len(sampleWords))])
+	}
+
+	return strings.TrimRight(sb.String()[:length], " ")
+}
@@ -0,0 +1,122 @@
+package tableformatter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGenerateSampleTable(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 20,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "ENABLED",
+			FieldType: TypeBool,
+		},
+		{
+			FieldName: "UPTIME",
+			FieldType: TypeDuration,
+		},
+		{
+			FieldName:        "DOUBLE_COST",
+			FieldType:        TypeFloat,
+			FieldComputeFrom: []string{"COST"},
+			FieldCompute: func(values ...interface{}) interface{} {
+				return values[0].(float64) * 2
+			},
+		},
+	}
+	table := GenerateSampleTable(schema, 50, 1)
+	Expect(table.Data).To(HaveLen(50))
+	Expect(table.Validate()).To(Succeed())
+	Expect(DiagnoseTable(*table)).To(BeEmpty())
+
+	for _, row := range table.Data {
+		Expect(row).To(HaveLen(6))
+		Expect(row[0]).To(BeNumerically("<", 10000))
+
+		label := row[1].(string)
+		Expect(len(label)).To(BeNumerically("<=", 20))
+		Expect(len(label)).To(BeNumerically(">=", 14))
+
+		cost := row[2].(float64)
+		Expect(cost).To(BeNumerically("~", math.Round(cost*100)/100, 1e-9))
+
+		created := row[3].(time.Time)
+		Expect(created.Before(sampleTimeStart)).To(BeFalse())
+		Expect(created.Before(sampleTimeStart.AddDate(1, 0, 0))).To(BeTrue())
+
+		Expect(row[4]).To(BeAssignableToTypeOf(true))
+		Expect(row[5]).To(BeAssignableToTypeOf(time.Duration(0)))
+	}
+
+	_, err := table.RenderTable("samples", "", "")
+	Expect(err).To(BeNil())
+}
+
+func TestGenerateSampleTableSeed(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 20,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "ENABLED",
+			FieldType: TypeBool,
+		},
+		{
+			FieldName: "UPTIME",
+			FieldType: TypeDuration,
+		},
+		{
+			FieldName:        "DOUBLE_COST",
+			FieldType:        TypeFloat,
+			FieldComputeFrom: []string{"COST"},
+			FieldCompute: func(values ...interface{}) interface{} {
+				return values[0].(float64) * 2
+			},
+		},
+	}
+	Expect(GenerateSampleTable(schema, 10, 7).Data).To(Equal(GenerateSampleTable(schema, 10, 7).Data))
+	Expect(GenerateSampleTable(schema, 10, 7).Data).NotTo(Equal(GenerateSampleTable(schema, 10, 8).Data))
+
+	table := GenerateSampleTable([]SchemaField{{FieldName: "CUSTOM", FieldType: MinCustomFieldType + 99}}, 2, 1)
+	Expect(table.Data).To(Equal([][]interface{}{{nil}, {nil}}))
+}
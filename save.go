@@ -0,0 +1,322 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+//tableEnvelopeVersion is the version of the format written by Save. LoadTable refuses newer versions.
+//Version 2 added the cells that are not plain values.
+const tableEnvelopeVersion = 2
+
+//tableEnvelope is the self describing document written by Save
+type tableEnvelope struct {
+	Version    int             `json:"version"`
+	TimeFormat string          `json:"timeFormat,omitempty"`
+	Schema     []savedField    `json:"schema"`
+	Data       [][]interface{} `json:"data"`
+	Cells      []savedCell     `json:"cells,omitempty"`
+}
+
+const (
+	//savedCellKindCell is a Cell, whose Value is in the data
+	savedCellKindCell = "cell"
+	//savedCellKindSeverity is a SeverityCell, whose Value is in the data
+	savedCellKindSeverity = "severity"
+	//savedCellKindRawRow is a raw row, whose line is the only cell of the row in the data
+	savedCellKindRawRow = "rawRow"
+)
+
+//savedCell is a cell of the data that is not a plain value, the data holding its value at the same position
+type savedCell struct {
+	Row      int      `json:"row"`
+	Column   int      `json:"column"`
+	Kind     string   `json:"kind"`
+	AsString string   `json:"asString,omitempty"`
+	Severity Severity `json:"severity,omitempty"`
+}
+
+//savedField is a SchemaField with the type stored by name
+type savedField struct {
+	FieldName                    string `json:"fieldName"`
+	FieldType                    string `json:"fieldType"`
+	FieldSize                    int    `json:"fieldSize,omitempty"`
+	FieldPrecision               int    `json:"fieldPrecision,omitempty"`
+	FieldFormat                  string `json:"fieldFormat,omitempty"`
+	FieldOutputFormat            string `json:"fieldOutputFormat,omitempty"`
+	FieldIgnoreRegisteredLayouts bool   `json:"fieldIgnoreRegisteredLayouts,omitempty"`
+	FieldDescription             string `json:"fieldDescription,omitempty"`
+	FieldNotSortable             bool   `json:"fieldNotSortable,omitempty"`
+	FieldSortKey                 int    `json:"fieldSortKey,omitempty"`
+	FieldSortDescendingByDefault bool   `json:"fieldSortDescendingByDefault,omitempty"`
+	FieldGroup                   string `json:"fieldGroup,omitempty"`
+	FieldDefault                 string `json:"fieldDefault,omitempty"`
+	FieldID                      string `json:"fieldID,omitempty"`
+	FieldPriority                int    `json:"fieldPriority,omitempty"`
+	FieldAlignment               int    `json:"fieldAlignment,omitempty"`
+	FieldHidden                  bool   `json:"fieldHidden,omitempty"`
+	FieldWrapAt                  int    `json:"fieldWrapAt,omitempty"`
+	FieldAggregate               int    `json:"fieldAggregate,omitempty"`
+	FieldWideOnly                bool   `json:"fieldWideOnly,omitempty"`
+	FieldNormalizeUnits          bool   `json:"fieldNormalizeUnits,omitempty"`
+	FieldSortNatural             bool   `json:"fieldSortNatural,omitempty"`
+}
+
+//Save writes the schema and the data of the table as a json document that can be read back with LoadTable.
+//Date time cells holding a time.Time or a non nil *time.Time are saved as strings formatted with the layout of the field and duration cells
+//as time.Duration numbers of nanoseconds.
+//Cell and SeverityCell values and raw rows are saved with their value in the data and what they add in a list of their own.
+//Computed fields are saved as regular fields holding the computed cells.
+func (t *Table) Save(w io.Writer) error {
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return err
+	}
+	data, err := computeColumns(t.getData(getFieldNames(t.Schema)...), t.Schema)
+	if err != nil {
+		return err
+	}
+
+	envelope := tableEnvelope{
+		Version:    tableEnvelopeVersion,
+		TimeFormat: t.TimeFormat,
+		Schema:     make([]savedField, len(t.Schema)),
+		Data:       make([][]interface{}, len(data)),
+	}
+
+	for i, field := range t.Schema {
+		typeName, ok := fieldTypeNames[field.FieldType]
+		if !ok {
+			return fmt.Errorf("could not save field %s: unknown type %d", field.FieldName, field.FieldType)
+		}
+		envelope.Schema[i] = savedField{
+			FieldName:                    field.FieldName,
+			FieldType:                    typeName,
+			FieldSize:                    field.FieldSize,
+			FieldPrecision:               field.FieldPrecision,
+			FieldFormat:                  field.FieldFormat,
+			FieldOutputFormat:            field.FieldOutputFormat,
+			FieldIgnoreRegisteredLayouts: field.FieldIgnoreRegisteredLayouts,
+			FieldDescription:             field.FieldDescription,
+			FieldNotSortable:             field.FieldNotSortable,
+			FieldSortKey:                 field.FieldSortKey,
+			FieldSortDescendingByDefault: field.FieldSortDescendingByDefault,
+			FieldGroup:                   field.FieldGroup,
+			FieldDefault:                 field.FieldDefault,
+			FieldID:                      field.FieldID,
+			FieldPriority:                field.FieldPriority,
+			FieldAlignment:               int(field.FieldAlignment),
+			FieldHidden:                  field.FieldHidden,
+			FieldWrapAt:                  field.FieldWrapAt,
+			FieldAggregate:               int(field.FieldAggregate),
+			FieldWideOnly:                field.FieldWideOnly,
+			FieldNormalizeUnits:          field.FieldNormalizeUnits,
+			FieldSortNatural:             field.FieldSortNatural,
+		}
+	}
+
+	schema := t.getResolvedSchema()
+	for k, row := range data {
+		if isRawRow(row) {
+			envelope.Data[k] = []interface{}{string(row[0].(RawRow))}
+			envelope.Cells = append(envelope.Cells, savedCell{Row: k, Kind: savedCellKindRawRow})
+			continue
+		}
+
+		newRow := make([]interface{}, len(row))
+		for i, v := range row {
+			switch c := v.(type) {
+			case Cell:
+				envelope.Cells = append(envelope.Cells, savedCell{Row: k, Column: i, Kind: savedCellKindCell, AsString: c.AsString})
+				v = c.Value
+			case SeverityCell:
+				envelope.Cells = append(envelope.Cells, savedCell{Row: k, Column: i, Kind: savedCellKindSeverity, Severity: c.Severity})
+				v = c.Value
+			}
+			if i < len(schema) {
+				v = getSavedValue(v, &schema[i])
+			}
+			newRow[i] = v
+		}
+		envelope.Data[k] = newRow
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(envelope)
+}
+
+//getSavedValue returns the value saved for a cell of the field: date time cells holding a time.Time or a *time.Time
+//are formatted with the layout of the field and duration cells are time.Duration numbers of nanoseconds
+func getSavedValue(v interface{}, field *SchemaField) interface{} {
+	if tm, ok := v.(*time.Time); ok && field.FieldType == TypeDateTime {
+		if tm == nil {
+			return nil
+		}
+		v = *tm
+	}
+	if tm, ok := v.(time.Time); ok && field.FieldType == TypeDateTime {
+		return formatTime(tm, getTimeLayout(field))
+	}
+	if duration, ok := parseDuration(v); ok && field.FieldType == TypeDuration {
+		return duration
+	}
+	return v
+}
+
+//LoadTable reads a table written by Save. The Value of a Cell that does not hold the type of its field,
+//such as N/A in an int field, is loaded as decoded from json.
+func LoadTable(r io.Reader) (*Table, error) {
+	var envelope tableEnvelope
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&envelope); err != nil {
+		return nil, err
+	}
+
+	if envelope.Version > tableEnvelopeVersion {
+		return nil, fmt.Errorf("table was saved with version %d, only versions up to %d are supported", envelope.Version, tableEnvelopeVersion)
+	}
+
+	schema := make([]SchemaField, len(envelope.Schema))
+	for i, field := range envelope.Schema {
+		fieldType, err := getFieldTypeByName(field.FieldType)
+		if err != nil {
+			return nil, fmt.Errorf("could not load field %s: %s", field.FieldName, err)
+		}
+		schema[i] = SchemaField{
+			FieldName:                    field.FieldName,
+			FieldType:                    fieldType,
+			FieldSize:                    field.FieldSize,
+			FieldPrecision:               field.FieldPrecision,
+			FieldFormat:                  field.FieldFormat,
+			FieldOutputFormat:            field.FieldOutputFormat,
+			FieldIgnoreRegisteredLayouts: field.FieldIgnoreRegisteredLayouts,
+			FieldDescription:             field.FieldDescription,
+			FieldNotSortable:             field.FieldNotSortable,
+			FieldSortKey:                 field.FieldSortKey,
+			FieldSortDescendingByDefault: field.FieldSortDescendingByDefault,
+			FieldGroup:                   field.FieldGroup,
+			FieldDefault:                 field.FieldDefault,
+			FieldID:                      field.FieldID,
+			FieldPriority:                field.FieldPriority,
+			FieldAlignment:               Alignment(field.FieldAlignment),
+			FieldHidden:                  field.FieldHidden,
+			FieldWrapAt:                  field.FieldWrapAt,
+			FieldAggregate:               Aggregate(field.FieldAggregate),
+			FieldWideOnly:                field.FieldWideOnly,
+			FieldNormalizeUnits:          field.FieldNormalizeUnits,
+			FieldSortNatural:             field.FieldSortNatural,
+		}
+	}
+
+	//the cells that are not plain values by row and column
+	cells := map[int]map[int]savedCell{}
+	for _, c := range envelope.Cells {
+		if c.Row < 0 || c.Row >= len(envelope.Data) || c.Column < 0 || c.Column >= len(envelope.Data[c.Row]) {
+			return nil, fmt.Errorf("could not load cell at row %d column %d: the data has no such cell", c.Row, c.Column)
+		}
+		if cells[c.Row] == nil {
+			cells[c.Row] = map[int]savedCell{}
+		}
+		cells[c.Row][c.Column] = c
+	}
+
+	data := make([][]interface{}, len(envelope.Data))
+	for k, row := range envelope.Data {
+		if c, ok := cells[k][0]; ok && c.Kind == savedCellKindRawRow {
+			line, ok := row[0].(string)
+			if !ok || len(row) != 1 {
+				return nil, fmt.Errorf("could not load raw row %d: expected a single string, got %v", k, row)
+			}
+			data[k] = NewRawRow(line)
+			continue
+		}
+
+		newRow := make([]interface{}, len(row))
+		for i, v := range row {
+			c, isSaved := cells[k][i]
+			if i < len(schema) && v != nil {
+				cell, err := loadCell(v, &schema[i])
+				switch {
+				case err == nil:
+					v = cell
+				case !isSaved || c.Kind != savedCellKindCell:
+					return nil, fmt.Errorf("could not load cell at row %d column %s: %s", k, schema[i].FieldName, err)
+				}
+			}
+			if isSaved {
+				switch c.Kind {
+				case savedCellKindCell:
+					v = Cell{Value: v, AsString: c.AsString}
+				case savedCellKindSeverity:
+					v = SeverityCell{Value: v, Severity: c.Severity}
+				default:
+					return nil, fmt.Errorf("could not load cell at row %d column %d: unknown kind %s", k, i, c.Kind)
+				}
+			}
+			newRow[i] = v
+		}
+		data[k] = newRow
+	}
+
+	return &Table{Data: data, Schema: schema, TimeFormat: envelope.TimeFormat}, nil
+}
+
+//loadCell converts a value decoded from json to the go type expected by the field
+func loadCell(v interface{}, field *SchemaField) (interface{}, error) {
+	switch field.FieldType {
+	case TypeInt:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return int(i), nil
+	case TypeFloat:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %v", v)
+		}
+		return n.Float64()
+	case TypeBytes:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %v", v)
+		}
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	case TypeString, TypeDateTime, TypeVersion, TypeIP:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %v", v)
+		}
+		return s, nil
+	case TypeDuration:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return time.Duration(i), nil
+	case TypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a bool, got %v", v)
+		}
+		return b, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,251 @@
+package tableformatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSaveAndLoadTable(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:        "ID",
+			FieldType:        TypeInt,
+			FieldSize:        6,
+			FieldDescription: "the id",
+		},
+		{
+			FieldName:   "LABEL",
+			FieldType:   TypeString,
+			FieldSize:   20,
+			FieldGroup:  "identification",
+			FieldWrapAt: 30,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldSize:      6,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:                    "CREATED",
+			FieldType:                    TypeDateTime,
+			FieldSortDescendingByDefault: true,
+		},
+		{
+			FieldName: "DETAILS",
+			FieldType: TypeInterface,
+		},
+		{
+			FieldName: "ENABLED",
+			FieldType: TypeBool,
+		},
+		{
+			FieldName:   "AGE",
+			FieldType:   TypeDuration,
+			FieldFormat: "age",
+		},
+		{
+			FieldName:         "UPDATED",
+			FieldType:         TypeDateTime,
+			FieldFormat:       time.RFC3339 + "|02/01/2006",
+			FieldOutputFormat: "2006-01-02 15:04",
+		},
+	}
+
+	data := [][]interface{}{
+		{4, "str\nsecond line", 20.1, "2013-11-29T13:00:01Z", "details", true, 5 * time.Hour, "2013-11-30T08:15:00Z"},
+		{5, "st11r", 22.15, "2014-11-29T13:00:01Z", nil, false, 90 * time.Second, "01/12/2014"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	var buf bytes.Buffer
+	err := table.Save(&buf)
+	Expect(err).To(BeNil())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Schema).To(Equal(schema))
+	Expect(loaded.Data).To(Equal(data))
+
+	expected, err := table.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	actual, err := loaded.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(actual).To(Equal(expected))
+	Expect(actual).To(ContainSubstring("2014-12-01 00:00"))
+}
+
+func TestSaveAndLoadTableWithNilCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{FieldName: "ID", FieldType: TypeInt},
+		{FieldName: "LABEL", FieldType: TypeString},
+		{FieldName: "INST.", FieldType: TypeFloat},
+		{FieldName: "CREATED", FieldType: TypeDateTime},
+		{FieldName: "DETAILS", FieldType: TypeInterface},
+		{FieldName: "ENABLED", FieldType: TypeBool},
+		{FieldName: "AGE", FieldType: TypeDuration},
+	}
+
+	data := [][]interface{}{
+		{nil, nil, nil, nil, nil, nil, nil},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	var buf bytes.Buffer
+	Expect(table.Save(&buf)).To(BeNil())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Data).To(Equal(data))
+}
+
+func TestSaveNormalizesDateTimeCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{FieldName: "CREATED", FieldType: TypeDateTime, FieldFormat: "2006-01-02"},
+	}
+	data := [][]interface{}{
+		{time.Date(2012, 11, 29, 13, 0, 3, 0, time.UTC)},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	var buf bytes.Buffer
+	Expect(table.Save(&buf)).To(BeNil())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Data[0][0]).To(Equal("2012-11-29"))
+}
+
+func TestSaveDateTimePointers(t *testing.T) {
+	RegisterTestingT(t)
+
+	created := time.Date(2012, 11, 29, 13, 0, 3, 0, time.UTC)
+	var notSet *time.Time
+
+	schema := []SchemaField{
+		{FieldName: "ID", FieldType: TypeInt},
+		{FieldName: "CREATED", FieldType: TypeDateTime},
+	}
+	data := [][]interface{}{
+		{1, &created},
+		{2, notSet},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	var buf bytes.Buffer
+	Expect(table.Save(&buf)).To(BeNil())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Data).To(Equal([][]interface{}{
+		{1, "2012-11-29T13:00:03Z"},
+		{2, nil},
+	}))
+
+	expected, err := table.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	actual, err := loaded.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(actual).To(Equal(expected))
+}
+
+func TestSaveAndLoadTableWithCellsAndRawRows(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{FieldName: "ID", FieldType: TypeInt},
+		{FieldName: "STATUS", FieldType: TypeString},
+		{FieldName: "CREATED", FieldType: TypeDateTime},
+		{FieldName: "CORES", FieldType: TypeInt},
+	}
+
+	data := [][]interface{}{
+		{1, WithSeverity("running", SeverityOK), "2020-11-03T10:00:00Z", Cell{Value: 8, AsString: "8 cores"}},
+		NewRawRow("  maintenance from 10:00"),
+		{2, WithSeverity(nil, SeverityError), Cell{Value: nil, AsString: "never"}, Cell{Value: "N/A", AsString: "unknown"}},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	var buf bytes.Buffer
+	Expect(table.Save(&buf)).To(Succeed())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Data).To(Equal(data))
+
+	expected, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	actual, err := loaded.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(actual).To(Equal(expected))
+
+	//the time.Time value of a SeverityCell is saved like the other date time cells
+	table.Data = [][]interface{}{{3, "stopped", WithSeverity(time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC), SeverityWarning), 4}}
+	buf.Reset()
+	Expect(table.Save(&buf)).To(Succeed())
+	loaded, err = LoadTable(&buf)
+	Expect(err).To(BeNil())
+	Expect(loaded.Data[0][2]).To(Equal(WithSeverity("2020-11-03T10:00:00Z", SeverityWarning)))
+}
+
+func TestSaveAndLoadErrorTable(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := ErrorToTable(wrapError(&apiError{code: 503}, "list servers"))
+
+	var buf bytes.Buffer
+	Expect(table.Save(&buf)).To(Succeed())
+
+	loaded, err := LoadTable(&buf)
+	Expect(err).To(BeNil())
+
+	expected, err := table.RenderTable("errors", "", "")
+	Expect(err).To(BeNil())
+	actual, err := loaded.RenderTable("errors", "", "")
+	Expect(err).To(BeNil())
+	Expect(actual).To(Equal(expected))
+	Expect(actual).To(ContainSubstring("retry=true"))
+}
+
+func TestLoadTableErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := LoadTable(strings.NewReader(`{"version": 100, "schema": [], "data": []}`))
+	Expect(err).NotTo(BeNil())
+
+	_, err = LoadTable(strings.NewReader(`{"version": 1, "schema": [{"fieldName": "ID", "fieldType": "unknown"}], "data": []}`))
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("ID"))
+
+	_, err = LoadTable(strings.NewReader(`{"version": 1, "schema": [{"fieldName": "ID", "fieldType": "int"}], "data": [["test"]]}`))
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("row 0"))
+
+	//the value of a SeverityCell must hold the type of its field, unlike the value of a Cell
+	_, err = LoadTable(strings.NewReader(`{"version": 2, "schema": [{"fieldName": "ID", "fieldType": "int"}], "data": [["test"]],
+		"cells": [{"row": 0, "column": 0, "kind": "severity", "severity": "ok"}]}`))
+	Expect(err).To(MatchError("could not load cell at row 0 column ID: expected a number, got test"))
+
+	_, err = LoadTable(strings.NewReader(`{"version": 2, "schema": [{"fieldName": "ID", "fieldType": "int"}], "data": [[1]],
+		"cells": [{"row": 0, "column": 1, "kind": "cell"}]}`))
+	Expect(err).To(MatchError("could not load cell at row 0 column 1: the data has no such cell"))
+
+	_, err = LoadTable(strings.NewReader(`{"version": 2, "schema": [{"fieldName": "ID", "fieldType": "int"}], "data": [[1]],
+		"cells": [{"row": 0, "column": 0, "kind": "unknown"}]}`))
+	Expect(err).To(MatchError("could not load cell at row 0 column 0: unknown kind unknown"))
+}
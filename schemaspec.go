@@ -0,0 +1,112 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ParseSchema builds a schema from a compact spec made of comma separated fields, each written as
+//NAME:type[:size[:precision or format]], such as "ID:int:6, LABEL:string:20, INST.:float:6:2, CREATED:datetime::2006-01-02".
+//The types are int, string, float, datetime, interface, bool and duration. The last part is the FieldPrecision
+//of float fields and the FieldFormat of the other fields, it can contain colons.
+func ParseSchema(spec string) ([]SchemaField, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("empty schema spec")
+	}
+
+	schema := []SchemaField{}
+	for _, segment := range strings.Split(spec, ",") {
+		field, err := parseFieldSpec(strings.TrimSpace(segment))
+		if err != nil {
+			return nil, fmt.Errorf("invalid field spec %q: %s", strings.TrimSpace(segment), err)
+		}
+		schema = append(schema, field)
+	}
+
+	return schema, nil
+}
+
+//MustSchema is like ParseSchema but panics if the spec is invalid
+func MustSchema(spec string) []SchemaField {
+	schema, err := ParseSchema(spec)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+//parseFieldSpec parses a single NAME:type[:size[:precision or format]] field of a schema spec
+func parseFieldSpec(segment string) (SchemaField, error) {
+	parts := strings.SplitN(segment, ":", 4)
+	if len(parts) < 2 {
+		return SchemaField{}, fmt.Errorf("expected NAME:type")
+	}
+
+	field := SchemaField{FieldName: strings.TrimSpace(parts[0])}
+	if field.FieldName == "" {
+		return SchemaField{}, fmt.Errorf("empty field name")
+	}
+
+	fieldType, err := getFieldTypeByName(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return SchemaField{}, err
+	}
+	field.FieldType = fieldType
+
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		size, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil || size < 0 {
+			return SchemaField{}, fmt.Errorf("invalid size %s", parts[2])
+		}
+		field.FieldSize = size
+	}
+
+	if len(parts) > 3 {
+		if fieldType != TypeFloat {
+			field.FieldFormat = parts[3]
+			return field, nil
+		}
+		precision, err := strconv.Atoi(strings.TrimSpace(parts[3]))
+		if err != nil || precision < 0 {
+			return SchemaField{}, fmt.Errorf("invalid precision %s", parts[3])
+		}
+		field.FieldPrecision = precision
+	}
+
+	return field, nil
+}
+
+//InferSchema builds a schema for data with the given field names, using the type of the first non nil cell
+//of each column: int, float64 (with 2 decimals), string, bool, time.Duration and time.Time cells have their types,
+//other columns are interface fields.
+func InferSchema(data [][]interface{}, fieldNames ...string) []SchemaField {
+	schema := make([]SchemaField, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		schema[i] = SchemaField{FieldName: fieldName, FieldType: TypeInterface}
+
+		for _, row := range withoutRawRows(data) {
+			if i >= len(row) || row[i] == nil {
+				continue
+			}
+			switch row[i].(type) {
+			case int:
+				schema[i].FieldType = TypeInt
+			case float64:
+				schema[i].FieldType = TypeFloat
+				schema[i].FieldPrecision = 2
+			case string:
+				schema[i].FieldType = TypeString
+			case bool:
+				schema[i].FieldType = TypeBool
+			case time.Duration:
+				schema[i].FieldType = TypeDuration
+			case time.Time:
+				schema[i].FieldType = TypeDateTime
+			}
+			break
+		}
+	}
+	return schema
+}
@@ -0,0 +1,63 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema, err := ParseSchema("ID:int:6, LABEL:string:20, INST.:float:6:2, CREATED:datetime::2006-01-02 15:04, ACTIVE:bool")
+	Expect(err).To(BeNil())
+	Expect(schema).To(Equal([]SchemaField{
+		{FieldName: "ID", FieldType: TypeInt, FieldSize: 6},
+		{FieldName: "LABEL", FieldType: TypeString, FieldSize: 20},
+		{FieldName: "INST.", FieldType: TypeFloat, FieldSize: 6, FieldPrecision: 2},
+		{FieldName: "CREATED", FieldType: TypeDateTime, FieldFormat: "2006-01-02 15:04"},
+		{FieldName: "ACTIVE", FieldType: TypeBool},
+	}))
+
+	for spec, segment := range map[string]string{
+		"ID:int, LABEL":            `"LABEL"`,
+		"ID:integer":               `"ID:integer"`,
+		"ID:int:six":               `"ID:int:six"`,
+		"ID:int, COST:float:6:two": `"COST:float:6:two"`,
+		":int":                     `":int"`,
+	} {
+		_, err := ParseSchema(spec)
+		Expect(err).NotTo(BeNil(), spec)
+		Expect(err.Error()).To(ContainSubstring(segment), spec)
+	}
+
+	_, err = ParseSchema(" ")
+	Expect(err).NotTo(BeNil())
+
+	Expect(func() { MustSchema("ID") }).To(Panic())
+}
+
+func TestInferSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, nil, 1.5, true, time.Minute, time.Now(), []int{1}},
+		{2, "label", 2.5, false, time.Hour, time.Now(), nil},
+	}
+
+	schema := InferSchema(data, "ID", "LABEL", "COST", "ACTIVE", "UPTIME", "CREATED", "TAGS")
+	Expect(schema).To(Equal([]SchemaField{
+		{FieldName: "ID", FieldType: TypeInt},
+		{FieldName: "LABEL", FieldType: TypeString},
+		{FieldName: "COST", FieldType: TypeFloat, FieldPrecision: 2},
+		{FieldName: "ACTIVE", FieldType: TypeBool},
+		{FieldName: "UPTIME", FieldType: TypeDuration},
+		{FieldName: "CREATED", FieldType: TypeDateTime},
+		{FieldName: "TAGS", FieldType: TypeInterface},
+	}))
+
+	table := Table{Data: data, Schema: InferSchema(data, "ID", "LABEL", "COST", "ACTIVE", "UPTIME", "CREATED", "TAGS")}
+	_, err := table.RenderTable("items", "", "")
+	Expect(err).To(BeNil())
+}
@@ -0,0 +1,62 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+//SelectColumns returns a new table with only the given fields, in the given order, such as for a --columns flag.
+//Fields are looked up by FieldID or FieldName and a field given more than once is selected once.
+//Computed fields become regular fields holding the computed cells, so they can be selected without the fields they are computed from.
+//Missing cells of rows shorter than the schema are nil and raw rows are kept as they are. The table itself is not changed.
+func (t *Table) SelectColumns(names ...string) (Table, error) {
+	materialized, err := t.getMaterializedTable()
+	if err != nil {
+		return Table{}, err
+	}
+
+	var indexes []int
+	selected := map[int]bool{}
+	for _, name := range names {
+		i := getFieldIndex(materialized.Schema, name)
+		if i == -1 {
+			return Table{}, fmt.Errorf("could not find field with name %s, available fields are %s", name, strings.Join(getFieldNames(t.Schema), ", "))
+		}
+		if !selected[i] {
+			selected[i] = true
+			indexes = append(indexes, i)
+		}
+	}
+
+	schema := make([]SchemaField, len(indexes))
+	for j, i := range indexes {
+		schema[j] = materialized.Schema[i]
+	}
+
+	data := make([][]interface{}, len(materialized.Data))
+	for k, row := range materialized.Data {
+		if isRawRow(row) {
+			data[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, len(indexes))
+		for j, i := range indexes {
+			if i < len(row) {
+				newRow[j] = row[i]
+			}
+		}
+		data[k] = newRow
+	}
+
+	return Table{Data: data, Schema: schema, TimeFormat: t.TimeFormat, errorRows: t.errorRows}, nil
+}
+
+//getFieldNames returns the names of the fields of the schema, in order
+func getFieldNames(schema []SchemaField) []string {
+	names := make([]string, len(schema))
+	for i, field := range schema {
+		names[i] = field.FieldName
+	}
+	return names
+}
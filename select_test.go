@@ -0,0 +1,165 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSelectColumns(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:     "ID",
+			FieldType:     TypeInt,
+			FieldPriority: 2,
+		},
+		{
+			FieldName: "VENDOR",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "NAME",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+		{
+			FieldName: "PORTS",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:     "STATUS",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "dell", "sw-1", 48, "active"},
+		{2, nil, "sw-2", 24, "down"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = append(table.Data, NewRawRow("--- raw ---"))
+
+	selected, err := table.SelectColumns("STATUS", "ID", "NAME")
+	Expect(err).To(BeNil())
+	Expect(getFieldNames(selected.Schema)).To(Equal([]string{"STATUS", "ID", "NAME"}))
+	Expect(selected.Data).To(Equal([][]interface{}{
+		{"active", 1, "sw-1"},
+		{"down", 2, "sw-2"},
+		NewRawRow("--- raw ---"),
+	}))
+
+	//the table is not changed
+	Expect(table.Schema).To(HaveLen(5))
+	Expect(table.Data[0]).To(HaveLen(5))
+
+	//fields given twice are selected once
+	selected, err = table.SelectColumns("ID", "NAME", "ID")
+	Expect(err).To(BeNil())
+	Expect(getFieldNames(selected.Schema)).To(Equal([]string{"ID", "NAME"}))
+	Expect(selected.Data[0]).To(Equal([]interface{}{1, "sw-1"}))
+
+	_, err = table.SelectColumns("ID", "MODEL")
+	Expect(err).To(MatchError("could not find field with name MODEL, available fields are ID, VENDOR, NAME, PORTS, STATUS"))
+}
+
+func TestSelectColumnsShortRows(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:     "ID",
+			FieldType:     TypeInt,
+			FieldPriority: 2,
+		},
+		{
+			FieldName: "VENDOR",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "NAME",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+		{
+			FieldName: "PORTS",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:     "STATUS",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "dell", "sw-1", 48, "active"},
+		{2, nil, "sw-2", 24, "down"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = [][]interface{}{
+		{1, "dell"},
+	}
+
+	selected, err := table.SelectColumns("VENDOR", "STATUS")
+	Expect(err).To(BeNil())
+	Expect(selected.Data).To(Equal([][]interface{}{{"dell", nil}}))
+}
+
+func TestSelectColumnsComputed(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:     "ID",
+			FieldType:     TypeInt,
+			FieldPriority: 2,
+		},
+		{
+			FieldName: "VENDOR",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "NAME",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+		{
+			FieldName: "PORTS",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:     "STATUS",
+			FieldType:     TypeString,
+			FieldPriority: 1,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "dell", "sw-1", 48, "active"},
+		{2, nil, "sw-2", 24, "down"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Schema = append(table.Schema, SchemaField{
+		FieldName:        "LABEL",
+		FieldType:        TypeString,
+		FieldComputeFrom: []string{"VENDOR", "NAME"},
+		FieldCompute: func(values ...interface{}) interface{} {
+			return values[0].(string) + "/" + values[1].(string)
+		},
+	})
+	table.Data = table.Data[:1]
+
+	selected, err := table.SelectColumns("LABEL")
+	Expect(err).To(BeNil())
+	Expect(selected.Data).To(Equal([][]interface{}{{"dell/sw-1"}}))
+
+	s, err := selected.RenderTable("switches", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("LABEL\ndell/sw-1\n"))
+}
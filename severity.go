@@ -0,0 +1,171 @@
+package tableformatter
+
+import (
+	"strings"
+)
+
+//SeverityOK is the severity of a cell in a good state, such as a running server, see WithSeverity.
+//The cells in a failed state have SeverityError and those that need attention SeverityWarning.
+const SeverityOK Severity = "ok"
+
+//severityStyle is how the cells of a severity are rendered: with color when colors are on and prefixed by symbol
+//when they are off
+type severityStyle struct {
+	color  string
+	symbol string
+}
+
+var severityStyles = map[Severity]severityStyle{
+	SeverityOK:      {color: "\x1b[32m", symbol: "✓ "},
+	SeverityWarning: {color: "\x1b[33m", symbol: "⚠ "},
+	SeverityError:   {color: "\x1b[31m", symbol: "✗ "},
+}
+
+//severityColorReset ends the color of a cell with a severity
+const severityColorReset = "\x1b[0m"
+
+//severityFieldSuffix is appended to the name of a field to name its severity field
+const severityFieldSuffix = "_SEVERITY"
+
+//SeverityCell is a cell annotated with a Severity, see WithSeverity
+type SeverityCell struct {
+	Value    interface{}
+	Severity Severity
+}
+
+//WithSeverity annotates a cell of any field with a severity. The formats read by people color the cell by its severity,
+//red for SeverityError, yellow for SeverityWarning and green for SeverityOK, or, when RenderOptions.NoColor is set,
+//prefix it with ✗, ⚠ or ✓ so that the severity survives the plain text output. The json, yaml and csv formats
+//write value, followed by the severity in a field of its own if RenderOptions.SeverityFields is set.
+func WithSeverity(value interface{}, sev Severity) SeverityCell {
+	return SeverityCell{Value: value, Severity: sev}
+}
+
+//WithSeverityFields enables RenderOptions.SeverityFields
+func WithSeverityFields() RenderOption {
+	return func(o *RenderOptions) {
+		o.SeverityFields = true
+	}
+}
+
+//applySeverities returns a copy of data with the cells with a severity replaced by a Cell with their value,
+//formatted with their color, or their symbol if RenderOptions.NoColor is set. data is returned as is if it has none.
+//The symbol is part of AsString so it is measured only when it is rendered.
+func applySeverities(data [][]interface{}, schema []SchemaField, options *RenderOptions) [][]interface{} {
+	if !hasSeverityCells(data) {
+		return data
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		newRow := make([]interface{}, len(row))
+		for i, d := range row {
+			newRow[i] = d
+			if c, ok := d.(SeverityCell); ok && i < len(schema) {
+				newRow[i] = getSeverityCell(c, &schema[i], options)
+			}
+		}
+		newData[k] = newRow
+	}
+	return newData
+}
+
+//getSeverityCell returns the Cell rendering a cell with a severity
+func getSeverityCell(c SeverityCell, field *SchemaField, options *RenderOptions) Cell {
+	lines := getCellLines(c.Value, field)
+	style, ok := severityStyles[c.Severity]
+	if !ok {
+		return Cell{Value: c.Value, AsString: strings.Join(lines, "\n")}
+	}
+
+	for i := range lines {
+		if options.NoColor {
+			if i == 0 {
+				lines[i] = style.symbol + lines[i]
+			}
+			continue
+		}
+		lines[i] = style.color + lines[i] + severityColorReset
+	}
+	return Cell{Value: c.Value, AsString: strings.Join(lines, "\n")}
+}
+
+//hasSeverityCells returns true if a cell of data has a severity
+func hasSeverityCells(data [][]interface{}) bool {
+	for _, row := range data {
+		for _, d := range row {
+			if _, ok := d.(SeverityCell); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//withSeverityFields returns a copy of data and of the schema with a string field after each field with a cell
+//with a severity, named after it with severityFieldSuffix and holding the severities.
+//data and the schema are returned as is if no cell has a severity.
+func withSeverityFields(data [][]interface{}, schema []SchemaField) ([][]interface{}, []SchemaField) {
+	hasSeverity := make([]bool, len(schema))
+	count := 0
+	for _, row := range data {
+		for i, d := range row {
+			if _, ok := d.(SeverityCell); ok && i < len(schema) && !hasSeverity[i] {
+				hasSeverity[i] = true
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return data, schema
+	}
+
+	newSchema := make([]SchemaField, 0, len(schema)+count)
+	for i, field := range schema {
+		newSchema = append(newSchema, field)
+		if hasSeverity[i] {
+			newSchema = append(newSchema, SchemaField{
+				FieldName:   field.FieldName + severityFieldSuffix,
+				FieldType:   TypeString,
+				FieldHidden: field.FieldHidden,
+			})
+		}
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, 0, len(row)+count)
+		for i, d := range row {
+			newRow = append(newRow, d)
+			if i < len(schema) && hasSeverity[i] {
+				var severity interface{}
+				if c, ok := d.(SeverityCell); ok {
+					severity = string(c.Severity)
+				}
+				newRow = append(newRow, severity)
+			}
+		}
+		newData[k] = newRow
+	}
+	return newData, newSchema
+}
+
+//getSeverityValue returns the value of a cell with a severity and the other cells as they are
+func getSeverityValue(d interface{}) interface{} {
+	if c, ok := d.(SeverityCell); ok {
+		return c.Value
+	}
+	return d
+}
+
+//severityLess returns a less function that compares the values of the cells with a severity
+func severityLess(less lessFunc) lessFunc {
+	return func(a, b interface{}, field *SchemaField) bool {
+		return less(getSeverityValue(a), getSeverityValue(b), field)
+	}
+}
@@ -0,0 +1,145 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSeverityColors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 1,
+		},
+	}
+	data := [][]interface{}{
+		{"web-1", WithSeverity("active", SeverityOK), 0.25},
+		{"web-2", WithSeverity("failed", SeverityError), WithSeverity(0.95, SeverityWarning)},
+		{"web-3", "deploying", 0.5},
+	}
+	table := &Table{Data: data, Schema: schema}
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| web-1 | \x1b[32mactive\x1b[0m    | 0.2  |"))
+	Expect(s).To(ContainSubstring("| web-2 | \x1b[31mfailed\x1b[0m    | \x1b[33m0.9\x1b[0m  |"))
+	Expect(s).To(ContainSubstring("| web-3 | deploying | 0.5  |"))
+}
+
+func TestSeveritySymbolsWithoutColors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 1,
+		},
+	}
+	data := [][]interface{}{
+		{"web-1", WithSeverity("active", SeverityOK), 0.25},
+		{"web-2", WithSeverity("failed", SeverityError), WithSeverity(0.95, SeverityWarning)},
+		{"web-3", "deploying", 0.5},
+	}
+	table := &Table{Data: data, Schema: schema}
+	s, err := table.Render(WithoutColors())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+-------+-----------+-------+\n" +
+			"| LABEL | STATUS    | LOAD  |\n" +
+			"+-------+-----------+-------+\n" +
+			"| web-1 | ✓ active  | 0.2   |\n" +
+			"| web-2 | ✗ failed  | ⚠ 0.9 |\n" +
+			"| web-3 | deploying | 0.5   |\n" +
+			"+-------+-----------+-------+\n" +
+			"Total: 3 \n\n"))
+}
+
+func TestSeverityMachineFormats(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 1,
+		},
+	}
+	data := [][]interface{}{
+		{"web-1", WithSeverity("active", SeverityOK), 0.25},
+		{"web-2", WithSeverity("failed", SeverityError), WithSeverity(0.95, SeverityWarning)},
+		{"web-3", "deploying", 0.5},
+	}
+	table := &Table{Data: data, Schema: schema}
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("LABEL,STATUS,LOAD\nweb-1,active,0.250000\nweb-2,failed,0.950000\nweb-3,deploying,0.500000\n"))
+
+	s, err = table.Render(WithFormat("csv"), WithSeverityFields())
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("LABEL,STATUS,STATUS_SEVERITY,LOAD,LOAD_SEVERITY\n" +
+		"web-1,active,ok,0.250000,\nweb-2,failed,error,0.950000,warning\nweb-3,deploying,,0.500000,\n"))
+
+	//the severity fields are only added to the machine formats
+	s, err = table.Render(WithSeverityFields(), WithoutColors())
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("_SEVERITY"))
+}
+
+func TestSeveritySortAndDiagnose(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "LOAD",
+			FieldType:      TypeFloat,
+			FieldPrecision: 1,
+		},
+	}
+	data := [][]interface{}{
+		{"web-1", WithSeverity("active", SeverityOK), 0.25},
+		{"web-2", WithSeverity("failed", SeverityError), WithSeverity(0.95, SeverityWarning)},
+		{"web-3", "deploying", 0.5},
+	}
+	table := &Table{Data: data, Schema: schema}
+	Expect(TableSorter(table.Schema).OrderBy("LOAD:desc").SortErr(table.Data)).To(BeNil())
+	Expect(table.Data[0][0]).To(Equal("web-2"))
+	Expect(table.Data[2][0]).To(Equal("web-1"))
+
+	Expect(DiagnoseTable(*table)).To(BeEmpty())
+}
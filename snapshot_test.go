@@ -0,0 +1,147 @@
+package tableformatter
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the snapshot tests")
+
+//snapshotObject is the fixture of the raw object renders
+type snapshotObject struct {
+	ServerID      int
+	ServerLabel   string
+	ServerCost    float64
+	ServerActive  bool
+	ServerTags    []string
+	ServerOwner   *string
+	ServerCreated time.Time
+}
+
+func TestRenderSnapshots(t *testing.T) {
+	RegisterTestingT(t)
+
+	//getTable returns a fixture with every field type, nil cells, multi-line, colored and unicode strings
+	//and rows long enough to be folded
+	getTable := func() *Table {
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "LABEL",
+				FieldType: TypeString,
+			},
+			{
+				FieldName:      "COST",
+				FieldType:      TypeFloat,
+				FieldPrecision: 2,
+			},
+			{
+				FieldName: "CREATED",
+				FieldType: TypeDateTime,
+			},
+			{
+				FieldName: "ACTIVE",
+				FieldType: TypeBool,
+			},
+			{
+				FieldName:   "AGE",
+				FieldType:   TypeDuration,
+				FieldFormat: "age",
+			},
+			{
+				FieldName: "UPTIME",
+				FieldType: TypeDuration,
+			},
+			{
+				FieldName: "EXTRA",
+				FieldType: TypeInterface,
+			},
+			{
+				FieldName:    "OWNER",
+				FieldType:    TypeString,
+				FieldDefault: "-",
+			},
+		}
+
+		data := [][]interface{}{
+			{1, "production-infrastructure\nsecond line", 10.5, time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC), true, 49 * time.Hour, 90 * time.Minute, map[string]int{"cpus": 4}, "田中"},
+			{2, "\x1b[31mfailed\x1b[0m", 1.257, "2020-11-02T09:30:00Z", false, 90 * time.Second, 36 * time.Hour, []string{"a", "b"}, "Zoë"},
+			{3, "München 🚀", nil, nil, nil, nil, nil, nil, nil},
+		}
+
+		return &Table{Data: data, Schema: schema}
+	}
+
+	renders := map[string]func(t *Table) (string, error){
+		"RenderTableFoldable_folded": func(t *Table) (string, error) {
+			return t.RenderTableFoldable("servers", "Servers:", "", 60)
+		},
+		"RenderTable_narrow": func(t *Table) (string, error) {
+			return t.Render(WithTableName("servers"), WithNarrowLayout())
+		},
+		"RenderTableAsSQL": func(t *Table) (string, error) {
+			return t.RenderTableAsSQL("servers")
+		},
+		"RenderRawObject": func(t *Table) (string, error) {
+			return RenderRawObject(snapshotObject{ServerID: 1, ServerLabel: "東京", ServerTags: []string{"a"}}, "", "Server")
+		},
+		"RenderRawObject_json": func(t *Table) (string, error) {
+			return RenderRawObject(snapshotObject{ServerID: 1, ServerLabel: "東京", ServerTags: []string{"a"}}, "json", "Server")
+		},
+	}
+
+	for _, format := range getFormats() {
+		format := format
+		renders["RenderTable_"+format] = func(t *Table) (string, error) {
+			return t.RenderTable("servers", "Servers:", format, WithFoldAtLength(1000))
+		}
+	}
+
+	//the transposed renders show every field of one row, the first with all the cells and the last with nil cells
+	for _, row := range []int{0, 2} {
+		row := row
+		suffix := []string{"_full", "", "_nil"}[row]
+		renders["RenderTransposedTable"+suffix] = func(t *Table) (string, error) {
+			t.Data = t.Data[row : row+1]
+			return t.RenderTransposedTable("server", "Server:", "")
+		}
+		renders["RenderTransposedTable_json"+suffix] = func(t *Table) (string, error) {
+			t.Data = t.Data[row : row+1]
+			return t.RenderTransposedTable("server", "Server:", "json")
+		}
+		renders["RenderTransposedTableHumanReadable"+suffix] = func(t *Table) (string, error) {
+			t.Data = t.Data[row : row+1]
+			return t.RenderTransposedTableHumanReadable("server", "Server:")
+		}
+	}
+
+	names := []string{}
+	for name := range renders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s, err := renders[name](getTable())
+		Expect(err).To(BeNil(), name)
+
+		golden := filepath.Join("testdata", "snapshot", name+".golden")
+		if *updateGolden {
+			Expect(ioutil.WriteFile(golden, []byte(s), 0644)).To(Succeed())
+			continue
+		}
+
+		expected, err := ioutil.ReadFile(golden)
+		Expect(err).To(BeNil(), name)
+		Expect(s).To(Equal(string(expected)), name)
+	}
+}
@@ -0,0 +1,100 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+//OrderByIndex adds the columns at the given positions of the rows to the order, for the tables without a schema
+//or with a generic one such as those returned by TransposeTable and ConvertToStringTable. The cells are compared
+//like those of a field of their type, inferred by Sort from the first cell of the column that is not nil:
+//int, float64, string, bool, time.Time or time.Duration. The columns mixing int, int64, float64 and json.Number cells
+//are compared as numbers. The nil cells are ordered like in OrderBy.
+//The columns are added after those of the previous calls to OrderBy, OrderByFunc and OrderByIndex.
+//SortErr returns an error if a row has no cell at one of the positions or if a column holds cells of different types.
+func (ms *MultiSorter) OrderByIndex(indexes ...int) *MultiSorter {
+	for _, index := range indexes {
+		if ms.err != nil {
+			return ms
+		}
+		if index < 0 {
+			ms.err = fmt.Errorf("column index %d is out of range", index)
+			return ms
+		}
+
+		//the field and the less function are set by inferColumnFields
+		ms.indexes = append(ms.indexes, index)
+		ms.less = append(ms.less, nil)
+		ms.fields = append(ms.fields, SchemaField{})
+		ms.byIndex = append(ms.byIndex, true)
+		ms.byFunc = append(ms.byFunc, false)
+	}
+	return ms
+}
+
+//getColumnFieldType returns the type of the fields holding cells like d
+func getColumnFieldType(d interface{}) (int, bool) {
+	switch d.(type) {
+	case int, int64:
+		return TypeInt, true
+	case float64, json.Number:
+		return TypeFloat, true
+	case string:
+		return TypeString, true
+	case bool:
+		return TypeBool, true
+	case time.Time:
+		return TypeDateTime, true
+	case time.Duration:
+		return TypeDuration, true
+	}
+	return 0, false
+}
+
+//inferColumnFields sets the fields and the less functions of the columns added by OrderByIndex from the cells of data
+func (ms *MultiSorter) inferColumnFields(data [][]interface{}) error {
+	for k, index := range ms.indexes {
+		if !ms.byIndex[k] {
+			continue
+		}
+
+		//a column of nil cells is sorted as strings
+		field := SchemaField{FieldName: fmt.Sprintf("#%d", index), FieldType: TypeString}
+		var first interface{}
+		for r, row := range data {
+			if index >= len(row) {
+				return fmt.Errorf("column index %d is out of range in row %d", index, r)
+			}
+			d := row[index]
+			if d == nil {
+				continue
+			}
+
+			fieldType, ok := getColumnFieldType(d)
+			switch {
+			case !ok:
+				return fmt.Errorf("cannot sort column %d holding %T cells", index, d)
+			case first == nil:
+				first = d
+				field.FieldType = fieldType
+			case isNumberField(&SchemaField{FieldType: fieldType}) && isNumberField(&field):
+				//the int and float cells are compared as numbers
+				field.FieldType = TypeFloat
+			case fieldType != field.FieldType:
+				return fmt.Errorf("cannot sort column %d holding %T and %T cells", index, first, d)
+			}
+		}
+
+		less, err := getLessFunc(&field)
+		if err != nil {
+			return err
+		}
+		if ms.nilsFirst {
+			less = nilsFirstLess(less)
+		}
+		ms.fields[k] = field
+		ms.less[k] = less
+	}
+	return nil
+}
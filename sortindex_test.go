@@ -0,0 +1,86 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOrderByIndex(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{21, "b", 2.5, true, time.Date(2020, 11, 2, 0, 0, 0, 0, time.UTC)},
+		{11, "a", nil, false, time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{31, "b", 0.5, false, time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{41, "a", 1.5, nil, nil},
+	}
+	ids := func() []int {
+		ids := []int{}
+		for _, row := range data {
+			ids = append(ids, row[0].(int))
+		}
+		return ids
+	}
+
+	for _, order := range []struct {
+		indexes  []int
+		expected []int
+	}{
+		{[]int{0}, []int{11, 21, 31, 41}},
+		{[]int{1, 0}, []int{11, 41, 21, 31}},
+		{[]int{2}, []int{31, 41, 21, 11}},
+		{[]int{3, 0}, []int{11, 31, 21, 41}},
+		{[]int{4}, []int{31, 21, 11, 41}},
+	} {
+		Expect(TableSorter(nil).OrderByIndex(order.indexes...).SortErr(data)).To(BeNil(), "%v", order.indexes)
+		Expect(ids()).To(Equal(order.expected), "%v", order.indexes)
+	}
+
+	Expect(TableSorter(nil).OrderByIndex(2).NilsFirst().SortErr(data)).To(BeNil())
+	Expect(ids()).To(Equal([]int{11, 31, 41, 21}))
+}
+
+func TestOrderByIndexTransposed(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{11, 12, 13},
+		{21, 22, 23},
+		{31, 32, 33},
+	}
+	tableT := TransposeTable(Table{Data: data, Schema: nil})
+
+	//the transposed table has a generic schema, the columns are sorted by position
+	Expect(TableSorter(nil).OrderByIndex(1).SortErr(tableT.Data)).To(BeNil())
+	Expect(tableT.Data).To(Equal([][]interface{}{
+		{11, 21, 31},
+		{12, 22, 32},
+		{13, 23, 33},
+	}))
+
+	//the fields of the transposed schema are interface fields, which cannot be sorted
+	tableT.Data[0][0] = 99
+	Expect(TableSorter(tableT.Schema).OrderBy("1").SortErr(tableT.Data)).To(HaveOccurred())
+	Expect(TableSorter(tableT.Schema).OrderByIndex(0).SortErr(tableT.Data)).To(BeNil())
+	Expect(tableT.Data[2]).To(Equal([]interface{}{99, 21, 31}))
+}
+
+func TestOrderByIndexErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, "a"},
+		{2, 3},
+	}
+	Expect(TableSorter(nil).OrderByIndex(2).SortErr(data)).To(MatchError("column index 2 is out of range in row 0"))
+	Expect(TableSorter(nil).OrderByIndex(-1).SortErr(data)).To(MatchError("column index -1 is out of range"))
+	Expect(TableSorter(nil).OrderByIndex(1).SortErr(data)).To(MatchError("cannot sort column 1 holding string and int cells"))
+
+	data = [][]interface{}{
+		{1, []string{"a"}},
+		{2, []string{"b"}},
+	}
+	Expect(TableSorter(nil).OrderByIndex(1).SortErr(data)).To(MatchError("cannot sort column 1 holding []string cells"))
+}
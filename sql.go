@@ -0,0 +1,122 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//sqlRowsPerInsert is the number of rows of each INSERT statement written by RenderTableAsSQL
+const sqlRowsPerInsert = 100
+
+//sqlColumnTypes are the column types of the CREATE TABLE statement by field type. Other types are TEXT.
+var sqlColumnTypes = map[int]string{
+	TypeInt:      "INTEGER",
+	TypeFloat:    "REAL",
+	TypeString:   "TEXT",
+	TypeDateTime: "TEXT",
+	TypeBool:     "BOOLEAN",
+	TypeDuration: "INTEGER",
+	TypeVersion:  "TEXT",
+	TypeIP:       "TEXT",
+	TypeBytes:    "REAL",
+}
+
+//RenderTableAsSQL renders a CREATE TABLE statement for the schema followed by INSERT statements for the rows,
+//for loading the table into SQLite or PostgreSQL. Columns are named after the FieldName of the fields.
+//Date time cells are written as text with the layout of their field, durations as nanoseconds and
+//cells that do not hold the type of their field as the text they are rendered as. Raw rows are skipped.
+func (t *Table) RenderTableAsSQL(tableName string) (string, error) {
+	data, schema, err := t.getExportedData()
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkCustomCells(data, schema); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	columns := make([]string, len(schema))
+	definitions := make([]string, len(schema))
+	for i, field := range schema {
+		columns[i] = quoteSQLIdentifier(field.FieldName)
+		columnType, ok := sqlColumnTypes[field.FieldType]
+		if !ok {
+			columnType = "TEXT"
+		}
+		definitions[i] = columns[i] + " " + columnType
+	}
+
+	sb.WriteString(fmt.Sprintf("CREATE TABLE %s (%s);\n", quoteSQLIdentifier(tableName), strings.Join(definitions, ", ")))
+
+	for start := 0; start < len(data); start += sqlRowsPerInsert {
+		end := start + sqlRowsPerInsert
+		if end > len(data) {
+			end = len(data)
+		}
+
+		sb.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES\n", quoteSQLIdentifier(tableName), strings.Join(columns, ", ")))
+		for k, row := range data[start:end] {
+			values := make([]string, len(schema))
+			for i := range schema {
+				values[i] = getSQLValue(row[i], &schema[i])
+			}
+			sb.WriteString("(" + strings.Join(values, ", ") + ")")
+			if start+k < end-1 {
+				sb.WriteString(",\n")
+			}
+		}
+		sb.WriteString(";\n")
+	}
+
+	return sb.String(), nil
+}
+
+//quoteSQLIdentifier returns name as a double quoted SQL identifier
+func quoteSQLIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+//quoteSQLString returns s as a single quoted SQL string literal. New lines are kept as they are.
+func quoteSQLString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+//getSQLValue returns the SQL literal of a cell
+func getSQLValue(d interface{}, field *SchemaField) string {
+	if c, ok := d.(Cell); ok {
+		d = c.Value
+	}
+	if d == nil {
+		return "NULL"
+	}
+
+	switch v := d.(type) {
+	case int:
+		if field.FieldType == TypeInt {
+			return strconv.Itoa(v)
+		}
+	case float64:
+		if field.FieldType == TypeFloat {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case bool:
+		if field.FieldType == TypeBool {
+			return strings.ToUpper(strconv.FormatBool(v))
+		}
+	}
+	if field.FieldType == TypeDuration {
+		if duration, ok := parseDuration(d); ok {
+			return strconv.FormatInt(int64(duration), 10)
+		}
+	}
+	if field.FieldType == TypeBytes {
+		if n, ok := getNumber(d); ok {
+			return formatNumber(n)
+		}
+	}
+
+	return quoteSQLString(decolorize(strings.Join(getCellLines(d, field), "\n")))
+}
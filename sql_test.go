@@ -0,0 +1,75 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableAsSQL(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "COST",
+			FieldType: TypeFloat,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+		{
+			FieldName: "UPTIME",
+			FieldType: TypeDuration,
+		},
+		{
+			FieldName: `"QUOTED"`,
+			FieldType: TypeInterface,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "O'Brien\nsecond line", 10.5, time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC), true, time.Second, []string{"a"}},
+		NewRawRow("skipped"),
+		{2, nil, 1.0, "2020-11-04", false, time.Duration(0), nil},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTableAsSQL("servers")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		`CREATE TABLE "servers" ("ID" INTEGER, "OWNER" TEXT, "COST" REAL, "CREATED" TEXT, "ACTIVE" BOOLEAN, "UPTIME" INTEGER, """QUOTED""" TEXT);` + "\n" +
+			`INSERT INTO "servers" ("ID", "OWNER", "COST", "CREATED", "ACTIVE", "UPTIME", """QUOTED""") VALUES` + "\n" +
+			"(1, 'O''Brien\nsecond line', 10.5, '2020-11-03', TRUE, 1000000000, '[a]'),\n" +
+			"(2, NULL, 1, '2020-11-04', FALSE, 0, NULL);\n"))
+}
+
+func TestRenderTableAsSQLSplitsInserts(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{}
+	for i := 0; i < sqlRowsPerInsert+1; i++ {
+		data = append(data, []interface{}{i})
+	}
+
+	table := Table{Data: data, Schema: []SchemaField{{FieldName: "ID", FieldType: TypeInt}}}
+
+	s, err := table.RenderTableAsSQL("numbers")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("(99);\nINSERT INTO \"numbers\" (\"ID\") VALUES\n(100);\n"))
+}
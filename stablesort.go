@@ -0,0 +1,26 @@
+package tableformatter
+
+import "sort"
+
+//Stable makes Sort keep the rows that are equal on all the fields in their order, see SortStable
+func (ms *MultiSorter) Stable() *MultiSorter {
+	ms.stable = true
+	return ms
+}
+
+//SortStable sorts data like Sort but keeps the rows that are equal on all the fields in their order, so that
+//the output of the same data is the same on every run, such as for diffing. It uses sort.Stable, which makes
+//O(n*log(n)) calls to Less like sort.Sort but O(n*log(n)*log(n)) swaps instead of O(n*log(n)), so it is slower
+//on large tables.
+func (ms *MultiSorter) SortStable(data [][]interface{}) error {
+	return ms.sort(data, true)
+}
+
+//sortRows sorts the rows of ms.data with sort.Stable if stable is set and with sort.Sort otherwise
+func (ms *MultiSorter) sortRows(stable bool) {
+	if stable {
+		sort.Stable(ms)
+		return
+	}
+	sort.Sort(ms)
+}
@@ -0,0 +1,97 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//expectStableOrder checks that data is sorted by STATUS and that the IDs of each status are increasing like in the table
+func expectStableOrder(data [][]interface{}) {
+	for k := 1; k < len(data); k++ {
+		prev, row := data[k-1], data[k]
+		Expect(prev[1].(string) <= row[1].(string)).To(BeTrue())
+		if prev[1] == row[1] {
+			Expect(prev[0].(int)).To(BeNumerically("<", row[0].(int)))
+		}
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+	//getData returns rows with many equal statuses, ordered by ID
+	getData := func() [][]interface{} {
+		statuses := []string{"active", "deleted", "pending"}
+		data := [][]interface{}{}
+		for id := 1; id <= 300; id++ {
+			data = append(data, []interface{}{id, statuses[(id*7)%len(statuses)]})
+		}
+		return data
+	}
+
+	data := getData()
+	Expect(TableSorter(schema).OrderBy("STATUS").SortStable(data)).To(Succeed())
+	expectStableOrder(data)
+
+	data = getData()
+	Expect(TableSorter(schema).OrderBy("STATUS").Stable().SortErr(data)).To(Succeed())
+	expectStableOrder(data)
+
+	//the descending fields keep the order of the equal rows too
+	data = getData()
+	Expect(TableSorter(schema).Stable().OrderBy("-STATUS").SortErr(data)).To(Succeed())
+	Expect(data[0][1]).To(Equal("pending"))
+	for k := 1; k < len(data); k++ {
+		if data[k-1][1] == data[k][1] {
+			Expect(data[k-1][0].(int)).To(BeNumerically("<", data[k][0].(int)))
+		}
+	}
+}
+
+func TestSortStableWithComputedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+	statuses := []string{"active", "deleted", "pending"}
+	data := [][]interface{}{}
+	for id := 1; id <= 300; id++ {
+		data = append(data, []interface{}{id, statuses[(id*7)%len(statuses)]})
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Schema = append(table.Schema, SchemaField{
+		FieldName:        "DELETED",
+		FieldType:        TypeBool,
+		FieldComputeFrom: []string{"STATUS"},
+		FieldCompute: func(values ...interface{}) interface{} {
+			return values[0] == "deleted"
+		},
+	})
+	Expect(TableSorter(table.Schema).OrderBy("DELETED").SortStable(table.Data)).To(Succeed())
+	for k := 1; k < len(table.Data); k++ {
+		prevDeleted, deleted := table.Data[k-1][1] == "deleted", table.Data[k][1] == "deleted"
+		Expect(prevDeleted && !deleted).To(BeFalse())
+		if prevDeleted == deleted {
+			Expect(table.Data[k-1][0].(int)).To(BeNumerically("<", table.Data[k][0].(int)))
+		}
+	}
+}
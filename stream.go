@@ -0,0 +1,156 @@
+package tableformatter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+)
+
+//The write functions below render the rows one at a time so that RenderTo does not hold the whole output in memory.
+//Errors of the underlying writer are kept by the bufio.Writer and returned by its Flush.
+
+//renderToString returns a render function returning as a string what write writes
+func renderToString(write func(w *bufio.Writer, rows [][]interface{}) error) func(rows [][]interface{}) (string, error) {
+	return func(rows [][]interface{}) (string, error) {
+		var sb strings.Builder
+		w := bufio.NewWriter(&sb)
+		if err := write(w, rows); err != nil {
+			return "", err
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+	}
+}
+
+//writeTableAsString writes the text format of a table followed, if it is not nil, by the footer row
+func writeTableAsString(w *bufio.Writer, data [][]interface{}, schema []SchemaField, footer []interface{}, options *RenderOptions) error {
+	sized := data
+	if footer != nil {
+		sized = append(data[:len(data):len(data)], footer)
+	}
+	if options.NormalizeTrailingSpace {
+		schema = getNormalizedSchema(sized, schema)
+	} else {
+		//cells wider than their field would shift the delimiters of their row only
+		schema = getWidenedSchema(sized, schema, 0)
+	}
+
+	delimiter := getTableDelimiter(schema, options)
+
+	writeLine := func(line string) {
+		w.WriteString(line)
+		w.WriteString("\n")
+	}
+
+	writeLine(delimiter)
+	writeLine(getTableHeader(schema, options))
+	writeLine(delimiter)
+	rowIndex := 0
+	for _, row := range data {
+		if isRawRow(row) {
+			writeLine(string(row[0].(RawRow)))
+			continue
+		}
+		color := ""
+		if options.RowColor != nil && !options.NoColor {
+			color = options.RowColor(rowIndex, row)
+		}
+		writeLine(getColoredTableRow(row, schema, color, options))
+		rowIndex++
+	}
+	writeLine(delimiter)
+	if footer != nil {
+		writeLine(getTableRow(footer, schema, options))
+		writeLine(delimiter)
+	}
+
+	return nil
+}
+
+//writeJSONArray writes n values as a json array indented like json.MarshalIndent with a tab
+func writeJSONArray(w *bufio.Writer, n int, value func(k int) interface{}) error {
+	if n == 0 {
+		w.WriteString("[]")
+		return nil
+	}
+
+	w.WriteString("[\n\t")
+	for k := 0; k < n; k++ {
+		if k > 0 {
+			w.WriteString(",\n\t")
+		}
+		b, err := json.MarshalIndent(value(k), "\t", "\t")
+		if err != nil {
+			return err
+		}
+		w.Write(b)
+	}
+	w.WriteString("\n]")
+
+	return nil
+}
+
+//writeTableAsJSON writes the json format of a table
+func writeTableAsJSON(w *bufio.Writer, data [][]interface{}, schema []SchemaField, options *RenderOptions) error {
+	return writeJSONArray(w, len(data), func(k int) interface{} {
+		return withoutNilKeys(getRowAsJSONMap(data[k], schema), options)
+	})
+}
+
+//writeTableAsOrderedJSON writes the json-ordered format of a table
+func writeTableAsOrderedJSON(w *bufio.Writer, data [][]interface{}, schema []SchemaField, options *RenderOptions) error {
+	return writeJSONArray(w, len(data), func(k int) interface{} {
+		return orderedJSONRow{data[k], schema, options.OmitNilKeys}
+	})
+}
+
+//writeTableAsCSV writes the csv format of a table
+func writeTableAsCSV(w *bufio.Writer, data [][]interface{}, schema []SchemaField, options *CSVOptions) error {
+	csvWriter := csv.NewWriter(w)
+
+	writeRecord := func(record []string) {
+		csvWriter.Write(record)
+	}
+	if options.Strict4180 {
+		//csv.Writer with UseCRLF would also turn the new lines of the cells into CRLF,
+		//so each record is written with LF and its terminator replaced
+		var record bytes.Buffer
+		recordWriter := csv.NewWriter(&record)
+		first := true
+		writeRecord = func(fields []string) {
+			record.Reset()
+			recordWriter.Write(fields)
+			recordWriter.Flush()
+			if !first {
+				w.WriteString("\r\n")
+			}
+			first = false
+			w.Write(bytes.TrimSuffix(record.Bytes(), []byte("\n")))
+		}
+	}
+
+	rowStr := make([]string, len(schema))
+	for i, field := range schema {
+		rowStr[i] = field.FieldName
+	}
+
+	writeRecord(rowStr)
+
+	for _, row := range data {
+		for i, field := range schema {
+			rowStr[i] = getCSVCell(row[i], &field)
+			if options.EscapeFormulas && !isNumericCell(row[i], &field) {
+				rowStr[i] = escapeFormula(rowStr[i], options.FormulaEscapePrefix)
+			}
+		}
+		writeRecord(rowStr)
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
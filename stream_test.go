@@ -0,0 +1,167 @@
+package tableformatter
+
+import (
+	"bytes"
+	"io/ioutil"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTo(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	for _, format := range []string{"", "json", "json-ordered", "csv", "yaml", "md", "html", "aligned", "html-pre"} {
+		expected, err := table.RenderTable("servers", "top", format)
+		Expect(err).To(BeNil())
+
+		var buf bytes.Buffer
+		Expect(table.RenderTableTo(&buf, "servers", "top", format)).To(Succeed())
+		Expect(buf.String()).To(Equal(expected), format)
+	}
+
+	//the transposed table and the error rows are rendered in memory
+	errorTable := Table{Data: withoutRawRows(data), Schema: schema}
+	errorTable.AddErrorRow("page 2 failed")
+	for _, opts := range [][]RenderOption{{WithTransposed()}, {WithFormat("json")}, {}} {
+		expected, err := errorTable.Render(opts...)
+		Expect(err).To(BeNil())
+
+		var buf bytes.Buffer
+		Expect(errorTable.RenderTo(&buf, opts...)).To(Succeed())
+		Expect(buf.String()).To(Equal(expected))
+	}
+
+	//the partial output is written together with the error
+	full, err := table.Render()
+	Expect(err).To(BeNil())
+	expected, err := table.Render(WithMaxOutputBytes(len(full) - 1))
+	Expect(err).To(BeAssignableToTypeOf(&ErrOutputTruncated{}))
+	var buf bytes.Buffer
+	Expect(table.RenderTo(&buf, WithMaxOutputBytes(len(full)-1))).To(Equal(err))
+	Expect(buf.String()).To(Equal(expected))
+}
+
+func TestRenderToWriteError(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{"\x1b[32mweb-1\x1b[0m", 10.5, "2020-11-03"},
+		NewRawRow("--- raw ---"),
+		{nil, 1.257, "2020-11-04"},
+		{"db-1", 3.0, "2020-11-05"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	for _, format := range []string{"", "json", "csv", "yaml"} {
+		Expect(table.RenderTo(failingWriter{}, WithFormat(format))).To(MatchError("disk full"), format)
+	}
+}
+
+//liveHeapWriter discards what is written and records the largest live heap seen while writing,
+//measured after a garbage collection every liveHeapSampleInterval writes
+type liveHeapWriter struct {
+	writes int
+	peak   uint64
+}
+
+const liveHeapSampleInterval = 32
+
+func (w *liveHeapWriter) Write(p []byte) (int, error) {
+	if w.writes%liveHeapSampleInterval == 0 {
+		w.sample()
+	}
+	w.writes++
+	return ioutil.Discard.Write(p)
+}
+
+func (w *liveHeapWriter) sample() {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	if stats.HeapAlloc > w.peak {
+		w.peak = stats.HeapAlloc
+	}
+}
+
+func TestRenderToMemory(t *testing.T) {
+	RegisterTestingT(t)
+
+	if testing.Short() {
+		t.Skip("renders 100k rows")
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+	table := GenerateSampleTable(schema, 100000, 1)
+
+	for _, format := range []string{"", "json", "csv"} {
+		runtime.GC()
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		w := &liveHeapWriter{}
+		Expect(table.RenderTableTo(w, "servers", "", format)).To(Succeed())
+		w.sample()
+
+		//the output is several megabytes, what is held while writing is the write buffer and a few rows
+		Expect(int64(w.peak)-int64(stats.HeapAlloc)).To(BeNumerically("<", 16*1024), format)
+	}
+}
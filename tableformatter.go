@@ -3,15 +3,15 @@ package tableformatter
 import (
 	"bufio"
 	"bytes"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
-	"time"
-
 	"github.com/iancoleman/strcase"
 	"gopkg.in/yaml.v2"
 )
@@ -20,11 +20,486 @@ import (
 type Table struct {
 	Data   [][]interface{}
 	Schema []SchemaField
+	//RowSources are the objects the rows are extracted from by the FieldExtract of the fields when the table is rendered,
+	//one row per object, instead of Data. Only the fields that are rendered are extracted.
+	RowSources []interface{}
+	//TimeFormat is the layout of the date time fields that do not set a FieldFormat. DefaultTimeFormat is used if empty.
+	TimeFormat string
+	//ExtraCells is what happens to the cells of the rows of Data after the last field, an error by default
+	ExtraCells ExtraCells
+
+	//errorRows are the messages recorded by AddErrorRow
+	errorRows []string
 }
 
 const defaultDelimiter = "|"
 const defaultTimeFormat = "2006-01-02T15:04:05Z" //oddly enough this is how you specify a format
 const defaultFoldAtLength = 100
+const defaultGroupSeparator = "‖"
+
+//DefaultTimeFormat is the layout of the date time fields of tables that set neither a FieldFormat nor a TimeFormat
+var DefaultTimeFormat = defaultTimeFormat
+
+//machineReadableFormats are the formats in which raw rows cannot be rendered
+var machineReadableFormats = map[string]bool{
+	"json":         true,
+	"json-ordered": true,
+	"csv":          true,
+	"yaml":         true,
+	"yaml-docs":    true,
+	"md":           true,
+	"html":         true,
+}
+
+//RawRow is a line that the text renderer prints verbatim between the rows of a table.
+//A row whose first cell is a RawRow is a raw row, the rest of its cells are ignored.
+//The caller is responsible for the width of the line. Raw rows are skipped by the
+//machine readable formats, are not counted in the total and are not supported by the sorter.
+type RawRow string
+
+//NewRawRow returns a raw row that can be appended to the data of a table
+func NewRawRow(line string) []interface{} {
+	return []interface{}{RawRow(line)}
+}
+
+//isRawRow returns true if the first cell of the row is a RawRow
+func isRawRow(row []interface{}) bool {
+	if len(row) == 0 {
+		return false
+	}
+	_, ok := row[0].(RawRow)
+	return ok
+}
+
+//withoutRawRows returns the rows that are not raw rows, data itself if there are none
+func withoutRawRows(data [][]interface{}) [][]interface{} {
+	hasRawRows := false
+	for _, row := range data {
+		if isRawRow(row) {
+			hasRawRows = true
+			break
+		}
+	}
+	if !hasRawRows {
+		return data
+	}
+
+	rows := make([][]interface{}, 0, len(data))
+	for _, row := range data {
+		if !isRawRow(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+//RenderOptions holds the optional settings of a render call
+type RenderOptions struct {
+	//Format is the format rendered by Render, see RenderTableFoldable for the supported formats. Empty means text.
+	Format string
+	//TableName is the name of the rows used in the Total line of the text format
+	TableName string
+	//TopLine is written before the table by the text format
+	TopLine string
+	//FoldAtLength is the row length above which the text format folds the table. It is 100 unless set with WithFoldAtLength.
+	FoldAtLength int
+	//Transposed makes Render render the text format as a key-value table, like RenderTransposedTable
+	Transposed bool
+	//NormalizeTrailingSpace leaves at least one space between the widest line of each cell and the next delimiter
+	//so that all the lines of the table have the same length even if AdjustFieldSizes was not called
+	NormalizeTrailingSpace bool
+	//StrictRawRows makes rendering a table with raw rows in a machine readable format an error instead of skipping them
+	StrictRawRows bool
+	//AutoStripPrefix makes RenderRawObject detect the prefix to strip from the field names when none is given
+	AutoStripPrefix bool
+	//WrapWidth is the width at which the human readable transposed output is wrapped. 0 disables wrapping.
+	WrapWidth int
+	//KeyValueSeparator separates the keys from the values in the human readable transposed output, ": " if empty
+	KeyValueSeparator string
+	//AlignKeys pads the keys of the human readable transposed output to the width of the widest key
+	AlignKeys bool
+	//MaxOutputBytes limits the size of the output of RenderTable, headers included. The rows that do not fit are dropped
+	//and the partial output is returned together with an ErrOutputTruncated, see its documentation. 0 means no limit.
+	MaxOutputBytes int
+	//ValueMasks replace the cells of the named fields in the text, aligned, transposed and csv formats,
+	//in the order in which they were added. The data of the table is not changed.
+	ValueMasks map[string][]ValueMask
+	//GroupSeparator is drawn by the text format instead of | between adjacent fields with different FieldGroup.
+	//Empty means the groups are not separated.
+	GroupSeparator string
+	//EmptyAsNil renders empty string cells like nil cells in all the formats
+	EmptyAsNil bool
+	//CollapseRunsField is the field annotated with the length of a run of identical rows collapsed by the text formats.
+	//Empty means runs are not collapsed. The machine readable formats render all the rows.
+	CollapseRunsField string
+	//CollapseRunsThreshold is the length above which runs of identical rows are collapsed
+	CollapseRunsThreshold int
+	//NarrowLayout makes the text format render each row as a block of "key: value" lines under the value of the first field.
+	//It is also used if WrapWidth is set below 60 columns.
+	NarrowLayout bool
+	//HTML are the CSS classes used by the html format
+	HTML *HTMLOptions
+	//CSV are the settings of the csv format, the defaults of CSVOptions if nil
+	CSV *CSVOptions
+	//FieldIDKeys makes the json formats use the FieldID of the fields as keys instead of their FieldName
+	FieldIDKeys bool
+	//OmitNilKeys makes the json and yaml formats leave out the keys of nil cells instead of writing null
+	OmitNilKeys bool
+	//DurationsInSeconds makes the json and yaml formats write the cells of the duration fields as their number of seconds
+	DurationsInSeconds bool
+	//ColumnSeparator follows each cell rendered by RenderColumn, a new line if empty. Use "\x00" for xargs -0.
+	ColumnSeparator string
+	//ColumnHeader makes RenderColumn start with the name of the field
+	ColumnHeader bool
+	//SkipNil makes RenderColumn leave out the nil cells
+	SkipNil bool
+	//TrailerFunc returns the line written after the table by the text format for count rows.
+	//If nil the line is "Total: count name" where name is the table name or the noun set with WithTableNoun.
+	TrailerFunc TrailerFunc
+	//TableNounSingular and TableNounPlural replace the table name in the default trailer depending on the count
+	TableNounSingular string
+	TableNounPlural   string
+	//SanitizeUTF8 replaces the invalid UTF-8 sequences of the string cells with U+FFFD in every format. It is set by default.
+	SanitizeUTF8 bool
+	//MaxColumns is the number of columns shown by the formats read by people, 0 means all of them.
+	//ColumnOverflow decides what happens to the fields left out, see FieldPriority for which fields are kept.
+	MaxColumns     int
+	ColumnOverflow ColumnOverflow
+	//NoColor removes the ANSI escape sequences of the string cells, raw rows and field names
+	//and renders the error rows without color. The layout is the same as with colors.
+	NoColor bool
+	//Metadata makes the json formats render {"table": name, "total": rows of the table, "shown": rows rendered, "rows": [...]},
+	//yaml the same mapping and csv append a "# total: " comment line. Fewer rows are shown than the total if the output
+	//is truncated by MaxOutputBytes. The error rows are rendered in the same document.
+	Metadata bool
+	//StrictKeys makes rendering an error, ErrKeyCollision, if fields produce the same key in the json or yaml formats
+	//or in the folded text format, instead of suffixing the keys of the later fields with 2, 3 and so on
+	StrictKeys bool
+	//FoldKeyColumns are the names or FieldIDs of the fields that the folded text format keeps as columns
+	//left of the Values column, which then holds the other fields only
+	FoldKeyColumns []string
+	//AggregateRow makes the formats other than text render the footer row of the FieldAggregate of the fields
+	//as a last row. The text format always renders it below the rows.
+	AggregateRow bool
+	//RowNumbers makes the table formats render a first column named # with the number of each row, starting at 1
+	//in the order of Data. Raw rows are not numbered.
+	RowNumbers bool
+	//RowColor colors the cells of each row of the text format, such as ZebraStripe. It is not used with NoColor.
+	RowColor RowColorFunc
+	//WideMode renders the fields with FieldWideOnly in every format
+	WideMode bool
+	//MaxFieldPrecision and MaxFieldSize clamp the FieldPrecision and FieldSize of the fields when rendering,
+	//DefaultMaxFieldPrecision and DefaultMaxFieldSize if 0
+	MaxFieldPrecision int
+	MaxFieldSize      int
+	//PageProgress is called by BuildTablePaged and RenderPaged after each page is fetched
+	PageProgress PageProgressFunc
+
+	//SeverityFields makes the json, yaml and csv formats write the severity of the cells annotated with WithSeverity
+	//in a string field after their field, named after it with a _SEVERITY suffix
+	SeverityFields bool
+
+	//PreRender are applied in order to a copy of the table before it is rendered, each to the table returned by
+	//the previous one, such as UppercaseHeaders. An error aborts the render and names the hook.
+	PreRender []PreRenderHook
+	//PostRender are applied in order to the output, such as StripColorsHook. An error aborts the render and names the hook.
+	PostRender []PostRenderHook
+}
+
+//TrailerFunc returns the line written after a table with count rows named tableName
+type TrailerFunc func(count int, tableName string) string
+
+//ValueMask returns the value rendered instead of a cell
+type ValueMask func(value interface{}) interface{}
+
+//RenderOption changes one of the RenderOptions
+type RenderOption func(*RenderOptions)
+
+//WithFormat sets RenderOptions.Format
+func WithFormat(format string) RenderOption {
+	return func(o *RenderOptions) {
+		o.Format = format
+	}
+}
+
+//WithTableName sets RenderOptions.TableName
+func WithTableName(tableName string) RenderOption {
+	return func(o *RenderOptions) {
+		o.TableName = tableName
+	}
+}
+
+//WithTopLine sets RenderOptions.TopLine
+func WithTopLine(topLine string) RenderOption {
+	return func(o *RenderOptions) {
+		o.TopLine = topLine
+	}
+}
+
+//WithFoldAtLength sets RenderOptions.FoldAtLength
+func WithFoldAtLength(foldAtLength int) RenderOption {
+	return func(o *RenderOptions) {
+		o.FoldAtLength = foldAtLength
+	}
+}
+
+//WithTransposed enables RenderOptions.Transposed
+func WithTransposed() RenderOption {
+	return func(o *RenderOptions) {
+		o.Transposed = true
+	}
+}
+
+//WithNormalizedTrailingSpace enables RenderOptions.NormalizeTrailingSpace
+func WithNormalizedTrailingSpace() RenderOption {
+	return func(o *RenderOptions) {
+		o.NormalizeTrailingSpace = true
+	}
+}
+
+//WithStrictRawRows enables RenderOptions.StrictRawRows
+func WithStrictRawRows() RenderOption {
+	return func(o *RenderOptions) {
+		o.StrictRawRows = true
+	}
+}
+
+//WithAutoStripPrefix enables RenderOptions.AutoStripPrefix
+func WithAutoStripPrefix() RenderOption {
+	return func(o *RenderOptions) {
+		o.AutoStripPrefix = true
+	}
+}
+
+//WithWrapWidth sets RenderOptions.WrapWidth
+func WithWrapWidth(width int) RenderOption {
+	return func(o *RenderOptions) {
+		o.WrapWidth = width
+	}
+}
+
+//WithKeyValueSeparator sets RenderOptions.KeyValueSeparator
+func WithKeyValueSeparator(separator string) RenderOption {
+	return func(o *RenderOptions) {
+		o.KeyValueSeparator = separator
+	}
+}
+
+//WithAlignedKeys enables RenderOptions.AlignKeys
+func WithAlignedKeys() RenderOption {
+	return func(o *RenderOptions) {
+		o.AlignKeys = true
+	}
+}
+
+//WithMaxOutputBytes sets RenderOptions.MaxOutputBytes
+func WithMaxOutputBytes(maxBytes int) RenderOption {
+	return func(o *RenderOptions) {
+		o.MaxOutputBytes = maxBytes
+	}
+}
+
+//WithValueMask adds a mask to RenderOptions.ValueMasks for the field with the given name
+func WithValueMask(fieldName string, mask ValueMask) RenderOption {
+	return func(o *RenderOptions) {
+		if o.ValueMasks == nil {
+			o.ValueMasks = map[string][]ValueMask{}
+		}
+		o.ValueMasks[fieldName] = append(o.ValueMasks[fieldName], mask)
+	}
+}
+
+//WithGroupSeparator sets RenderOptions.GroupSeparator, to defaultGroupSeparator if separator is empty
+func WithGroupSeparator(separator string) RenderOption {
+	return func(o *RenderOptions) {
+		if separator == "" {
+			separator = defaultGroupSeparator
+		}
+		o.GroupSeparator = separator
+	}
+}
+
+//WithEmptyAsNil enables RenderOptions.EmptyAsNil
+func WithEmptyAsNil() RenderOption {
+	return func(o *RenderOptions) {
+		o.EmptyAsNil = true
+	}
+}
+
+//WithCollapsedRuns makes the text formats render the runs of more than threshold consecutive identical rows
+//as their first row with the cell of fieldName annotated with the length of the run, such as "ok (×137)"
+func WithCollapsedRuns(fieldName string, threshold int) RenderOption {
+	return func(o *RenderOptions) {
+		o.CollapseRunsField = fieldName
+		o.CollapseRunsThreshold = threshold
+	}
+}
+
+//WithNarrowLayout enables RenderOptions.NarrowLayout
+func WithNarrowLayout() RenderOption {
+	return func(o *RenderOptions) {
+		o.NarrowLayout = true
+	}
+}
+
+//WithHTMLOptions sets RenderOptions.HTML
+func WithHTMLOptions(options HTMLOptions) RenderOption {
+	return func(o *RenderOptions) {
+		o.HTML = &options
+	}
+}
+
+//WithCSVOptions sets RenderOptions.CSV
+func WithCSVOptions(options CSVOptions) RenderOption {
+	return func(o *RenderOptions) {
+		o.CSV = &options
+	}
+}
+
+//WithFieldIDKeys enables RenderOptions.FieldIDKeys
+func WithFieldIDKeys() RenderOption {
+	return func(o *RenderOptions) {
+		o.FieldIDKeys = true
+	}
+}
+
+//WithOmittedNilKeys enables RenderOptions.OmitNilKeys
+func WithOmittedNilKeys() RenderOption {
+	return func(o *RenderOptions) {
+		o.OmitNilKeys = true
+	}
+}
+
+//WithDurationsInSeconds enables RenderOptions.DurationsInSeconds
+func WithDurationsInSeconds() RenderOption {
+	return func(o *RenderOptions) {
+		o.DurationsInSeconds = true
+	}
+}
+
+//WithColumnSeparator sets RenderOptions.ColumnSeparator
+func WithColumnSeparator(separator string) RenderOption {
+	return func(o *RenderOptions) {
+		o.ColumnSeparator = separator
+	}
+}
+
+//WithColumnHeader enables RenderOptions.ColumnHeader
+func WithColumnHeader() RenderOption {
+	return func(o *RenderOptions) {
+		o.ColumnHeader = true
+	}
+}
+
+//WithSkippedNil enables RenderOptions.SkipNil
+func WithSkippedNil() RenderOption {
+	return func(o *RenderOptions) {
+		o.SkipNil = true
+	}
+}
+
+//WithTrailerFunc sets RenderOptions.TrailerFunc
+func WithTrailerFunc(trailerFunc TrailerFunc) RenderOption {
+	return func(o *RenderOptions) {
+		o.TrailerFunc = trailerFunc
+	}
+}
+
+//WithTableNoun sets the nouns used by the default trailer instead of the table name, such as "employee" and "employees"
+func WithTableNoun(singular string, plural string) RenderOption {
+	return func(o *RenderOptions) {
+		o.TableNounSingular = singular
+		o.TableNounPlural = plural
+	}
+}
+
+//WithUTF8Sanitization sets RenderOptions.SanitizeUTF8
+func WithUTF8Sanitization(sanitize bool) RenderOption {
+	return func(o *RenderOptions) {
+		o.SanitizeUTF8 = sanitize
+	}
+}
+
+//WithMaxColumns sets RenderOptions.MaxColumns and RenderOptions.ColumnOverflow
+func WithMaxColumns(maxColumns int, overflow ColumnOverflow) RenderOption {
+	return func(o *RenderOptions) {
+		o.MaxColumns = maxColumns
+		o.ColumnOverflow = overflow
+	}
+}
+
+//WithoutColors sets RenderOptions.NoColor
+func WithoutColors() RenderOption {
+	return func(o *RenderOptions) {
+		o.NoColor = true
+	}
+}
+
+//WithMetadata sets RenderOptions.Metadata
+func WithMetadata() RenderOption {
+	return func(o *RenderOptions) {
+		o.Metadata = true
+	}
+}
+
+//WithStrictKeys enables RenderOptions.StrictKeys
+func WithStrictKeys() RenderOption {
+	return func(o *RenderOptions) {
+		o.StrictKeys = true
+	}
+}
+
+//WithFoldKeyColumns sets RenderOptions.FoldKeyColumns
+func WithFoldKeyColumns(fieldNames ...string) RenderOption {
+	return func(o *RenderOptions) {
+		o.FoldKeyColumns = fieldNames
+	}
+}
+
+//WithAggregateRow enables RenderOptions.AggregateRow
+func WithAggregateRow() RenderOption {
+	return func(o *RenderOptions) {
+		o.AggregateRow = true
+	}
+}
+
+//WithRowNumbers enables RenderOptions.RowNumbers
+func WithRowNumbers() RenderOption {
+	return func(o *RenderOptions) {
+		o.RowNumbers = true
+	}
+}
+
+//WithRowColorFunc sets RenderOptions.RowColor
+func WithRowColorFunc(rowColor RowColorFunc) RenderOption {
+	return func(o *RenderOptions) {
+		o.RowColor = rowColor
+	}
+}
+
+//WithWideMode enables RenderOptions.WideMode
+func WithWideMode() RenderOption {
+	return func(o *RenderOptions) {
+		o.WideMode = true
+	}
+}
+
+//WithFieldLimits sets RenderOptions.MaxFieldPrecision and RenderOptions.MaxFieldSize
+func WithFieldLimits(maxPrecision int, maxSize int) RenderOption {
+	return func(o *RenderOptions) {
+		o.MaxFieldPrecision = maxPrecision
+		o.MaxFieldSize = maxSize
+	}
+}
+
+//newRenderOptions returns the default options with opts applied in order
+func newRenderOptions(opts ...RenderOption) *RenderOptions {
+	options := &RenderOptions{FoldAtLength: defaultFoldAtLength, SanitizeUTF8: true}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
 
 const (
 	//TypeInt is printed as %d
@@ -33,14 +508,50 @@ const (
 	TypeString = iota
 	//TypeFloat is printed as %f
 	TypeFloat = iota
-	//TypeDateTime is printed as a string after parsing
+	//TypeDateTime is printed as a string after parsing. FieldFormat can hold several layouts separated by |, the first is used for printing
+	//unless the field has a FieldOutputFormat
 	TypeDateTime = iota
 	//TypeInterface is printed as %v
 	TypeInterface = iota
 	//TypeBool is printed as %v
 	TypeBool = iota
+	//TypeDuration holds time.Duration values, numbers of seconds or duration strings printed as Duration.String() or,
+	//according to the FieldFormat, as DurationFormatAge or DurationFormatHuman. The json and yaml formats write the seconds
+	//if RenderOptions.DurationsInSeconds is set.
+	TypeDuration = iota
+	//TypeVersion holds version strings such as 1.10.2 or 2.0.0-rc1, printed as they are and sorted like semantic versions
+	TypeVersion = iota
+	//TypeIP holds IPv4 and IPv6 addresses, optionally with a /prefix, printed as they are and sorted numerically
+	TypeIP = iota
+	//TypeBytes holds numbers of bytes printed by the text formats as sizes such as 1.5 GiB, or 1.5 GB if FieldFormat is "si",
+	//with FieldPrecision decimals. The csv, json and yaml formats write the numbers and sorting compares them.
+	TypeBytes = iota
 )
 
+//fieldTypeNames holds the names used for the field types when a schema is saved
+var fieldTypeNames = map[int]string{
+	TypeInt:       "int",
+	TypeString:    "string",
+	TypeFloat:     "float",
+	TypeDateTime:  "datetime",
+	TypeInterface: "interface",
+	TypeBool:      "bool",
+	TypeDuration:  "duration",
+	TypeVersion:   "version",
+	TypeIP:        "ip",
+	TypeBytes:     "bytes",
+}
+
+//getFieldTypeByName returns the field type with the given name
+func getFieldTypeByName(name string) (int, error) {
+	for fieldType, n := range fieldTypeNames {
+		if n == name {
+			return fieldType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown field type %s", name)
+}
+
 //SchemaField defines a field in a table
 type SchemaField struct {
 	FieldName      string
@@ -48,6 +559,62 @@ type SchemaField struct {
 	FieldSize      int
 	FieldPrecision int
 	FieldFormat    string
+	//FieldOutputFormat is the layout of the date time cells in the text formats and csv, the first layout of FieldFormat
+	//if empty. The cells are parsed with the layouts of FieldFormat and those that cannot be parsed are rendered as they are.
+	//The json and yaml formats keep the first layout of FieldFormat.
+	FieldOutputFormat string
+	//FieldIgnoreRegisteredLayouts parses the date time cells of the field with the layouts of FieldFormat only,
+	//without the layouts added with RegisterTimeLayout
+	FieldIgnoreRegisteredLayouts bool
+	//FieldDescription is a human readable description of the column. It is not used when rendering the table
+	FieldDescription string
+	//FieldNotSortable makes OrderBy refuse the field, for example because it holds colored strings
+	FieldNotSortable bool
+	//FieldSortKey is the position of the field in the order used by SortBySchema: 1 is the primary key,
+	//2 the secondary and so on. Negative keys sort descending. Fields with 0 are not used.
+	FieldSortKey int
+	//FieldSortDescendingByDefault sorts the field descending when no direction is given, such as newest first
+	//for a CREATED field: by OrderBy for the names without a + or - prefix and by SortBySchema for a positive FieldSortKey
+	FieldSortDescendingByDefault bool
+	//FieldCompute computes the cell of the field at render time from the cells of the FieldComputeFrom fields of the same row.
+	//The rows of Data do not hold cells for computed fields. A panic of FieldCompute is returned as an error by the renders.
+	FieldCompute func(values ...interface{}) interface{}
+	//FieldComputeFrom are the names of the fields passed to FieldCompute, in order
+	FieldComputeFrom []string
+	//FieldGroup is the name of the logical group of the field, such as network or status.
+	//With RenderOptions.GroupSeparator the text format separates adjacent fields of different groups.
+	FieldGroup string
+	//FieldDefault is shown instead of nil cells by the text formats and csv. The json and yaml formats write null.
+	FieldDefault string
+	//FieldID is the stable identifier of the field used to look it up, such as in OrderBy, while FieldName is only displayed.
+	//FieldName is used if empty. With RenderOptions.FieldIDKeys it is also the key of the json formats.
+	FieldID string
+	//FieldPriority decides which fields are shown when RenderOptions.MaxColumns leaves out some of them:
+	//fields with a higher priority are kept first, fields with the same priority in the order of the schema.
+	FieldPriority int
+	//FieldAlignment is the alignment of the cells and of the header of the field in the text, aligned and markdown formats
+	FieldAlignment Alignment
+	//FieldHidden leaves the field out of every format while its cells stay in Data, so that the table can still be sorted by it
+	FieldHidden bool
+	//FieldWrapAt wraps the lines of the cells wider than FieldWrapAt characters in the text and aligned formats,
+	//breaking them on spaces where possible, instead of widening the column. 0 disables wrapping.
+	FieldWrapAt int
+	//FieldAggregate is the value computed over the cells of TypeInt and TypeFloat fields shown in a footer row
+	//at the bottom of the text format, see RenderOptions.AggregateRow for the other formats
+	FieldAggregate Aggregate
+	//FieldExtract returns the cell of the field for one of the RowSources of the table.
+	//If it panics the cell shows the error, see ValidateExtractors.
+	FieldExtract func(source interface{}) interface{}
+	//FieldWideOnly leaves the field out of the formats read by people unless RenderOptions.WideMode is set,
+	//like the wide output of kubectl. The json, yaml and csv formats always render it.
+	FieldWideOnly bool
+	//FieldNormalizeUnits parses the string cells holding a number and a unit, such as "1024 MB", "2 GHz" or "10Gbps",
+	//into a UnitValue that sorts numerically and is rendered as "1.0 GB". See RegisterUnitParser for other units.
+	//The cells that cannot be parsed are left unchanged and reported by DiagnoseTable.
+	FieldNormalizeUnits bool
+	//FieldSortNatural sorts the string cells in natural order: the runs of digits compare as numbers, so node2 sorts
+	//before node10, and the other characters compare ignoring case
+	FieldSortNatural bool
 }
 
 type lessFunc func(p1, p2 interface{}, field *SchemaField) bool
@@ -58,12 +625,126 @@ type MultiSorter struct {
 	less    []lessFunc
 	schema  []SchemaField
 	indexes []int
+	//fields are the fields sorted by less, the fields of the schema or those inferred for OrderByIndex
+	fields []SchemaField
+	//byIndex is set for the columns added by OrderByIndex
+	byIndex []bool
+	//byFunc is set for the fields added by OrderByFunc, whose cells are compared by the function of the caller
+	byFunc   []bool
+	err      error
+	warnings []string
+	//nilsFirst is set by NilsFirst
+	nilsFirst bool
+	//stable is set by Stable
+	stable bool
 }
 
 // Sort sorts the argument slice according to the less functions passed to OrderedBy.
+// The rows that are equal on all the fields may be reordered unless Stable was called, see SortStable.
+// Data is not sorted if OrderBy failed or if cells cannot be compared, see SortErr for the error.
 func (ms *MultiSorter) Sort(data [][]interface{}) {
+	ms.SortErr(data)
+}
+
+//SortErr is like Sort but returns the error encountered by OrderBy, if any, or the first cell that cannot be compared,
+//without sorting
+func (ms *MultiSorter) SortErr(data [][]interface{}) error {
+	return ms.sort(data, ms.stable)
+}
+
+//sort sorts data with sort.Stable if stable is set and with sort.Sort otherwise
+func (ms *MultiSorter) sort(data [][]interface{}, stable bool) error {
+	ms.warnings = nil
+	if ms.err != nil {
+		return ms.err
+	}
+	if len(ms.less) == 0 {
+		return nil
+	}
+	if err := ms.inferColumnFields(data); err != nil {
+		return err
+	}
+
+	if !hasComputedFields(ms.schema) {
+		ms.data = data
+		if err := ms.checkNumberCells(); err != nil {
+			return err
+		}
+		if err := ms.checkStringAndBoolCells(); err != nil {
+			return err
+		}
+		ms.checkDateTimeCells()
+		ms.sortRows(stable)
+		return nil
+	}
+
+	//sort the rows with the computed cells and keep the position of the original row in an extra cell
+	computedData, err := computeColumns(data, ms.schema)
+	if err != nil {
+		return err
+	}
+	ms.data = make([][]interface{}, len(data))
+	for k, row := range computedData {
+		ms.data[k] = append(row[:len(row):len(row)], k)
+	}
+	if err := ms.checkNumberCells(); err != nil {
+		return err
+	}
+	if err := ms.checkStringAndBoolCells(); err != nil {
+		return err
+	}
+	ms.checkDateTimeCells()
+	ms.sortRows(stable)
+
+	sortedData := make([][]interface{}, len(data))
+	for k, row := range ms.data {
+		sortedData[k] = data[row[len(row)-1].(int)]
+	}
+	copy(data, sortedData)
 	ms.data = data
-	sort.Sort(ms)
+	return nil
+}
+
+//Warnings returns the problems found by the last call to Sort that did not prevent sorting,
+//such as date time cells that could not be parsed with any layout and were sorted last
+func (ms *MultiSorter) Warnings() []string {
+	return ms.warnings
+}
+
+//checkStringAndBoolCells returns an error for the first cell of the string and bool fields sorted by their type
+//that does not hold the type of its field. The nil cells and the Cell values, which sort last, are not checked.
+func (ms *MultiSorter) checkStringAndBoolCells() error {
+	for k, index := range ms.indexes {
+		field := &ms.fields[k]
+		if ms.byFunc[k] || (field.FieldType != TypeString && field.FieldType != TypeBool) {
+			continue
+		}
+		for r, row := range ms.data {
+			d := getSeverityValue(row[index])
+			if d != nil && !hasCellType(d, field) {
+				return fmt.Errorf("row %d: cannot sort %v (%T) in %s field %s", r, d, d, fieldTypeNames[field.FieldType], field.FieldName)
+			}
+		}
+	}
+	return nil
+}
+
+//checkDateTimeCells adds a warning for each cell of the date time fields used for sorting that cannot be parsed
+func (ms *MultiSorter) checkDateTimeCells() {
+	for i, index := range ms.indexes {
+		field := &ms.fields[i]
+		if field.FieldType != TypeDateTime {
+			continue
+		}
+		for k, row := range ms.data {
+			if _, ok := row[index].(Cell); row[index] == nil || ok || isNilTime(row[index]) {
+				continue
+			}
+			if _, ok := parseDateTimeCell(row[index], field); !ok {
+				ms.warnings = append(ms.warnings, fmt.Sprintf("row %d: could not parse %v as a date time in field %s", k, row[index], field.FieldName))
+			}
+		}
+	}
 }
 
 // Len is part of sort.Interface.
@@ -90,10 +771,10 @@ func (ms *MultiSorter) Less(i, j int) bool {
 		less := ms.less[k]
 		index := ms.indexes[k]
 		switch {
-		case less(p[index], q[index], &ms.schema[index]):
+		case less(p[index], q[index], &ms.fields[k]):
 			// p < q, so we have a decision.
 			return true
-		case less(q[index], p[index], &ms.schema[index]):
+		case less(q[index], p[index], &ms.fields[k]):
 			// p > q, so we have a decision.
 			return false
 		}
@@ -102,7 +783,7 @@ func (ms *MultiSorter) Less(i, j int) bool {
 	// All comparisons to here said "equal", so just return whatever
 	// the final comparison reports.
 	lastIndex := ms.indexes[k]
-	return ms.less[k](p[lastIndex], q[lastIndex], &ms.schema[lastIndex])
+	return ms.less[k](p[lastIndex], q[lastIndex], &ms.fields[k])
 }
 
 //TableSorter a multisorter for a table
@@ -112,161 +793,269 @@ func TableSorter(schema []SchemaField) *MultiSorter {
 	}
 }
 
-//OrderBy specifies the order
+//OrderBy specifies the order. A field name prefixed with + or suffixed with :asc sorts ascending and one prefixed
+//with - or suffixed with :desc descending, such as OrderBy("LABEL", "-ID") or OrderBy("ID:desc").
+//Without a direction the field sorts descending only if it has FieldSortDescendingByDefault.
+//The nil cells sort after all the others in both directions, unless NilsFirst is called.
+//A field whose name holds a direction marker, such as -ID, is found by its full name first.
+//The fields are added after those of the previous calls to OrderBy and OrderByFunc.
+//If one of the fields cannot be found or is not sortable the error is returned by SortErr.
 func (ms *MultiSorter) OrderBy(fieldNames ...string) *MultiSorter {
+	for _, fn := range fieldNames {
+		ms.addSortField(fn, false, func(field *SchemaField) (lessFunc, error) {
+			return getLessFunc(field)
+		})
+	}
+	return ms
+}
 
-	ms.less = make([]lessFunc, len(fieldNames))
-	ms.indexes = make([]int, len(fieldNames))
+//OrderByFunc adds a field sorted with a comparison function, for orders that do not follow from the type of the field,
+//such as a status ranked by severity. less receives the cells of the field and reports whether a sorts before b.
+//The field name can hold a direction like in OrderBy and the nil cells are ordered like in OrderBy without being passed
+//to less. The field is added after those of the previous calls to OrderBy and OrderByFunc.
+//If the field cannot be found the error is returned by SortErr.
+func (ms *MultiSorter) OrderByFunc(fieldName string, less func(a, b interface{}) bool) *MultiSorter {
+	return ms.addSortField(fieldName, true, func(field *SchemaField) (lessFunc, error) {
+		return severityLess(nilLess(func(a, b interface{}, field *SchemaField) bool {
+			return less(a, b)
+		})), nil
+	})
+}
 
-	for k, fn := range fieldNames {
-		var field *SchemaField
-		for i, f := range ms.schema {
+//addSortField adds a field to the order with the less function returned by getLess, unless a previous field failed.
+//byFunc is set for the fields added by OrderByFunc.
+func (ms *MultiSorter) addSortField(fieldName string, byFunc bool, getLess func(field *SchemaField) (lessFunc, error)) *MultiSorter {
+	if ms.err != nil {
+		return ms
+	}
 
-			if f.FieldName == fn {
-				field = &f
-				ms.indexes[k] = i
-				break
-			}
-		}
+	index, descending := getSortField(ms.schema, fieldName)
+	if index == -1 {
+		ms.err = fmt.Errorf("could not find field with name %s", fieldName)
+		return ms
+	}
+
+	less, err := getLess(&ms.schema[index])
+	if err != nil {
+		ms.err = err
+		return ms
+	}
+	if descending {
+		less = reversed(less)
+	}
+	if ms.nilsFirst {
+		less = nilsFirstLess(less)
+	}
 
-		if field == nil {
-			fmt.Printf("could not find field with name %s\n", fn)
-			return nil
+	ms.appendSortField(index, less)
+	ms.byFunc[len(ms.byFunc)-1] = byFunc
+	return ms
+}
+
+//appendSortField adds the field of the schema at index to the order with the less function
+func (ms *MultiSorter) appendSortField(index int, less lessFunc) {
+	ms.indexes = append(ms.indexes, index)
+	ms.less = append(ms.less, less)
+	ms.fields = append(ms.fields, ms.schema[index])
+	ms.byIndex = append(ms.byIndex, false)
+	ms.byFunc = append(ms.byFunc, false)
+}
+
+//getSortField returns the index of the field named by a field name passed to OrderBy, -1 if there is none,
+//and whether the field sorts descending
+func getSortField(schema []SchemaField, fieldName string) (int, bool) {
+	if index := getFieldIndex(schema, fieldName); index != -1 {
+		return index, schema[index].FieldSortDescendingByDefault
+	}
+	if strings.HasPrefix(fieldName, "+") || strings.HasPrefix(fieldName, "-") {
+		return getFieldIndex(schema, fieldName[1:]), fieldName[0] == '-'
+	}
+	if i := strings.LastIndex(fieldName, ":"); i != -1 {
+		switch strings.ToLower(fieldName[i+1:]) {
+		case "asc":
+			return getFieldIndex(schema, fieldName[:i]), false
+		case "desc":
+			return getFieldIndex(schema, fieldName[:i]), true
 		}
+	}
+	return -1, false
+}
 
-		switch field.FieldType {
-		case TypeInt:
-			ms.less[k] = func(a, b interface{}, field *SchemaField) bool {
-				return a.(int) < b.(int)
-			}
-		case TypeString:
-			ms.less[k] = func(a, b interface{}, field *SchemaField) bool {
-				return a.(string) < b.(string)
-			}
-		case TypeFloat:
-			ms.less[k] = func(a, b interface{}, field *SchemaField) bool {
-				return a.(float64) < b.(float64)
-			}
-		case TypeDateTime:
-			ms.less[k] = func(a, b interface{}, field *SchemaField) bool {
+//getLessFunc returns the comparison function used to sort the cells of a field
+func getLessFunc(field *SchemaField) (lessFunc, error) {
+	if field.FieldNotSortable {
+		return nil, fmt.Errorf("field %s is not sortable", field.FieldName)
+	}
 
-				layout := defaultTimeFormat
+	handler, ok := fieldTypeHandlers[field.FieldType]
+	if !ok || field.FieldType == TypeInterface {
+		return nil, fmt.Errorf("could not find a comparison function for type %d of field %s", field.FieldType, field.FieldName)
+	}
 
-				if field.FieldFormat != "" {
-					layout = field.FieldFormat
-				}
+	less := lessFunc(handler.Less)
+	if field.FieldSortNatural {
+		less = naturalLess(less)
+	}
+	if field.FieldNormalizeUnits {
+		less = unitLess(less)
+	}
+	return severityLess(nilLess(cellLess(less))), nil
+}
 
-				ta, err := time.Parse(layout, a.(string))
-				if err != nil {
-					fmt.Printf("could not convert string %s to date time", a.(string))
-					return false
-				}
+//reversed returns a less function that orders descending. The cells that sort last, see sortsLast,
+//still sort last and in the same order.
+func reversed(less lessFunc) lessFunc {
+	return func(a, b interface{}, field *SchemaField) bool {
+		if sortsLast(a, field) || sortsLast(b, field) {
+			return less(a, b, field)
+		}
+		return less(b, a, field)
+	}
+}
 
-				tb, err := time.Parse(layout, b.(string))
-				if err != nil {
-					fmt.Printf("could not convert string %s to date time", b.(string))
-					return false
-				}
+//sortsLast returns true for the cells that the less functions order after all the others whatever the direction:
+//nil cells, Cell values, date time, version and IP address cells that cannot be parsed, NaN float cells and, in fields with
+//FieldNormalizeUnits, the string cells that are not values with a unit
+func sortsLast(d interface{}, field *SchemaField) bool {
+	d = getSeverityValue(d)
+	if d == nil {
+		return true
+	}
+	if _, ok := d.(Cell); ok {
+		return true
+	}
+	if s, ok := d.(string); ok && field.FieldNormalizeUnits {
+		_, ok := parseUnitValue(s)
+		return !ok
+	}
+	switch field.FieldType {
+	case TypeDateTime:
+		_, ok := parseDateTimeCell(d, field)
+		return !ok
+	case TypeDuration:
+		_, ok := parseDuration(d)
+		return !ok
+	case TypeVersion:
+		_, ok := parseVersion(getInterfaceAsString(d))
+		return !ok
+	case TypeIP:
+		_, ok := parseIPAddress(getInterfaceAsString(d))
+		return !ok
+	case TypeInt, TypeFloat, TypeBytes:
+		n, ok := getNumber(d)
+		return ok && !n.isInt && math.IsNaN(n.f)
+	}
+	return false
+}
 
-				return ta.Before(tb)
-			}
-		case TypeBool:
-			ms.less[k] = func(a, b interface{}, field *SchemaField) bool {
-				return a.(bool) != b.(bool)
-			}
-		default:
-			fmt.Printf("could not find type %d", field.FieldType)
+//SortBySchema sorts the data using the fields with a FieldSortKey, in the order of their keys.
+//Fields with a negative FieldSortKey or with FieldSortDescendingByDefault are sorted descending.
+func (t *Table) SortBySchema() error {
+	var indexes []int
+	for i, field := range t.Schema {
+		if field.FieldSortKey != 0 {
+			indexes = append(indexes, i)
 		}
 	}
 
-	return ms
+	abs := func(n int) int {
+		if n < 0 {
+			return -n
+		}
+		return n
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return abs(t.Schema[indexes[i]].FieldSortKey) < abs(t.Schema[indexes[j]].FieldSortKey)
+	})
+
+	schema := t.getResolvedSchema()
+	ms := TableSorter(schema)
+	for _, index := range indexes {
+		less, err := getLessFunc(&schema[index])
+		if err != nil {
+			return err
+		}
+		if schema[index].FieldSortKey < 0 || schema[index].FieldSortDescendingByDefault {
+			less = reversed(less)
+		}
+		ms.appendSortField(index, less)
+	}
+
+	return ms.SortErr(t.Data)
+}
+
+//getTimeLayout returns the layout used to format the date time cells of a field, the first of its layouts.
+//The TimeFormat of the table is resolved into the FieldFormat by getResolvedSchema.
+func getTimeLayout(field *SchemaField) string {
+	return getTimeLayouts(field)[0]
+}
+
+//getOutputTimeLayout returns the layout of the date time cells of a field in the text formats and csv,
+//its FieldOutputFormat or its first layout
+func getOutputTimeLayout(field *SchemaField) string {
+	if field.FieldOutputFormat != "" {
+		return field.FieldOutputFormat
+	}
+	return getTimeLayout(field)
 }
 
 //getTableHeader returns the row for header (all cells strings but of the length specified in the schema)
-func getTableHeader(schema []SchemaField) string {
+func getTableHeader(schema []SchemaField, options *RenderOptions) string {
 	var alteredSchema []SchemaField
 	var header []interface{}
 
 	for _, field := range schema {
 		alteredSchema = append(alteredSchema, SchemaField{
-			FieldType: TypeString,
-			FieldSize: field.FieldSize,
+			FieldType:      TypeString,
+			FieldSize:      field.FieldSize,
+			FieldGroup:     field.FieldGroup,
+			FieldAlignment: field.FieldAlignment,
 		})
 		header = append(header, field.FieldName)
 	}
-	return getTableRow(header, alteredSchema)
+	return getTableRow(header, alteredSchema, options)
 }
 
 func emptyString(length int) string {
-	var sb strings.Builder
-	for i := 0; i < length; i++ {
-		sb.WriteString(" ")
+	//the render functions return an ErrPaddingTooLarge for wider fields, this only bounds the allocation
+	if length > maxPadding {
+		length = maxPadding
 	}
-	return sb.String()
+	if length <= 0 {
+		return ""
+	}
+	return strings.Repeat(" ", length)
 }
 
 //getTableRow returns the string for a row with the | delimiter
-func getTableRow(row []interface{}, schema []SchemaField) string {
-	//row[0] is the first cell row[1] second cell row[1][1] is the value of the second row of the second cell
-	//this is to allow multi-line string cells
-	var rowStr [][]string
-	rowHeight := 1
-
-	for i, field := range schema {
-		switch field.FieldType {
-		case TypeInt:
-			rowStr = append(rowStr, []string{fmt.Sprintf(fmt.Sprintf(" %%-%dd", field.FieldSize), row[i].(int))})
-		case TypeString:
-			//escape %
-			s := strings.ReplaceAll(row[i].(string), "%", "%%")
-			splittedS := strings.Split(s, "\n")
-			multiLineCell := []string{}
-			for _, r := range splittedS {
-				multiLineCell = append(multiLineCell, fmt.Sprintf(fmt.Sprintf(" %%-%ds", field.FieldSize), r))
-			}
-			if rowHeight < len(multiLineCell) {
-				rowHeight = len(multiLineCell)
-			}
-			rowStr = append(rowStr, multiLineCell)
-
-		case TypeFloat:
-			rowStr = append(rowStr, []string{fmt.Sprintf(fmt.Sprintf(" %%-%d.%df", field.FieldSize, field.FieldPrecision), row[i].(float64))})
-		default:
-			rowStr = append(rowStr, []string{fmt.Sprintf(fmt.Sprintf(" %%-+%dv", field.FieldSize), row[i])})
-		}
-	}
+func getTableRow(row []interface{}, schema []SchemaField, options *RenderOptions) string {
+	return getColoredTableRow(row, schema, "", options)
+}
 
-	//for each cell fill it to rowHeight with empty strings of length equal to the largest
-	for i, cell := range rowStr {
-		//determine the max width
-		maxWidth := 0
-		for _, s := range cell {
-			if len(s) > maxWidth {
-				maxWidth = len(s)
-			}
-		}
-		newCell := []string{}
-		//adjust sizes to all other fields by padding them with spaces
-		for j := 0; j < len(cell); j++ {
-			newCell = append(newCell, fmt.Sprintf(fmt.Sprintf("%%-%ds", maxWidth), cell[j]))
-		}
-		//fill remainder with empty strings
-		for j := len(cell); j < rowHeight; j++ {
-			newCell = append(newCell, emptyString(maxWidth))
-		}
-		//replace original cell
-		rowStr[i] = newCell
+//getColoredTableRow is like getTableRow but starts each cell with the color and ends it with a reset if the color is not empty
+func getColoredTableRow(row []interface{}, schema []SchemaField, color string, options *RenderOptions) string {
+	cells := formatCells(row, schema, options)
+	rowHeight := 1
+	if len(cells) > 0 {
+		rowHeight = len(cells[0])
 	}
 
 	var sb strings.Builder
+	delimiters := getColumnDelimiters(schema, options)
 
 	for y := 0; y < rowHeight; y++ {
 
-		for x := 0; x < len(rowStr); x++ {
-			sb.WriteString(defaultDelimiter)
-			sb.WriteString(rowStr[x][y])
+		for x := 0; x < len(cells); x++ {
+			sb.WriteString(delimiters[x])
+			sb.WriteString(color)
+			sb.WriteString(" ")
+			sb.WriteString(cells[x][y])
+			if color != "" {
+				sb.WriteString(rowColorReset)
+			}
 		}
-		sb.WriteString(defaultDelimiter)
+		sb.WriteString(delimiters[len(cells)])
 		if y < rowHeight-1 {
 			sb.WriteString("\n")
 		}
@@ -275,29 +1064,87 @@ func getTableRow(row []interface{}, schema []SchemaField) string {
 	return sb.String()
 }
 
-// GetCellSize calculates how wide a cell is by converting it to string and measuring it's size
-func getCellSize(d interface{}, field *SchemaField) int {
-	var s string
-	switch field.FieldType {
-	case TypeInt:
-		s = fmt.Sprintf("%d", d.(int))
-	case TypeString:
-		s = d.(string)
-	case TypeFloat:
-		s = fmt.Sprintf(fmt.Sprintf("%%.%df", field.FieldPrecision), d.(float64))
-	default:
-		s = fmt.Sprintf("%v", d)
+//getCellLines returns the lines of a cell formatted by the handler of the type of its field.
+//Cells that do not hold the type of their field, such as masked cells, are formatted like interface cells.
+func getCellLines(d interface{}, field *SchemaField) []string {
+	if d == nil {
+		return strings.Split(field.FieldDefault, "\n")
+	}
+	if c, ok := d.(Cell); ok {
+		return strings.Split(c.AsString, "\n")
+	}
+	if c, ok := d.(SeverityCell); ok {
+		return getCellLines(c.Value, field)
+	}
+	return strings.Split(formatCell(d, field), "\n")
+}
 
+//formatCell returns the cell formatted by the handler of the type of its field. If the handler fails or panics,
+//such as on a type assertion of a cell that does not hold the type of its field, the cell is formatted with %v.
+func formatCell(d interface{}, field *SchemaField) string {
+	s, err := tryFormat(getFieldTypeHandler(field), d, field)
+	if err != nil {
+		return getInterfaceAsString(d)
 	}
-	//if multi-line measure the widest string in array
-	splittedS := strings.Split(s, "\n")
-	maxW := 0
-	for _, w := range splittedS {
-		if maxW < len(w) {
-			maxW = len(w)
+	return s
+}
+
+//tryFormat returns the cell formatted by the handler, or an error if the handler fails or panics
+func tryFormat(handler FieldTypeHandler, d interface{}, field *SchemaField) (s string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
 		}
+	}()
+	return handler.Format(d, field)
+}
+
+//getWrappedCellLines returns the lines of a cell like getCellLines, wrapped at the FieldWrapAt of the field
+func getWrappedCellLines(d interface{}, field *SchemaField) []string {
+	lines := getCellLines(d, field)
+	if field.FieldWrapAt < 1 {
+		return lines
 	}
-	return maxW
+	return WrapToWidth(strings.Join(lines, "\n"), field.FieldWrapAt)
+}
+
+//getBoolAsString returns true or false for bool cells. Other values are formatted like interface cells
+func getBoolAsString(d interface{}) string {
+	if b, ok := d.(bool); ok {
+		return strconv.FormatBool(b)
+	}
+	return getInterfaceAsString(d)
+}
+
+//getDurationAsString formats the cells holding a duration, see parseDuration, according to the FieldFormat:
+//Duration.String() by default, DurationFormatAge or DurationFormatHuman. Other values are formatted like interface cells.
+func getDurationAsString(d interface{}, field *SchemaField) string {
+	duration, ok := parseDuration(d)
+	if !ok {
+		return getInterfaceAsString(d)
+	}
+	switch field.FieldFormat {
+	case DurationFormatAge:
+		return formatAge(duration)
+	case DurationFormatHuman:
+		return formatHumanDuration(duration)
+	default:
+		return duration.String()
+	}
+}
+
+//getInterfaceAsString formats a cell with %v. nil cells are empty
+func getInterfaceAsString(d interface{}) string {
+	if d == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", d)
+}
+
+//getCellSize returns the visible width of the widest line of the cell as rendered by getTableRow. Every cell is
+//formatted to its lines first so that colors never count in the width, whatever the type of the cell and of its field.
+func getCellSize(d interface{}, field *SchemaField) int {
+	return measureLines(strings.Join(getWrappedCellLines(d, field), "\n"))
 }
 
 //getRowSize returns the row size of a table
@@ -311,270 +1158,826 @@ func getRowSize(data [][]interface{}, schema []SchemaField) int {
 	return size
 }
 
-//AdjustFieldSizes expands field sizes to match the widest cell
+//AdjustFieldSizes expands field sizes to match the widest cell.
+//If the computed fields cannot be computed the sizes are adjusted to the other cells, see AdjustFieldSizesErr.
 func (t *Table) AdjustFieldSizes() {
+	t.AdjustFieldSizesErr()
+}
+
+//AdjustFieldSizesErr is like AdjustFieldSizes but returns the error of the computed fields that cannot be computed
+func (t *Table) AdjustFieldSizesErr() error {
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		data = make([][]interface{}, len(t.getData()))
+		for k, row := range t.getData() {
+			data[k] = row
+			if !isRawRow(row) {
+				data[k] = withComputedCells(row, t.Schema)
+			}
+		}
+	}
+	t.adjustFieldSizes(data, t.getResolvedSchema())
+	return err
+}
 
-	rowSize := len(t.Schema)
+//adjustFieldSizes expands the field sizes of the resolved schema and of the table to match the widest cell of data
+func (t *Table) adjustFieldSizes(data [][]interface{}, schema []SchemaField) {
+	adjustFieldSizes(data, schema)
+	for i := range schema {
+		t.Schema[i].FieldSize = schema[i].FieldSize
+	}
+}
+
+//adjustFieldSizes expands field sizes to match the widest cell of data, which holds the computed cells too
+func adjustFieldSizes(data [][]interface{}, schema []SchemaField) {
+
+	rowSize := len(schema)
 	for i := 0; i < rowSize; i++ {
-		f := t.Schema[i]
+		f := schema[i]
+		if f.FieldHidden {
+			continue
+		}
 
 		//iterate over the entire column
-		rowCount := len(t.Data)
+		rowCount := len(data)
 
 		maxLen := f.FieldSize
 
-		if len(f.FieldName) > maxLen {
-			maxLen = len(f.FieldName)
+		if VisibleWidth(f.FieldName) > maxLen {
+			maxLen = VisibleWidth(f.FieldName)
 		}
 
 		for k := 0; k < rowCount; k++ {
-			cellSize := getCellSize(t.Data[k][i], &f)
+			if isRawRow(data[k]) {
+				continue
+			}
+			cellSize := getCellSize(data[k][i], &f)
 			if cellSize > maxLen {
 				maxLen = cellSize
 			}
 		}
 		if maxLen > f.FieldSize {
-			t.Schema[i].FieldSize = maxLen + 1 //we leave a little room to the right
+			schema[i].FieldSize = maxLen + 1 //we leave a little room to the right
 		}
 	}
 }
 
 //getTableDelimiter returns a delimiter row for the schema
-func getTableDelimiter(schema []SchemaField) string {
-	row := "+"
-	for _, field := range schema {
-		for i := 0; i < field.FieldSize+1; i++ {
+func getTableDelimiter(schema []SchemaField, options *RenderOptions) string {
+	delimiters := getColumnDelimiters(schema, options)
+	row := strings.Repeat("+", VisibleWidth(delimiters[0]))
+	for i, field := range schema {
+		for j := 0; j < field.FieldSize+1; j++ {
 			row += "-"
 		}
-		row += "+"
+		row += strings.Repeat("+", VisibleWidth(delimiters[i+1]))
 	}
 	return row
 }
 
+//getColumnDelimiters returns the delimiters drawn before each column of the schema and after the last one
+func getColumnDelimiters(schema []SchemaField, options *RenderOptions) []string {
+	delimiters := make([]string, len(schema)+1)
+	for i := range delimiters {
+		delimiters[i] = defaultDelimiter
+		if options.GroupSeparator != "" && i > 0 && i < len(schema) && schema[i-1].FieldGroup != schema[i].FieldGroup {
+			delimiters[i] = options.GroupSeparator
+		}
+	}
+	return delimiters
+}
+
 //getTableAsString returns the string representation of a table.
-func getTableAsString(data [][]interface{}, schema []SchemaField) string {
-	var rows []string
+func getTableAsString(data [][]interface{}, schema []SchemaField, options *RenderOptions) string {
+	s, _ := renderToString(func(w *bufio.Writer, rows [][]interface{}) error {
+		return writeTableAsString(w, rows, schema, nil, options)
+	})(data)
+	return s
+}
 
-	rows = append(rows, getTableDelimiter(schema))
-	rows = append(rows, getTableHeader(schema))
-	rows = append(rows, getTableDelimiter(schema))
-	for _, row := range data {
-		rows = append(rows, getTableRow(row, schema))
+//getNormalizedSchema returns a copy of the schema with the field sizes large enough
+//to leave at least one space after the widest cell of each column, including the header
+func getNormalizedSchema(data [][]interface{}, schema []SchemaField) []SchemaField {
+	return getWidenedSchema(data, schema, 1)
+}
+
+//getWidenedSchema returns a copy of the schema with the field sizes large enough to hold
+//the widest cell of each column, including the header, followed by margin spaces
+func getWidenedSchema(data [][]interface{}, schema []SchemaField, margin int) []SchemaField {
+	newSchema := make([]SchemaField, len(schema))
+	copy(newSchema, schema)
+
+	for i := range newSchema {
+		f := newSchema[i]
+
+		maxLen := VisibleWidth(f.FieldName)
+		for _, row := range withoutRawRows(data) {
+			cellSize := getCellSize(row[i], &f)
+			if cellSize > maxLen {
+				maxLen = cellSize
+			}
+		}
+		fieldMargin := margin
+		if f.FieldAlignment != AlignLeft && fieldMargin == 0 {
+			//the space before the next column, see formatCells
+			fieldMargin = 1
+		}
+		if maxLen+fieldMargin > f.FieldSize {
+			newSchema[i].FieldSize = maxLen + fieldMargin
+		}
 	}
-	rows = append(rows, getTableDelimiter(schema))
 
-	return strings.Join(rows, "\n") + "\n"
+	return newSchema
 }
 
-//getFoldedTableAsString returns the string representation of a table with the fields collapsed
-func getFoldedTableAsString(data [][]interface{}, schema []SchemaField) (string, error) {
+//getFoldedTableAsString returns the string representation of a table with the fields collapsed into a yaml Values column.
+//The RenderOptions.FoldKeyColumns stay columns of their own left of it.
+func getFoldedTableAsString(data [][]interface{}, schema []SchemaField, options *RenderOptions) (string, error) {
 
-	newSchema := []SchemaField{
-		{
-			FieldName: "Values",
-			FieldType: TypeString,
-			FieldSize: 5,
-		},
+	isKey := make([]bool, len(schema))
+	var keyIndexes []int
+	for _, fieldName := range options.FoldKeyColumns {
+		i := getFieldIndex(schema, fieldName)
+		if i == -1 {
+			return "", fmt.Errorf("could not find field with name %s", fieldName)
+		}
+		if !isKey[i] {
+			isKey[i] = true
+			keyIndexes = append(keyIndexes, i)
+		}
+	}
+
+	var newSchema, valueSchema []SchemaField
+	for _, i := range keyIndexes {
+		newSchema = append(newSchema, schema[i])
 	}
+	//the cells are formatted before they are marshaled, see getFoldedCell
+	stringField := SchemaField{FieldType: TypeString}
+	newSchema = append(newSchema, SchemaField{
+		FieldName: "Values",
+		FieldType: TypeString,
+		FieldSize: 5,
+	})
+	for i := range schema {
+		if !isKey[i] {
+			stringField.FieldName = schema[i].FieldName
+			valueSchema = append(valueSchema, stringField)
+		}
+	}
+
 	newData := [][]interface{}{}
 	for _, row := range data {
 
-		cell, err := getTableAsYAMLString([][]interface{}{row}, schema)
+		if isRawRow(row) {
+			newData = append(newData, row)
+			continue
+		}
+
+		newRow := []interface{}{}
+		var valueRow []interface{}
+		for _, i := range keyIndexes {
+			newRow = append(newRow, row[i])
+		}
+		for i := range schema {
+			if !isKey[i] {
+				valueRow = append(valueRow, getFoldedCell(row[i], &schema[i]))
+			}
+		}
+
+		//yaml would show the escape sequences of colored cells quoted
+		cell, err := getTableAsYAMLString(withoutColors([][]interface{}{valueRow}), valueSchema, newRenderOptions())
 		if err != nil {
 			return "", err
 		}
-		newData = append(newData, []interface{}{cell})
+		newData = append(newData, append(newRow, cell))
 
 	}
 
-	table := Table{newData, newSchema}
-	table.AdjustFieldSizes()
+	table := Table{Data: newData, Schema: newSchema}
+	if err := table.AdjustFieldSizesErr(); err != nil {
+		return "", err
+	}
+
+	return getTableAsString(table.Data, table.Schema, options), nil
+}
+
+//getFoldedCell returns a cell as it is written in the Values column of the folded text format: formatted like in
+//the unfolded text format, such as 20.10 for a float field with a FieldPrecision of 2, except for nil cells and the
+//cells of int, string, bool and interface fields which are marshaled as they are, interface cells as yaml.
+func getFoldedCell(d interface{}, field *SchemaField) interface{} {
+	if _, ok := d.(Cell); !ok && d != nil {
+		switch field.FieldType {
+		case TypeInt, TypeString, TypeBool, TypeInterface:
+			return d
+		}
+	}
+	if d == nil {
+		return nil
+	}
+	return strings.Join(getCellLines(d, field), "\n")
+}
+
+func printTableHeader(schema []SchemaField) {
+	fmt.Println(getTableHeader(schema, newRenderOptions()))
+}
+
+func printTableRow(row []interface{}, schema []SchemaField) {
+	fmt.Println(getTableRow(row, schema, newRenderOptions()))
+}
+
+func printTableDelimiter(schema []SchemaField) {
+	fmt.Println(getTableDelimiter(schema, newRenderOptions()))
+}
+
+func printTable(data [][]interface{}, schema []SchemaField) {
+	fmt.Print(getTableAsString(data, schema, newRenderOptions()))
+}
+
+//getTableAsYAMLString returns a yaml.Marshal string for the given data
+func getTableAsYAMLString(data [][]interface{}, schema []SchemaField, options *RenderOptions) (string, error) {
+
+	dataAsMap := make([]interface{}, len(data))
+
+	for k, row := range data {
+		dataAsMap[k] = withoutNilKeys(getRowAsYAMLMap(row, schema), options)
+	}
+
+	ret, err := yaml.Marshal(dataAsMap)
+	if err != nil {
+		return "", err
+	}
+
+	return string(ret), nil
+}
+
+//getRowAsYAMLMap returns a map with the cells of the row using the sanitized lowerCamel field names as keys, see getYAMLKeys
+func getRowAsYAMLMap(row []interface{}, schema []SchemaField) map[string]interface{} {
+	rowAsMap := make(map[string]interface{}, len(schema))
+	for i, key := range getYAMLKeys(schema) {
+		rowAsMap[key] = getExportedCell(row[i], &schema[i])
+	}
+	return rowAsMap
+}
+
+//getTableAsYAMLDocsString returns each row as a separate yaml document. Documents are separated by ---
+func getTableAsYAMLDocsString(data [][]interface{}, schema []SchemaField, options *RenderOptions) (string, error) {
+	var sb strings.Builder
+
+	for k, row := range data {
+		ret, err := yaml.Marshal(withoutNilKeys(getRowAsYAMLMap(row, schema), options))
+		if err != nil {
+			return "", err
+		}
+		if k > 0 {
+			sb.WriteString("---\n")
+		}
+		sb.Write(ret)
+	}
+
+	return sb.String(), nil
+}
+
+//getRowAsJSONMap returns the row as a map keyed by field name, see getJSONKeys
+func getRowAsJSONMap(row []interface{}, schema []SchemaField) map[string]interface{} {
+	rowAsMap := make(map[string]interface{}, len(schema))
+	for i, key := range getJSONKeys(schema) {
+		rowAsMap[key] = getExportedCell(row[i], &schema[i])
+	}
+	return rowAsMap
+}
+
+//withoutNilKeys removes the keys of the nil cells from a row map if RenderOptions.OmitNilKeys is set
+func withoutNilKeys(rowAsMap map[string]interface{}, options *RenderOptions) map[string]interface{} {
+	if options.OmitNilKeys {
+		for key, value := range rowAsMap {
+			if value == nil {
+				delete(rowAsMap, key)
+			}
+		}
+	}
+	return rowAsMap
+}
+
+//getTableAsJSONString returns a json.MarshalIndent string for the given data
+func getTableAsJSONString(data [][]interface{}, schema []SchemaField, options *RenderOptions) (string, error) {
+	return renderToString(func(w *bufio.Writer, rows [][]interface{}) error {
+		return writeTableAsJSON(w, rows, schema, options)
+	})(data)
+}
+
+//orderedJSONRow is a row that is marshaled as a json object with the keys in the order of the schema
+type orderedJSONRow struct {
+	row     []interface{}
+	schema  []SchemaField
+	omitNil bool
+}
+
+//MarshalJSON encodes the row as a json object, values are encoded just like json.Marshal does
+func (r orderedJSONRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	first := true
+	for i, fieldKey := range getJSONKeys(r.schema) {
+		cell := getExportedCell(r.row[i], &r.schema[i])
+		if r.omitNil && cell == nil {
+			continue
+		}
+		if !first {
+			buf.WriteString(",")
+		}
+		first = false
+		key, err := json.Marshal(fieldKey)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(cell)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+//getTableAsOrderedJSONString is like getTableAsJSONString but the keys of each object are in the order of the schema
+func getTableAsOrderedJSONString(data [][]interface{}, schema []SchemaField, options *RenderOptions) (string, error) {
+	return renderToString(func(w *bufio.Writer, rows [][]interface{}) error {
+		return writeTableAsOrderedJSON(w, rows, schema, options)
+	})(data)
+}
+
+//getCSVCell returns the csv value of a cell. Cells that do not hold the type of their field are formatted like interface cells.
+//A Cell is written as its Value.
+func getCSVCell(d interface{}, field *SchemaField) string {
+	if c, ok := d.(Cell); ok {
+		d = c.Value
+	}
+	if d == nil {
+		return field.FieldDefault
+	}
+	handler := getFieldTypeHandler(field)
+
+	var s string
+	var err error
+	if csvHandler, ok := handler.(csvFieldTypeHandler); ok {
+		s, err = csvHandler.FormatCSV(d, field)
+	} else {
+		s, err = handler.Format(d, field)
+	}
+	if err != nil {
+		return getInterfaceAsString(d)
+	}
+	return s
+}
+
+//getTableAsCSVString returns a table as a csv
+func getTableAsCSVString(data [][]interface{}, schema []SchemaField, options *CSVOptions) (string, error) {
+	return renderToString(func(w *bufio.Writer, rows [][]interface{}) error {
+		return writeTableAsCSV(w, rows, schema, options)
+	})(data)
+}
+
+func truncateString(s string, length int) string {
+	str := s
+	if len(str) > 0 {
+		return str[:length] + "..."
+	}
+	return ""
+}
 
-	return getTableAsString(table.Data, table.Schema), nil
+//RenderTableAsJSON renders the table as an array json objects
+//
+//Deprecated: use Render with WithFormat("json")
+func (t *Table) RenderTableAsJSON() (string, error) {
+	return t.Render(WithFormat("json"))
 }
 
-func printTableHeader(schema []SchemaField) {
-	fmt.Println(getTableHeader(schema))
+//RenderTableAsCSV renders the table as a csv
+//
+//Deprecated: use Render with WithFormat("csv")
+func (t *Table) RenderTableAsCSV() (string, error) {
+	return t.Render(WithFormat("csv"))
 }
 
-func printTableRow(row []interface{}, schema []SchemaField) {
-	fmt.Println(getTableRow(row, schema))
+//RenderTableAsYAML renders the table as a yaml object
+//
+//Deprecated: use Render with WithFormat("yaml")
+func (t *Table) RenderTableAsYAML() (string, error) {
+	return t.Render(WithFormat("yaml"))
 }
 
-func printTableDelimiter(schema []SchemaField) {
-	fmt.Println(getTableDelimiter(schema))
+//RenderTable renders a table object as a string
+//supported formats: text (or empty), json, json-ordered, csv, yaml, yaml-docs, md (or markdown), html, aligned (or slack), html-pre
+//
+//Deprecated: use Render with the WithTableName, WithTopLine and WithFormat options
+func (t *Table) RenderTable(tableName string, topLine string, format string, opts ...RenderOption) (string, error) {
+	return t.Render(append(opts, WithTableName(tableName), WithTopLine(topLine), WithFormat(format))...)
 }
 
-func printTable(data [][]interface{}, schema []SchemaField) {
-	fmt.Print(getTableAsString(data, schema))
+//RenderTableFoldable renders a table object as a string
+//supported formats: text (the default, also selected by an empty format), json, json-ordered (keys in schema order), csv, yaml, yaml-docs (one yaml document per row),
+//md or markdown (a GitHub flavored markdown table), html (a table element, see HTMLOptions), aligned or slack (columns without borders, topLine and total, for pasting in chat clients),
+//html-pre (the text format in a html pre element with the colors converted to spans),
+//text-fixed (the text format with the column widths of the schema and one line per row, for output compared with diff).
+//Format names are not case sensitive, other formats return an ErrInvalidFormat.
+//foldAtLength specifies at which row length to fold the
+//
+//Deprecated: use Render with the WithTableName, WithTopLine, WithFormat and WithFoldAtLength options
+func (t *Table) RenderTableFoldable(tableName string, topLine string, format string, foldAtLength int, opts ...RenderOption) (string, error) {
+	return t.Render(append(opts, WithTableName(tableName), WithTopLine(topLine), WithFormat(format), WithFoldAtLength(foldAtLength))...)
 }
 
-//getTableAsYAMLString returns a yaml.Marshal string for the given data
-func getTableAsYAMLString(data [][]interface{}, schema []SchemaField) (string, error) {
-
-	dataAsMap := make([]interface{}, len(data))
+//Render renders the table in the format and with the settings given by the options.
+//See RenderTableFoldable for the supported formats. With WithTransposed the text format renders a key-value table.
+func (t *Table) Render(opts ...RenderOption) (string, error) {
+	return t.renderWithHooks(newRenderOptions(opts...))
+}
 
-	for k, row := range data {
-		rowAsMap := make(map[string]interface{}, len(schema))
-		for i, field := range schema {
-			formattedFieldName := strcase.ToLowerCamel(strings.ToLower(field.FieldName))
-			rowAsMap[formattedFieldName] = row[i]
+//RenderTo is like Render but writes the output to w. The text, json, json-ordered and csv formats are written
+//one row at a time, without holding the whole output in memory, unless the table has error rows
+//or post-render hooks.
+func (t *Table) RenderTo(w io.Writer, opts ...RenderOption) error {
+	options := newRenderOptions(opts...)
+	if options.Transposed || len(options.PostRender) > 0 {
+		s, err := t.renderWithHooks(options)
+		if _, werr := io.WriteString(w, s); werr != nil {
+			return werr
 		}
-		dataAsMap[k] = rowAsMap
+		return err
 	}
 
-	ret, err := yaml.Marshal(dataAsMap)
+	t, err := applyPreRenderHooks(t, options)
 	if err != nil {
-		return "", err
+		return err
 	}
-
-	return string(ret), nil
+	return t.renderTableTo(w, options)
 }
 
-//getTableAsJSONString returns a json.MarshalIndent string for the given data
-func getTableAsJSONString(data [][]interface{}, schema []SchemaField) (string, error) {
-	dataAsMap := make([]interface{}, len(data))
+//RenderTableTo is like RenderTable but writes the output to w, see RenderTo
+func (t *Table) RenderTableTo(w io.Writer, tableName string, topLine string, format string, opts ...RenderOption) error {
+	return t.RenderTo(w, append(opts, WithTableName(tableName), WithTopLine(topLine), WithFormat(format))...)
+}
 
-	for k, row := range data {
-		rowAsMap := make(map[string]interface{}, len(schema))
-		for i, field := range schema {
-			rowAsMap[field.FieldName] = row[i]
-		}
-		dataAsMap[k] = rowAsMap
+//renderTable renders the table as set by the options
+func (t *Table) renderTable(options *RenderOptions) (string, error) {
+	var sb strings.Builder
+	err := t.renderTableTo(&sb, options)
+	if _, ok := err.(*ErrOutputTruncated); err != nil && !ok {
+		return "", err
 	}
+	return sb.String(), err
+}
 
-	ret, err := json.MarshalIndent(dataAsMap, "", "\t")
+//getPreparedData returns the rows, with their computed fields, and the schema as they are rendered in the format:
+//the schema is resolved and clamped and the cells are changed by the options such as Severities or EmptyAsNil.
+//The rows hold the cells of the fields of t.Schema that are not computed, like the rows of Table.Data.
+func (t *Table) getPreparedData(rows [][]interface{}, format string, options *RenderOptions) ([][]interface{}, []SchemaField, error) {
+	data, err := computeColumns(rows, t.Schema)
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+	schema := getClampedSchema(t.getResolvedSchema(), options)
+	if options.SeverityFields && exportFormats[format] {
+		data, schema = withSeverityFields(data, schema)
+	}
+	data = applyWithoutColors(applySanitizedUTF8(applyEmptyAsNil(applySeverities(applyUnits(data, schema), schema, options), options), options), options)
+	if options.NoColor {
+		schema = getSchemaWithoutColors(schema)
 	}
 
-	return string(ret), nil
+	//the renderers cannot return the errors of the custom field types
+	if err := checkCustomCells(data, schema); err != nil {
+		return nil, nil, err
+	}
+	return data, schema, nil
 }
 
-//getTableAsCSVString returns a table as a csv
-func getTableAsCSVString(data [][]interface{}, schema []SchemaField) (string, error) {
-	var buf bytes.Buffer
-	writer := bufio.NewWriter(&buf)
-	csvWriter := csv.NewWriter(writer)
-
-	rowStr := make([]string, len(schema))
-	for i, field := range schema {
-		rowStr[i] = field.FieldName
+//renderTableTo writes the table as set by the options to w
+func (t *Table) renderTableTo(w io.Writer, options *RenderOptions) error {
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return err
 	}
 
-	csvWriter.Write(rowStr)
+	tableName := options.TableName
+	topLine := options.TopLine
+	foldAtLength := options.FoldAtLength
 
-	for _, row := range data {
-		for i, field := range schema {
-			switch field.FieldType {
-			case TypeInt:
-				rowStr[i] = fmt.Sprintf("%d", row[i].(int))
-			case TypeString:
-				rowStr[i] = row[i].(string)
-			case TypeFloat:
-				rowStr[i] = fmt.Sprintf("%f", row[i].(float64))
-			case TypeInterface:
-				rowStr[i] = fmt.Sprintf("%v", row[i])
-			case TypeDateTime:
-				rowStr[i] = row[i].(string)
-			default:
-				rowStr[i] = fmt.Sprintf("%v", row[i])
+	format, err := resolveFormat(options.Format)
+	if err != nil {
+		return err
+	}
+
+	if format == "html-pre" {
+		textOptions := *options
+		textOptions.Format = ""
+		//the error might be an ErrOutputTruncated returned with the partial output
+		s, err := t.renderTable(&textOptions)
+		//the markup added to the text counts in the limit, the text is rendered again with less room until it fits
+		for options.MaxOutputBytes > 0 && s != "" && len(getHTMLPre(s)) > options.MaxOutputBytes {
+			if textOptions.MaxOutputBytes == 1 {
+				s = ""
+				break
+			}
+			textOptions.MaxOutputBytes -= len(getHTMLPre(s)) - options.MaxOutputBytes
+			if textOptions.MaxOutputBytes <= 0 {
+				textOptions.MaxOutputBytes = 1
 			}
+			s, err = t.renderTable(&textOptions)
 		}
-		csvWriter.Write(rowStr)
+		if s == "" {
+			return err
+		}
+		if _, werr := io.WriteString(w, getHTMLPre(s)); werr != nil {
+			return werr
+		}
+		return err
 	}
 
-	writer.Flush()
-	csvWriter.Flush()
+	allData, schema, err := t.getPreparedData(t.getData(), format, options)
+	if err != nil {
+		return err
+	}
 
-	return buf.String(), nil
-}
+	//raw rows are only rendered by the text format
+	data := withoutRawRows(allData)
+	if options.StrictRawRows && len(data) != len(allData) && machineReadableFormats[format] {
+		return fmt.Errorf("raw rows cannot be rendered in the %s format", format)
+	}
 
-func truncateString(s string, length int) string {
-	str := s
-	if len(str) > 0 {
-		return str[:length] + "..."
+	//the masks apply only to the formats read by people and to csv
+	maskedData, err := applyValueMasks(allData, schema, options)
+	if err != nil {
+		return err
 	}
-	return ""
-}
 
-//RenderTableAsJSON renders the table as an array json objects
-func (t *Table) RenderTableAsJSON() (string, error) {
-	return t.RenderTableFoldable("", "", "json", defaultFoldAtLength)
-}
+	//runs of identical rows are collapsed only by the text formats
+	collapsedData, err := collapseRuns(maskedData, schema, options)
+	if err != nil {
+		return err
+	}
 
-//RenderTableAsCSV renders the table as a csv
-func (t *Table) RenderTableAsCSV() (string, error) {
-	return t.RenderTableFoldable("", "", "csv", defaultFoldAtLength)
-}
+	//the sizes of the fields of the table are updated unless the rendered schema has other fields
+	adjustSizes := t.adjustFieldSizes
+
+	//the hidden fields are left out by every format, the wide only ones unless asked for them
+	schema = getProfileSchema(schema, format, options)
+	if hasHiddenFields(schema) {
+		data = withoutHiddenFields(data, schema)
+		maskedData = withoutHiddenFields(maskedData, schema)
+		collapsedData = withoutHiddenFields(collapsedData, schema)
+		schema = getVisibleSchema(schema)
+		adjustSizes = adjustFieldSizes
+	}
 
-//RenderTableAsYAML renders the table as a yaml object
-func (t *Table) RenderTableAsYAML() (string, error) {
-	return t.RenderTableFoldable("", "", "yaml", defaultFoldAtLength)
-}
+	if options.RowNumbers {
+		data = withRowNumbers(data)
+		maskedData = withRowNumbers(maskedData)
+		collapsedData = withRowNumbers(collapsedData)
+		schema = getSchemaWithRowNumbers(schema)
+		adjustSizes = adjustFieldSizes
+	}
 
-//RenderTable renders a table object as a string
-//supported formats: json, csv, yaml
-func (t *Table) RenderTable(tableName string, topLine string, format string) (string, error) {
-	return t.RenderTableFoldable(tableName, topLine, format, defaultFoldAtLength)
-}
+	//the formats read by people show at most MaxColumns columns
+	omittedColumns := 0
+	if options.MaxColumns > 0 && !machineReadableFormats[format] {
+		collapsedData, schema, omittedColumns = limitColumns(collapsedData, schema, options)
+		if omittedColumns > 0 {
+			adjustSizes = adjustFieldSizes
+		}
+	}
 
-//RenderTableFoldable renders a table object as a string
-//supported formats: json, csv, yaml
-//foldAtLength specifies at which row length to fold the
-func (t *Table) RenderTableFoldable(tableName string, topLine string, format string, foldAtLength int) (string, error) {
-	var sb strings.Builder
+	//render renders the given rows in the requested format, the text format also renders raw rows.
+	//write, if set, writes the same output one row at a time.
+	var render func(rows [][]interface{}) (string, error)
+	var write func(w *bufio.Writer, rows [][]interface{}) error
+	rows := data
+	isText := false
+	//footer is the row of the FieldAggregate of the fields, nil if there are none
+	footer := getAggregateRow(data, schema)
+	//keyCollision returns the error of RenderOptions.StrictKeys for the formats that key the cells by field
+	keyCollision := func() error { return nil }
 
 	switch format {
-	case "json", "JSON":
-		ret, err := getTableAsJSONString(t.Data, t.Schema)
-		if err != nil {
-			return "", err
+	case "json":
+		keyCollision = func() error { return getKeyCollision(getKeyedSchema(schema, options), getJSONKeyFold) }
+		write = func(w *bufio.Writer, rows [][]interface{}) error {
+			return writeTableAsJSON(w, withDurationsInSeconds(rows, schema, options), getKeyedSchema(schema, options), options)
 		}
-		sb.WriteString(ret)
-	case "csv", "CSV":
-		ret, err := getTableAsCSVString(t.Data, t.Schema)
-		if err != nil {
-			return "", err
+	case "json-ordered":
+		keyCollision = func() error { return getKeyCollision(getKeyedSchema(schema, options), getJSONKeyFold) }
+		write = func(w *bufio.Writer, rows [][]interface{}) error {
+			return writeTableAsOrderedJSON(w, withDurationsInSeconds(rows, schema, options), getKeyedSchema(schema, options), options)
 		}
-		sb.WriteString(ret)
-	case "yaml", "YAML":
-		ret, err := getTableAsYAMLString(t.Data, t.Schema)
-		if err != nil {
-			return "", err
+	case "csv":
+		rows = withoutRawRows(maskedData)
+		csvOptions := options.CSV
+		if csvOptions == nil {
+			csvOptions = &CSVOptions{}
+		}
+		write = func(w *bufio.Writer, rows [][]interface{}) error {
+			return writeTableAsCSV(w, rows, schema, csvOptions)
+		}
+	case "yaml":
+		keyCollision = func() error { return getKeyCollision(schema, getYAMLKey) }
+		render = func(rows [][]interface{}) (string, error) {
+			return getTableAsYAMLString(withDurationsInSeconds(rows, schema, options), schema, options)
+		}
+	case "yaml-docs":
+		keyCollision = func() error { return getKeyCollision(schema, getYAMLKey) }
+		render = func(rows [][]interface{}) (string, error) {
+			return getTableAsYAMLDocsString(withDurationsInSeconds(rows, schema, options), schema, options)
+		}
+	case "md":
+		rows = withoutRawRows(maskedData)
+		render = func(rows [][]interface{}) (string, error) {
+			return getTableAsMarkdownString(rows, schema), nil
+		}
+	case "html":
+		rows = withoutRawRows(maskedData)
+		render = func(rows [][]interface{}) (string, error) {
+			return getTableAsHTMLString(rows, schema, options.HTML), nil
+		}
+	case "aligned":
+		adjustSizes(collapsedData, schema)
+		rows = collapsedData
+		render = func(rows [][]interface{}) (string, error) {
+			return getTableAsAlignedString(rows, schema), nil
+		}
+	case "text-fixed":
+		//the field sizes are not adjusted, see fixed.go
+		isText = true
+		rows = collapsedData
+		render = func(rows [][]interface{}) (string, error) {
+			return getTableAsFixedString(rows, schema, footer, options), nil
 		}
-		sb.WriteString(ret)
 
 	default:
+		isText = true
+
+		if footer != nil {
+			adjustSizes(append(collapsedData[:len(collapsedData):len(collapsedData)], footer), schema)
+		} else {
+			adjustSizes(collapsedData, schema)
+		}
+		rows = collapsedData
+
+		switch {
+		case isNarrowLayout(options):
+			render = func(rows [][]interface{}) (string, error) {
+				return getTableAsNarrowString(rows, schema, options), nil
+			}
+		case len(data) > 0 && getRowSize(withoutRawRows(collapsedData), schema) > foldAtLength:
+			keyCollision = func() error { return getKeyCollision(schema, getYAMLKey) }
+			render = func(rows [][]interface{}) (string, error) {
+				return getFoldedTableAsString(rows, schema, options)
+			}
+		default:
+			write = func(w *bufio.Writer, rows [][]interface{}) error {
+				return writeTableAsString(w, rows, schema, footer, options)
+			}
+		}
+	}
+
+	//the fields are widened to their widest cell, which must not make every row megabytes long
+	if err := checkPadding(schema); err != nil {
+		return err
+	}
+
+	if options.AggregateRow && footer != nil && !isText {
+		rows = append(rows[:len(rows):len(rows)], footer)
+	}
+
+	if options.StrictKeys {
+		if err := keyCollision(); err != nil {
+			return err
+		}
+	}
+
+	if write != nil {
+		render = renderToString(write)
+	}
+
+	if options.Metadata && metadataFormats[format] {
+		render = withMetadata(render, format, tableName, len(data), t.errorRows)
+		write = nil
+	} else if len(t.errorRows) > 0 {
+		render = withErrorRows(render, format, t.errorRows)
+		write = nil
+		if options.NoColor {
+			colored := render
+			render = func(rows [][]interface{}) (string, error) {
+				s, err := colored(rows)
+				return decolorize(s), err
+			}
+		}
+	}
+
+	var header, trailer string
+	if isText {
 		if topLine != "" {
-			sb.WriteString(fmt.Sprintf("%s\n", topLine))
+			header = fmt.Sprintf("%s\n", topLine)
+		}
+		incomplete := ""
+		if len(t.errorRows) > 0 {
+			incomplete = " (incomplete)"
 		}
+		trailer = fmt.Sprintf("%s%s\n\n", getTrailer(len(data), tableName, options), incomplete)
+	}
+	if omittedColumns > 0 && options.ColumnOverflow == ColumnOverflowDrop {
+		trailer = getOmittedColumnsNotice(omittedColumns) + trailer
+	}
 
-		t.AdjustFieldSizes()
+	var truncated error
+	if options.MaxOutputBytes > 0 {
+		n, err := limitOutput(rows, render, options.MaxOutputBytes-len(header)-len(trailer))
+		if err != nil {
+			return err
+		}
+		if n < len(rows) {
+			truncated = &ErrOutputTruncated{
+				RenderedRows: len(withoutRawRows(rows[:n])),
+				TotalRows:    len(data),
+			}
+			rows = rows[:n]
+		}
 
-		if len(t.Data) > 0 && getRowSize(t.Data, t.Schema) > foldAtLength {
-			s, err := getFoldedTableAsString(t.Data, t.Schema)
+		//nothing is written if not even the document without rows fits
+		if n == 0 {
+			empty, err := render(rows)
 			if err != nil {
-				return "", err
+				return err
+			}
+			if len(header)+len(empty)+len(trailer) > options.MaxOutputBytes {
+				return &ErrOutputTruncated{TotalRows: len(data)}
 			}
-			sb.WriteString(s)
-		} else {
-			sb.WriteString(getTableAsString(t.Data, t.Schema))
 		}
+	}
 
-		sb.WriteString(fmt.Sprintf("Total: %d %s\n\n", len(t.Data), tableName))
+	var ret string
+	if write == nil {
+		ret, err = render(rows)
+		if err != nil {
+			return err
+		}
 	}
 
-	return sb.String(), nil
+	bw := bufio.NewWriter(w)
+	bw.WriteString(header)
+	if write != nil {
+		if err := write(bw, rows); err != nil {
+			return err
+		}
+	} else {
+		bw.WriteString(ret)
+	}
+	bw.WriteString(trailer)
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	return truncated
+}
+
+//RenderColumnHelp renders a table with the names and descriptions of the columns of this table
+func (t *Table) RenderColumnHelp() string {
+	data := [][]interface{}{}
+	for _, field := range getVisibleSchema(t.Schema) {
+		data = append(data, []interface{}{field.FieldName, field.FieldDescription})
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "COLUMN",
+			FieldType: TypeString,
+			FieldSize: 5,
+		},
+		{
+			FieldName: "DESCRIPTION",
+			FieldType: TypeString,
+			FieldSize: 5,
+		},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.AdjustFieldSizes()
+
+	return getTableAsString(table.Data, table.Schema, newRenderOptions())
 }
 
-//TransposeTable turns columns into rows. It assumes an uniform length table
+//TransposeTable turns columns into rows. It assumes an uniform length table.
+//The schema of the transposed table has one field for each row of the table, named after the position of the row
+//starting at 1. The fields have the type of the fields of the table if they all have the same one, TypeInterface otherwise.
 func TransposeTable(t Table) Table {
+	t = *t.getLenientShapedTable()
 
 	dataT := [][]interface{}{}
 
 	if len(t.Data) == 0 {
-		return t
+		return Table{Data: dataT, Schema: []SchemaField{}, TimeFormat: t.TimeFormat}
 	}
 
 	tableLength := len(t.Data)
@@ -591,45 +1994,123 @@ func TransposeTable(t Table) Table {
 
 		dataT = append(dataT, newRow)
 	}
-	newTable := Table{dataT, t.Schema}
+	fieldType := TypeInterface
+	for i, field := range t.Schema {
+		if i == 0 {
+			fieldType = field.FieldType
+		} else if field.FieldType != fieldType {
+			fieldType = TypeInterface
+			break
+		}
+	}
+
+	schemaT := make([]SchemaField, tableLength)
+	for i := range schemaT {
+		schemaT[i] = SchemaField{FieldName: strconv.Itoa(i + 1), FieldType: fieldType}
+	}
+
+	newTable := Table{Data: dataT, Schema: schemaT, TimeFormat: t.TimeFormat}
 	return newTable
 }
 
-//ConvertToStringTable converts all cells to string cells
+//ConvertToStringTable converts all cells to string cells formatted like in the text format, such as date time cells
+//with the layout of their field and float cells with its FieldPrecision. Cells not described by the schema are formatted with %v.
+//The fields of the schema keep their names and sizes but become TypeString fields, so sorting the converted table
+//compares the text of the cells. Sort before converting to compare the original values, see TransposeSorted.
+//Computed fields are computed and become regular fields.
 func ConvertToStringTable(table Table) Table {
+	table = *table.getLenientShapedTable()
 	dataS := [][]interface{}{}
+	schema := table.getResolvedSchema()
+
+	data, err := computeColumns(table.getData(getFieldNames(table.Schema)...), table.Schema)
+	if err != nil {
+		//the cells of computed fields that cannot be computed are left empty
+		data = table.getData(getFieldNames(table.Schema)...)
+	}
 
-	for _, row := range table.Data {
+	for _, row := range data {
 		newRow := []interface{}{}
-		for _, v := range row {
+		for i, v := range row {
 			if v == nil {
 				v = " "
+				if i < len(schema) && schema[i].FieldDefault != "" {
+					v = schema[i].FieldDefault
+				}
+			}
+			if i < len(schema) {
+				v = strings.Join(getCellLines(v, &schema[i]), "\n")
 			}
 			newRow = append(newRow, fmt.Sprintf("%v", v))
 		}
 		dataS = append(dataS, newRow)
 	}
+	schemaS := make([]SchemaField, len(table.Schema))
+	for i, field := range table.Schema {
+		schemaS[i] = SchemaField{
+			FieldName:        field.FieldName,
+			FieldType:        TypeString,
+			FieldSize:        field.FieldSize,
+			FieldDescription: field.FieldDescription,
+			FieldGroup:       field.FieldGroup,
+			FieldPriority:    field.FieldPriority,
+			FieldAlignment:   field.FieldAlignment,
+			FieldHidden:      field.FieldHidden,
+			FieldWrapAt:      field.FieldWrapAt,
+			FieldWideOnly:    field.FieldWideOnly,
+			FieldID:          field.FieldID,
+		}
+	}
+
 	newTable := Table{
-		Data:   dataS,
-		Schema: table.Schema,
+		Data:       dataS,
+		Schema:     schemaS,
+		TimeFormat: table.TimeFormat,
 	}
 	return newTable
 }
 
 //RenderTransposedTable renders the text format as a key-value table. json and csv formats remain the same as render table
-//supported formats: json, csv, yaml
-func (t *Table) RenderTransposedTable(tableName string, topLine string, format string) (string, error) {
+//supported formats: text (or empty), json, csv, yaml
+//
+//Deprecated: use Render with the WithTransposed, WithTableName, WithTopLine and WithFormat options
+func (t *Table) RenderTransposedTable(tableName string, topLine string, format string, opts ...RenderOption) (string, error) {
+	return t.Render(append(opts, WithTableName(tableName), WithTopLine(topLine), WithFormat(format), WithTransposed())...)
+}
+
+//renderTransposedTable renders the text format as a key-value table and the other formats like renderTable
+func (t *Table) renderTransposedTable(options *RenderOptions) (string, error) {
+	format, err := resolveFormat(options.Format)
+	if err != nil {
+		return "", err
+	}
+	if format != "text" {
+		return t.renderTable(options)
+	}
 
-	if format != "" {
-		return t.RenderTable(tableName, topLine, format)
+	t, err = t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return "", err
 	}
 
+	schema := getProfileSchema(getMaterializedSchema(t.getResolvedSchema()), format, options)
 	headerRow := []interface{}{}
-	for _, s := range t.Schema {
+	for _, s := range getVisibleSchema(schema) {
 		headerRow = append(headerRow, s.FieldName)
 	}
 
-	stringsTable := ConvertToStringTable(*t)
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		return "", err
+	}
+
+	data, err = applyValueMasks(applyEmptyAsNil(applySeverities(applyUnits(data, t.Schema), t.Schema, options), options), t.Schema, options)
+	if err != nil {
+		return "", err
+	}
+
+	//raw rows cannot be transposed
+	stringsTable := ConvertToStringTable(Table{Data: withoutHiddenFields(withoutRawRows(data), schema), Schema: getVisibleSchema(schema)})
 
 	newDataAsStrings := [][]interface{}{}
 	newDataAsStrings = append(newDataAsStrings, headerRow)
@@ -650,26 +2131,87 @@ func (t *Table) RenderTransposedTable(tableName string, topLine string, format s
 		},
 	}
 
-	newTable := Table{newDataAsStrings, newSchema}
-	tableTransposed := TransposeTable(newTable)
+	tableTransposed := TransposeTable(Table{Data: newDataAsStrings})
+	tableTransposed.Schema = newSchema
+	//only the values of the first row are rendered
+	tableTransposed.ExtraCells = ExtraCellsIgnore
+
+	//the masks were applied before transposing
+	transposedOptions := *options
+	transposedOptions.ValueMasks = nil
+	return tableTransposed.renderTable(&transposedOptions)
+}
+
+//writeKeyValueLines writes one "key: value" line for each field of schema, each line starting with margin.
+//Values are wrapped at RenderOptions.WrapWidth and their continuation lines are indented to the start of the value.
+func writeKeyValueLines(sb *strings.Builder, row []interface{}, schema []SchemaField, options *RenderOptions, margin string) {
+	separator := options.KeyValueSeparator
+	if separator == "" {
+		separator = ": "
+	}
+
+	keyWidth := 0
+	if options.AlignKeys {
+		for _, field := range schema {
+			if VisibleWidth(field.FieldName) > keyWidth {
+				keyWidth = VisibleWidth(field.FieldName)
+			}
+		}
+	}
+
+	for i, field := range schema {
+		key := margin + pad(field.FieldName, keyWidth) + separator
+		indent := emptyString(VisibleWidth(key))
+
+		valueWidth := 0
+		if options.WrapWidth > 0 {
+			//always leave some room for the value even if the key is wider than the wrap width
+			valueWidth = options.WrapWidth - VisibleWidth(key)
+			if valueWidth < 1 {
+				valueWidth = 1
+			}
+		}
 
-	return tableTransposed.RenderTable(tableName, topLine, format)
+		for k, line := range WrapToWidth(getHumanReadableValue(row[i], &field), valueWidth) {
+			switch {
+			case k == 0:
+				sb.WriteString(key + line)
+			case line != "":
+				sb.WriteString(indent + line)
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
 
+//getHumanReadableValue formats a cell for the human readable renders
+func getHumanReadableValue(value interface{}, field *SchemaField) string {
+	return strings.Join(getCellLines(value, field), "\n")
 }
 
-//RenderTransposedTableHumanReadable renders an object in a human readable way
-func (t *Table) RenderTransposedTableHumanReadable(tableName string, topLine string) (string, error) {
+//RenderTransposedTableHumanReadable renders an object in a human readable way, one "key: value" line per field.
+//Values are wrapped at RenderOptions.WrapWidth and their continuation lines are indented to the start of the value.
+func (t *Table) RenderTransposedTableHumanReadable(tableName string, topLine string, opts ...RenderOption) (string, error) {
+	options := newRenderOptions(opts...)
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return "", err
+	}
 
-	headerRow := []interface{}{}
-	for _, s := range t.Schema {
-		headerRow = append(headerRow, s.FieldName)
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		return "", err
 	}
 
-	var sb strings.Builder
-	for i, field := range t.Schema {
-		sb.WriteString(fmt.Sprintf("%s: %v\n", field.FieldName, t.Data[0][i]))
+	data, err = applyValueMasks(applyWithoutColors(applyEmptyAsNil(applySeverities(applyUnits(data, t.Schema), t.Schema, options), options), options), t.Schema, options)
+	if err != nil {
+		return "", err
 	}
 
+	schema := getProfileSchema(t.getResolvedSchema(), "text", options)
+	var sb strings.Builder
+	writeKeyValueLines(&sb, withoutHiddenFields(data[:1], schema)[0], getVisibleSchema(schema), options, "")
+
 	return sb.String(), nil
 }
 
@@ -715,96 +2257,160 @@ func NewStripPrefixFormatter(prefix string) *StripPrefixFormatter {
 	return &StripPrefixFormatter{Prefix: prefix}
 }
 
+//commonPrefixMinShare is the fraction of field names that must start with a prefix for DetectCommonPrefix to return it
+const commonPrefixMinShare = 0.6
+
+//DetectCommonPrefix returns the longest prefix made of whole CamelCase words that is shared by at least
+//60% of the field names. A prefix is never a whole field name. Returns an empty string if there is no such prefix.
+func DetectCommonPrefix(fieldNames []string) string {
+	counts := map[string]int{}
+	for _, name := range fieldNames {
+		for i := 1; i < len(name); i++ {
+			if isWordBoundary(name, i) {
+				counts[name[:i]]++
+			}
+		}
+	}
+
+	prefix := ""
+	for candidate, count := range counts {
+		if float64(count) < commonPrefixMinShare*float64(len(fieldNames)) {
+			continue
+		}
+		if len(candidate) > len(prefix) || (len(candidate) == len(prefix) && candidate < prefix) {
+			prefix = candidate
+		}
+	}
+	return prefix
+}
+
+//isWordBoundary returns true if a new CamelCase word starts at position i of the name
+func isWordBoundary(name string, i int) bool {
+	isUpper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	if !isUpper(name[i]) {
+		return false
+	}
+	if !isUpper(name[i-1]) {
+		return true
+	}
+	//the last capital of an acronym starts a new word: IDString
+	return i+1 < len(name) && !isUpper(name[i+1])
+}
+
+//AutoStripPrefixFormatter is like StripPrefixFormatter but the prefix is detected with DetectCommonPrefix
+//from the field names of a struct the first time Format is called
+type AutoStripPrefixFormatter struct {
+	obj       interface{}
+	formatter *StripPrefixFormatter
+}
+
+//Format returns formatted string
+func (o *AutoStripPrefixFormatter) Format(s string) string {
+	if o.formatter == nil {
+		var fieldNames []string
+		t := reflect.TypeOf(o.obj)
+		if t != nil && t.Kind() == reflect.Struct {
+			for i := 0; i < t.NumField(); i++ {
+				fieldNames = append(fieldNames, t.Field(i).Name)
+			}
+		}
+		o.formatter = NewStripPrefixFormatter(DetectCommonPrefix(fieldNames))
+	}
+	return o.formatter.Format(s)
+}
+
+//NewAutoStripPrefixFormatter creates a formatter that strips the common prefix of the fields of obj
+func NewAutoStripPrefixFormatter(obj interface{}) *AutoStripPrefixFormatter {
+	return &AutoStripPrefixFormatter{obj: obj}
+}
+
 //ObjectToTable converts an object into a table directly
 //without having to manually build the schema and fields
 func ObjectToTable(obj interface{}) (*Table, error) {
 	return ObjectToTableWithFormatter(obj, NewHumanReadableFormatter())
 }
 
-//ObjectToTableWithFormatter converts an object into a table directly without having to manually build the schema and fields
+//ObjectToTableWithFormatter converts an object into a table directly without having to manually build the schema and fields.
+//An error is returned if obj is not a struct.
 func ObjectToTableWithFormatter(obj interface{}, fieldNameFormatter FieldNameFormatter) (*Table, error) {
-	var data []interface{}
-	var schema []SchemaField
-
 	t := reflect.TypeOf(obj)
-	v := reflect.ValueOf(obj)
 
+	if t == nil {
+		return nil, fmt.Errorf("Only struct types are supported. This is nil")
+	}
 	if t.Kind() != reflect.Struct {
-		panic(fmt.Errorf("Only struct types are supported. This is %v", t.Kind()))
+		return nil, fmt.Errorf("Only struct types are supported. This is %v", t.Kind())
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-
-		fieldName := fieldNameFormatter.Format(t.Field(i).Name)
-
-		typeName := 0
-
-		switch t.Field(i).Type.Kind() {
-		case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
-			typeName = TypeInt
-			data = append(data, int(v.Field(i).Int()))
-		case reflect.String:
-			typeName = TypeString
-			data = append(data, v.Field(i).String())
-		case reflect.Float32, reflect.Float64:
-			typeName = TypeFloat
-			data = append(data, v.Field(i).Float())
-		default:
-			typeName = TypeString
-			s, err := yaml.Marshal(v.Field(i).Interface())
-			if err != nil {
-				return nil, err
-			}
-			data = append(data, strings.TrimSpace(string(s)))
-		}
+	return ObjectToTableWithOptions(obj, ObjectToTableOptions{FieldNameFormatter: fieldNameFormatter})
+}
 
-		schema = append(schema, SchemaField{
-			FieldName: fieldName,
-			FieldType: typeName,
-		})
+//getRawObjectFormatter returns the formatter of the field names of an object rendered by RenderRawObject
+func getRawObjectFormatter(obj interface{}, prefixToStrip string, options *RenderOptions) FieldNameFormatter {
+	if prefixToStrip == "" && options.AutoStripPrefix {
+		return NewAutoStripPrefixFormatter(obj)
 	}
-	newData := [][]interface{}{data}
-	newTbl := Table{newData, schema}
-	return &newTbl, nil
-
+	return NewStripPrefixFormatter(prefixToStrip)
 }
 
-//RenderRawObject renders an object without having to build a schema for it
-func RenderRawObject(obj interface{}, format string, prefixToStrip string) (string, error) {
+//RenderRawObject renders an object without having to build a schema for it.
+//The md (or markdown) format renders a two column KEY and VALUE markdown table.
+//If prefixToStrip is empty and the WithAutoStripPrefix option is used the prefix is detected automatically
+func RenderRawObject(obj interface{}, format string, prefixToStrip string, opts ...RenderOption) (string, error) {
+	options := newRenderOptions(opts...)
+
+	format, err := resolveFormat(format)
+	if err != nil {
+		return "", err
+	}
 
 	switch format {
-	case "json", "JSON":
+	case "json":
 		ret, err := json.MarshalIndent(obj, "", "\t")
 		if err != nil {
 			return "", err
 		}
 		return string(ret), nil
-	case "csv", "CSV":
+	case "csv":
 		t, err := ObjectToTableWithFormatter(obj, NewPassThroughFormatter())
 		if err != nil {
 			return "", err
 		}
-		ret, err := getTableAsCSVString(t.Data, t.Schema)
+		ret, err := getTableAsCSVString(t.Data, t.Schema, &CSVOptions{})
 		if err != nil {
 			return "", err
 		}
 		return ret, nil
-	case "yaml", "YAML":
+	case "md":
+		table, err := ObjectToTableWithFormatter(obj, getRawObjectFormatter(obj, prefixToStrip, options))
+		if err != nil {
+			return "", err
+		}
+		return getObjectAsMarkdownString(table)
+	case "yaml", "yaml-docs":
+		//a single object is a single document
 		ret, err := yaml.Marshal(obj)
 		if err != nil {
 			return "", err
 		}
 		return string(ret), nil
-	default:
-		table, err := ObjectToTableWithFormatter(obj, NewStripPrefixFormatter(prefixToStrip))
+	case "text":
+		table, err := ObjectToTableWithFormatter(obj, getRawObjectFormatter(obj, prefixToStrip, options))
 		if err != nil {
 			return "", err
 		}
-		ret, err := table.RenderTransposedTableHumanReadable("", "")
+		ret, err := table.RenderTransposedTableHumanReadable("", "", opts...)
 		if err != nil {
 			return "", err
 		}
 		return ret, nil
+	default:
+		//the other formats render the object as a table with a single row
+		table, err := ObjectToTableWithFormatter(obj, getRawObjectFormatter(obj, prefixToStrip, options))
+		if err != nil {
+			return "", err
+		}
+		return table.Render(append(opts, WithFormat(format))...)
 	}
 
 }
@@ -3,7 +3,9 @@ package tableformatter
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
@@ -63,6 +65,111 @@ func TestTableSortWithSchema(t *testing.T) {
 	}
 }
 
+func TestTableSortWithSchemaMixedDirections(t *testing.T) {
+	RegisterTestingT(t)
+
+	getData := func() [][]interface{} {
+		return [][]interface{}{
+			{4, "str", 20.1},
+			{6, "st11r", 22.1},
+			{5, "wt11r444", 2.3},
+			{5, "wt11r444", 2.1},
+			{5, "at11r43", 2.2},
+			{4, "xxxx", 2.2},
+			{7, "wt11r444", 2.2},
+		}
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 6,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 20,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldSize:      6,
+			FieldPrecision: 2,
+		},
+	}
+
+	expected := [][]interface{}{
+		{5, "at11r43", 2.2},
+		{6, "st11r", 22.1},
+		{4, "str", 20.1},
+		{7, "wt11r444", 2.2},
+		{5, "wt11r444", 2.3},
+		{5, "wt11r444", 2.1},
+		{4, "xxxx", 2.2},
+	}
+
+	data := getData()
+	Expect(TableSorter(schema).OrderBy("LABEL", "-ID", "-INST.").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal(expected))
+
+	data = getData()
+	Expect(TableSorter(schema).OrderBy("LABEL:asc", "ID:desc", "INST.:DESC").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal(expected))
+
+	data = getData()
+	Expect(TableSorter(schema).OrderBy("-LABEL", "+ID", "INST.").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal([][]interface{}{
+		{4, "xxxx", 2.2},
+		{5, "wt11r444", 2.1},
+		{5, "wt11r444", 2.3},
+		{7, "wt11r444", 2.2},
+		{4, "str", 20.1},
+		{6, "st11r", 22.1},
+		{5, "at11r43", 2.2},
+	}))
+
+	Expect(TableSorter(schema).OrderBy("ID:sideways").SortErr(getData())).NotTo(Succeed())
+}
+
+func TestSortDescendingKeepsLastCellsLast(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "-DATE",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "RATIO",
+			FieldType: TypeFloat,
+		},
+	}
+
+	data := [][]interface{}{
+		{"yesterday", math.NaN()},
+		{"2020-01-01T00:00:00Z", 0.5},
+		{StringCell("N/A"), StringCell("N/A")},
+		{"2021-01-01T00:00:00Z", 1.5},
+	}
+
+	//-DATE is the name of the field, not a descending DATE
+	Expect(TableSorter(schema).OrderBy("-DATE").SortErr(data)).To(Succeed())
+	Expect(data[0][0]).To(Equal("2020-01-01T00:00:00Z"))
+
+	Expect(TableSorter(schema).OrderBy("--DATE").SortErr(data)).To(Succeed())
+	Expect(data[0][0]).To(Equal("2021-01-01T00:00:00Z"))
+	Expect(data[1][0]).To(Equal("2020-01-01T00:00:00Z"))
+	Expect(data[2][0]).To(Equal("yesterday"))
+	Expect(data[3][0]).To(Equal(StringCell("N/A")))
+
+	Expect(TableSorter(schema).OrderBy("RATIO:desc").SortErr(data)).To(Succeed())
+	Expect(data[0][1]).To(Equal(1.5))
+	Expect(data[1][1]).To(Equal(0.5))
+	Expect(math.IsNaN(data[2][1].(float64))).To(BeTrue())
+	Expect(data[3][1]).To(Equal(StringCell("N/A")))
+}
+
 func TestTableSortWithSchemaWithDateTime(t *testing.T) {
 
 	data := [][]interface{}{
@@ -105,6 +212,320 @@ func TestTableSortWithSchemaWithDateTime(t *testing.T) {
 	}
 }
 
+func TestTableSortWithSchemaWithBool(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, order := range []struct {
+		field    string
+		expected []bool
+	}{
+		{"ACTIVE", []bool{false, false, false, false, false, false, false, true, true, true, true, true, true, true}},
+		{"-ACTIVE", []bool{true, true, true, true, true, true, true, false, false, false, false, false, false, false}},
+	} {
+		data := [][]interface{}{
+			{1, true},
+			{2, false},
+			{3, true},
+			{4, false},
+			{5, false},
+			{6, true},
+			{7, false},
+			{8, true},
+			{9, true},
+			{10, false},
+			{11, true},
+			{12, false},
+			{13, true},
+			{14, false},
+		}
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName: "ACTIVE",
+				FieldType: TypeBool,
+			},
+		}
+		Expect(TableSorter(schema).OrderBy(order.field).SortErr(data)).To(BeNil())
+
+		values := []bool{}
+		for _, row := range data {
+			values = append(values, row[1].(bool))
+		}
+		Expect(values).To(Equal(order.expected), order.field)
+	}
+}
+
+func TestTableSortWithSchemaBoolThenInt(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, true},
+		{2, false},
+		{3, true},
+		{4, false},
+		{5, false},
+		{6, true},
+		{7, false},
+		{8, true},
+		{9, true},
+		{10, false},
+		{11, true},
+		{12, false},
+		{13, true},
+		{14, false},
+	}
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+	}
+	Expect(TableSorter(schema).OrderBy("ACTIVE", "-ID").SortErr(data)).To(BeNil())
+
+	ids := []int{}
+	for _, row := range data {
+		ids = append(ids, row[0].(int))
+	}
+	Expect(ids).To(Equal([]int{14, 12, 10, 7, 5, 4, 2, 13, 11, 9, 8, 6, 3, 1}))
+}
+
+func TestSortStringAndBoolCellsOfOtherTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{1, true},
+		{2, false},
+		{3, true},
+		{4, false},
+		{5, false},
+		{6, true},
+		{7, false},
+		{8, true},
+		{9, true},
+		{10, false},
+		{11, true},
+		{12, false},
+		{13, true},
+		{14, false},
+	}
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+	}
+	data[3][1] = "yes"
+	err := TableSorter(schema).OrderBy("ACTIVE").SortErr(data)
+	Expect(err).To(MatchError("row 3: cannot sort yes (string) in bool field ACTIVE"))
+
+	schema = []SchemaField{{FieldName: "LABEL", FieldType: TypeString}}
+	data = [][]interface{}{{"web-2"}, {nil}, {Cell{Value: 0, AsString: "none"}}, {"web-1"}}
+	Expect(TableSorter(schema).OrderBy("LABEL").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal([][]interface{}{{"web-1"}, {"web-2"}, {Cell{Value: 0, AsString: "none"}}, {nil}}))
+
+	data = append(data, []interface{}{2})
+	err = TableSorter(schema).OrderBy("LABEL").SortErr(data)
+	Expect(err).To(MatchError("row 4: cannot sort 2 (int) in string field LABEL"))
+}
+
+func TestOrderByFunc(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+	data := [][]interface{}{
+		{1, "ok"},
+		{2, "critical"},
+		{3, "warning"},
+		{4, nil},
+		{5, "critical"},
+		{6, "ok"},
+		{7, "warning"},
+	}
+
+	ranks := map[string]int{"critical": 0, "warning": 1, "ok": 2}
+	bySeverity := func(a, b interface{}) bool {
+		return ranks[a.(string)] < ranks[b.(string)]
+	}
+
+	Expect(TableSorter(schema).OrderByFunc("STATUS", bySeverity).OrderBy("ID").SortErr(data)).To(BeNil())
+	Expect(getIDs(data)).To(Equal([]int{2, 5, 3, 7, 1, 6, 4}))
+
+	Expect(TableSorter(schema).OrderByFunc("-STATUS", bySeverity).OrderBy("-ID").SortErr(data)).To(BeNil())
+	Expect(getIDs(data)).To(Equal([]int{6, 1, 7, 3, 5, 2, 4}))
+
+	err := TableSorter(schema).OrderBy("ID").OrderByFunc("SEVERITY", bySeverity).SortErr(data)
+	Expect(err).To(MatchError("could not find field with name SEVERITY"))
+}
+
+func TestSortBySchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{4, "str", 20.1},
+		{6, "st11r", 22.1},
+		{5, "wt11r444", 2.3},
+		{5, "wt11r444", 2.1},
+		{5, "at11r43", 2.2},
+		{4, "xxxx", 2.2},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName:    "ID",
+			FieldType:    TypeInt,
+			FieldSortKey: 1,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:    "INST.",
+			FieldType:    TypeFloat,
+			FieldSortKey: -2,
+		},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	err := table.SortBySchema()
+	Expect(err).To(BeNil())
+
+	expected := [][]interface{}{
+		{4, "str", 20.1},
+		{4, "xxxx", 2.2},
+		{5, "wt11r444", 2.3},
+		{5, "at11r43", 2.2},
+		{5, "wt11r444", 2.1},
+		{6, "st11r", 22.1},
+	}
+
+	Expect(data).To(Equal(expected))
+}
+
+func TestSortDescendingByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "KIND",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:                    "CREATED",
+			FieldType:                    TypeDateTime,
+			FieldSortDescendingByDefault: true,
+		},
+	}
+
+	getData := func() [][]interface{} {
+		return [][]interface{}{
+			{"b", "2020-01-02T00:00:00Z"},
+			{"a", "2020-01-01T00:00:00Z"},
+			{"a", "2020-01-03T00:00:00Z"},
+			{"b", "2020-01-04T00:00:00Z"},
+		}
+	}
+
+	getCreated := func(data [][]interface{}) []interface{} {
+		created := []interface{}{}
+		for _, row := range data {
+			created = append(created, row[1])
+		}
+		return created
+	}
+
+	//newest first without a direction
+	data := getData()
+	Expect(TableSorter(schema).OrderBy("CREATED").SortErr(data)).To(Succeed())
+	Expect(getCreated(data)).To(Equal([]interface{}{"2020-01-04T00:00:00Z", "2020-01-03T00:00:00Z", "2020-01-02T00:00:00Z", "2020-01-01T00:00:00Z"}))
+
+	//an explicit direction wins
+	data = getData()
+	Expect(TableSorter(schema).OrderBy("+CREATED").SortErr(data)).To(Succeed())
+	Expect(getCreated(data)).To(Equal([]interface{}{"2020-01-01T00:00:00Z", "2020-01-02T00:00:00Z", "2020-01-03T00:00:00Z", "2020-01-04T00:00:00Z"}))
+
+	//as the secondary key, after an explicit descending primary key
+	data = getData()
+	Expect(TableSorter(schema).OrderBy("-KIND", "CREATED").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal([][]interface{}{
+		{"b", "2020-01-04T00:00:00Z"},
+		{"b", "2020-01-02T00:00:00Z"},
+		{"a", "2020-01-03T00:00:00Z"},
+		{"a", "2020-01-01T00:00:00Z"},
+	}))
+
+	data = getData()
+	Expect(TableSorter(schema).OrderBy("KIND", "+CREATED").SortErr(data)).To(Succeed())
+	Expect(getCreated(data)).To(Equal([]interface{}{"2020-01-01T00:00:00Z", "2020-01-03T00:00:00Z", "2020-01-02T00:00:00Z", "2020-01-04T00:00:00Z"}))
+
+	Expect(TableSorter(schema).OrderBy("-MISSING").SortErr(getData())).NotTo(Succeed())
+
+	//SortBySchema consults it for positive keys
+	sortSchema := append([]SchemaField{}, schema...)
+	sortSchema[0].FieldSortKey = 1
+	sortSchema[1].FieldSortKey = 2
+	table := Table{Data: getData(), Schema: sortSchema}
+	Expect(table.SortBySchema()).To(Succeed())
+	Expect(getCreated(table.Data)).To(Equal([]interface{}{"2020-01-03T00:00:00Z", "2020-01-01T00:00:00Z", "2020-01-04T00:00:00Z", "2020-01-02T00:00:00Z"}))
+}
+
+func TestSortNotSortableField(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{2, "\x1b[31mb\x1b[0m"},
+		{1, "\x1b[32ma\x1b[0m"},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:        "STATUS",
+			FieldType:        TypeString,
+			FieldNotSortable: true,
+			FieldSortKey:     1,
+		},
+	}
+
+	err := TableSorter(schema).OrderBy("ID", "STATUS").SortErr(data)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("STATUS"))
+	Expect(data[0][0]).To(Equal(2))
+
+	table := Table{Data: data, Schema: schema}
+	Expect(table.SortBySchema()).NotTo(BeNil())
+
+	err = TableSorter(schema).OrderBy("UNKNOWN").SortErr(data)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("UNKNOWN"))
+
+	Expect(TableSorter(schema).OrderBy("ID").SortErr(data)).To(BeNil())
+	Expect(data[0][0]).To(Equal(1))
+}
+
 func TestDefaultTimeFormat(t *testing.T) {
 
 	layout := defaultTimeFormat
@@ -258,7 +679,7 @@ func TestGetTableAsJSONRegressionTest1(t *testing.T) {
 	Expect(data[0][1]).NotTo(Equal(data[0][2]))
 	Expect(data[0][1]).NotTo(Equal(data[0][2]))
 
-	ret, err := getTableAsJSONString(data, schema)
+	ret, err := getTableAsJSONString(data, schema, newRenderOptions())
 	Expect(err).To(BeNil())
 
 	var m []interface{}
@@ -292,7 +713,7 @@ func TestGetTableHeader(t *testing.T) {
 	}
 	expected := "| ID    | LABEL               | INST. |"
 
-	actual := getTableHeader(schema)
+	actual := getTableHeader(schema, newRenderOptions())
 
 	if actual != expected {
 		t.Errorf("Header is not correct, \nexpected:  %s\n     was: %s", expected, actual)
@@ -327,12 +748,81 @@ func TestGetTableRow(t *testing.T) {
 
 	row := []interface{}{10, "test", 33.3, map[string]string{"test": "test1", "test2": "test3"}}
 
-	actual := getTableRow(row, schema)
+	actual := getTableRow(row, schema, newRenderOptions())
 
 	Expect(actual).To(ContainSubstring("test1"))
 	Expect(actual).To(ContainSubstring("test3"))
 }
 
+func TestRenderBoolsMapsAndNils(t *testing.T) {
+	RegisterTestingT(t)
+	schema := []SchemaField{
+		{
+			FieldName: "ENABLED",
+			FieldType: TypeBool,
+			FieldSize: 6,
+		},
+		{
+			FieldName: "TAGS",
+			FieldType: TypeInterface,
+			FieldSize: 6,
+		},
+		{
+			FieldName: "COUNT",
+			FieldType: TypeInterface,
+			FieldSize: 3,
+		},
+		{
+			FieldName: "OWNER",
+			FieldType: TypeInterface,
+			FieldSize: 3,
+		},
+	}
+
+	data := [][]interface{}{
+		{true, map[string]int{"a": 1, "b": 2}, 5, nil},
+		{false, map[string]int{}, -5, nil},
+	}
+
+	row := getTableRow(data[0], schema, newRenderOptions())
+	Expect(row).To(Equal("| true  | map[a:1 b:2]| 5  |    |"))
+
+	table := Table{Data: data, Schema: schema}
+	s, err := table.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+---------+--------------+-------+-------+
+| ENABLED | TAGS         | COUNT | OWNER |
++---------+--------------+-------+-------+
+| true    | map[a:1 b:2] | 5     |       |
+| false   | map[]        | -5    |       |
++---------+--------------+-------+-------+
+Total: 2 test
+
+`))
+
+	s, err = table.RenderTable("test", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`ENABLED,TAGS,COUNT,OWNER
+true,map[a:1 b:2],5,
+false,map[],-5,
+`))
+
+	table = Table{Data: data[:1], Schema: schema}
+	s, err = table.RenderTransposedTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+---------+--------------+
+| KEY     | VALUE        |
++---------+--------------+
+| ENABLED | true         |
+| TAGS    | map[a:1 b:2] |
+| COUNT   | 5            |
+| OWNER   |              |
++---------+--------------+
+Total: 4 test
+
+`))
+}
+
 func TestGetTableDelimiter(t *testing.T) {
 	schema := []SchemaField{
 		{
@@ -355,7 +845,7 @@ func TestGetTableDelimiter(t *testing.T) {
 
 	expected := "+-------+---------------------+-------+"
 
-	actual := getTableDelimiter(schema)
+	actual := getTableDelimiter(schema, newRenderOptions())
 
 	if actual != expected {
 		t.Errorf("Delimiter is not correct, \nexpected: %s\n     was: %s", expected, actual)
@@ -397,7 +887,7 @@ func TestGetTableAsString(t *testing.T) {
 		{6, "st11r444", 2.1},
 	}
 
-	actual := getTableAsString(data, schema)
+	actual := getTableAsString(data, schema, newRenderOptions())
 
 	if actual != expected {
 		t.Errorf("Delimiter is not correct, \nexpected:\n%s\nwas:\n%s", expected, actual)
@@ -431,7 +921,7 @@ func TestGetTableAsJSONString(t *testing.T) {
 		{6, "st11r444", 2.1},
 	}
 
-	ret, err := getTableAsJSONString(data, schema)
+	ret, err := getTableAsJSONString(data, schema, newRenderOptions())
 	if err != nil {
 		t.Errorf("%s", err)
 	}
@@ -448,32 +938,98 @@ func TestGetTableAsJSONString(t *testing.T) {
 
 }
 
-func TestGetTableAsCSVString(t *testing.T) {
-
+func TestGetTableAsOrderedJSONString(t *testing.T) {
+	RegisterTestingT(t)
 	schema := []SchemaField{
 		{
-			FieldName: "ID",
+			FieldName: "INDEX",
 			FieldType: TypeInt,
-			FieldSize: 6,
 		},
 		{
-			FieldName: "LABEL",
+			FieldName: "PROTOCOL",
 			FieldType: TypeString,
-			FieldSize: 20,
 		},
 		{
-			FieldName:      "INST.",
-			FieldType:      TypeFloat,
-			FieldSize:      6,
-			FieldPrecision: 2,
+			FieldName: "ENABLED",
+			FieldType: TypeBool,
+		},
+		{
+			FieldName: "INST.",
+			FieldType: TypeFloat,
+		},
+		{
+			FieldName: "DESC.",
+			FieldType: TypeInterface,
 		},
 	}
 
-	expected :=
-		`ID,LABEL,INST.
-4,str,20.100000
-5,st11r,22.100000
-6,st11r444,2.100000
+	data := [][]interface{}{
+		{4, "tcp", true, 20.1, nil},
+		{5, "<udp>", false, 2.0, map[string]string{"b": "1", "a": "2"}},
+	}
+
+	ret, err := getTableAsOrderedJSONString(data, schema, newRenderOptions())
+	Expect(err).To(BeNil())
+	Expect(ret).To(Equal(`[
+	{
+		"INDEX": 4,
+		"PROTOCOL": "tcp",
+		"ENABLED": true,
+		"INST.": 20.1,
+		"DESC.": null
+	},
+	{
+		"INDEX": 5,
+		"PROTOCOL": "\u003cudp\u003e",
+		"ENABLED": false,
+		"INST.": 2,
+		"DESC.": {
+			"a": "2",
+			"b": "1"
+		}
+	}
+]`))
+
+	unordered, err := getTableAsJSONString(data, schema, newRenderOptions())
+	Expect(err).To(BeNil())
+
+	var m1, m2 []interface{}
+	Expect(json.Unmarshal([]byte(ret), &m1)).To(BeNil())
+	Expect(json.Unmarshal([]byte(unordered), &m2)).To(BeNil())
+	Expect(m1).To(Equal(m2))
+
+	table := Table{Data: data, Schema: schema}
+	s, err := table.RenderTable("test", "", "json-ordered")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(ret))
+}
+
+func TestGetTableAsCSVString(t *testing.T) {
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 6,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 20,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldSize:      6,
+			FieldPrecision: 2,
+		},
+	}
+
+	expected :=
+		`ID,LABEL,INST.
+4,str,20.100000
+5,st11r,22.100000
+6,st11r444,2.100000
 `
 
 	data := [][]interface{}{
@@ -482,7 +1038,7 @@ func TestGetTableAsCSVString(t *testing.T) {
 		{6, "st11r444", 2.1},
 	}
 
-	actual, err := getTableAsCSVString(data, schema)
+	actual, err := getTableAsCSVString(data, schema, &CSVOptions{})
 	if err != nil {
 		t.Errorf("%s", err)
 	}
@@ -523,7 +1079,7 @@ func TestAdjustFieldSizes(t *testing.T) {
 		{5, "12", 22.1, "te"},
 		{6, "12345\n6789", 1.2345, "t"},
 	}
-	table := Table{data, schema}
+	table := Table{Data: data, Schema: schema}
 	table.AdjustFieldSizes()
 
 	Expect(schema[0].FieldSize).To(Equal(3))
@@ -567,7 +1123,7 @@ func TestRenderTable(t *testing.T) {
 		{6, "123456789", 1.2345, "t"},
 	}
 
-	table := Table{data, schema}
+	table := Table{Data: data, Schema: schema}
 	s, err := table.RenderTable("test", "", "")
 
 	Expect(err).To(BeNil())
@@ -588,6 +1144,107 @@ func TestRenderTable(t *testing.T) {
 	Expect(err).To(BeNil())
 }
 
+func TestRenderTableAsYAMLDocs(t *testing.T) {
+	RegisterTestingT(t)
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{4, "str"}, {5, "st11r"}}, Schema: schema}
+	s, err := table.RenderTable("test", "", "yaml-docs")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`id: 4
+label: str
+---
+id: 5
+label: st11r
+`))
+
+	decoder := yaml.NewDecoder(strings.NewReader(s))
+	docs := 0
+	for {
+		var m map[string]interface{}
+		if decoder.Decode(&m) != nil {
+			break
+		}
+		docs++
+	}
+	Expect(docs).To(Equal(2))
+
+	table = Table{Data: [][]interface{}{{4, "str"}}, Schema: schema}
+	s, err = table.RenderTable("test", "", "yaml-docs")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("id: 4\nlabel: str\n"))
+
+	table = Table{Data: [][]interface{}{}, Schema: schema}
+	s, err = table.RenderTable("test", "", "yaml-docs")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(""))
+}
+
+func TestRenderTableWithRawRows(t *testing.T) {
+	RegisterTestingT(t)
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 3,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 6,
+		},
+	}
+
+	data := [][]interface{}{
+		{4, "str"},
+		NewRawRow("|====|=======|"),
+		{5, "st11r"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	s, err := table.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`+----+-------+
+| ID | LABEL |
++----+-------+
+| 4  | str   |
+|====|=======|
+| 5  | st11r |
++----+-------+
+Total: 2 test
+
+`))
+
+	s, err = table.RenderTable("test", "", "json")
+	Expect(err).To(BeNil())
+	m, err := JSONUnmarshal(s)
+	Expect(err).To(BeNil())
+	Expect(len(m)).To(Equal(2))
+
+	s, err = table.RenderTable("test", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,LABEL\n4,str\n5,st11r\n"))
+
+	_, err = table.RenderTable("test", "", "csv", WithStrictRawRows())
+	Expect(err).NotTo(BeNil())
+
+	_, err = table.RenderTable("test", "", "", WithStrictRawRows())
+	Expect(err).To(BeNil())
+
+	s, err = table.RenderTableFoldable("test", "", "", 1)
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("|====|=======|\n"))
+}
+
 func TestYAMLMArshalOfMetalcloudObjects(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -700,7 +1357,7 @@ func TestTransposeTable(t *testing.T) {
 		{21, 22, 23},
 		{31, 32, 33},
 	}
-	table := Table{data, nil}
+	table := Table{Data: data, Schema: nil}
 	tableT := TransposeTable(table)
 
 	expectedDataT := [][]interface{}{
@@ -765,7 +1422,7 @@ func TestRenderTransposedTable(t *testing.T) {
 		{4, "12345", 20.1, "tes"},
 	}
 
-	table := Table{data, schema}
+	table := Table{Data: data, Schema: schema}
 	s, err := table.RenderTransposedTable("test", "", "")
 
 	Expect(err).To(BeNil())
@@ -842,7 +1499,7 @@ func TestRenderTransposedTableHumanReadable(t *testing.T) {
 		},
 	}
 
-	table := Table{data, schema}
+	table := Table{Data: data, Schema: schema}
 	s, err := table.RenderTransposedTableHumanReadable("test", "test")
 
 	Expect(err).To(BeNil())
@@ -889,6 +1546,39 @@ func TestRenderRawObject(t *testing.T) {
 
 }
 
+func TestDetectCommonPrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(DetectCommonPrefix([]string{"InstanceArrayID", "InstanceArrayLabel", "InfrastructureID"})).To(Equal("InstanceArray"))
+	Expect(DetectCommonPrefix([]string{"InstanceArrayID", "InstanceLabel", "InfrastructureID"})).To(Equal("Instance"))
+	Expect(DetectCommonPrefix([]string{"ID", "Label", "Status"})).To(Equal(""))
+	Expect(DetectCommonPrefix([]string{"ServerIDString", "ServerIDNumber"})).To(Equal("ServerID"))
+	Expect(DetectCommonPrefix([]string{"ServerID", "ServerIDNumber"})).To(Equal("Server"))
+	Expect(DetectCommonPrefix(nil)).To(Equal(""))
+}
+
+func TestRenderRawObjectWithAutoStripPrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	var sw metalcloud.SwitchDevice
+
+	err := json.Unmarshal([]byte(_switchDeviceFixture1), &sw)
+	Expect(err).To(BeNil())
+
+	table, err := ObjectToTableWithFormatter(sw, NewAutoStripPrefixFormatter(sw))
+	Expect(err).To(BeNil())
+	Expect(table.Schema[1].FieldName).To(Equal("Identifier String"))
+	Expect(table.Schema[39].FieldName).To(Equal("Volume Template Id"))
+
+	ret, err := RenderRawObject(sw, "", "", WithAutoStripPrefix())
+	Expect(err).To(BeNil())
+	Expect(ret).To(ContainSubstring("Identifier String: UK_RDG_EVR01_00_0001_00A9_01"))
+
+	ret, err = RenderRawObject(sw, "", "")
+	Expect(err).To(BeNil())
+	Expect(ret).To(ContainSubstring("Network Equipment Identifier String: UK_RDG_EVR01_00_0001_00A9_01"))
+}
+
 func TestGetRowSize(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -966,10 +1656,113 @@ func TestGetTableRowMultiline(t *testing.T) {
 |    | 45   |        | dasda           |
 |    |      |        | sdasd           |`
 
-	s := getTableRow(data[0], schema)
+	s := getTableRow(data[0], schema, newRenderOptions())
+	t.Logf("%s", s)
+
+	Expect(s).To(Equal(expected))
+}
+
+func TestGetTableRowMultilineNormalized(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{4, "123\n45", 20.1, "teklkkkllklklsas\ndasda\nsdasd"},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 3,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 5,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldSize:      0,
+			FieldPrecision: 4,
+		},
+		{
+			FieldName:      "VERY LONG FIELD NAME",
+			FieldType:      TypeString,
+			FieldSize:      4,
+			FieldPrecision: 4,
+		},
+	}
+
+	expected :=
+		`| 4  | 123  | 20.1000 | teklkkkllklklsas |
+|    | 45   |         | dasda            |
+|    |      |         | sdasd            |`
+
+	s := getTableRow(data[0], schema, newRenderOptions(WithNormalizedTrailingSpace()))
+	t.Logf("%s", s)
+
+	Expect(s).To(Equal(expected))
+}
+
+func TestGetTableAsStringNormalized(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{4, "123\n45", 20.1, "teklkkkllklklsas\ndasda"},
+		{5, "12", 22.1, "te"},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 1,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 5,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldSize:      0,
+			FieldPrecision: 4,
+		},
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+			FieldSize: 4,
+		},
+	}
+
+	expected :=
+		`+----+-------+---------+------------------+
+| ID | LABEL | INST.   | NAME             |
++----+-------+---------+------------------+
+| 4  | 123   | 20.1000 | teklkkkllklklsas |
+|    | 45    |         | dasda            |
+| 5  | 12    | 22.1000 | te               |
++----+-------+---------+------------------+
+`
+
+	s := getTableAsString(data, schema, newRenderOptions(WithNormalizedTrailingSpace()))
 	t.Logf("%s", s)
 
 	Expect(s).To(Equal(expected))
+	Expect(schema[2].FieldSize).To(Equal(0))
+
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for _, line := range lines {
+		Expect(len(line)).To(Equal(len(lines[0])))
+		Expect(line).NotTo(HaveSuffix(" "))
+	}
+
+	table := Table{Data: data, Schema: schema}
+	s, err := table.RenderTable("test", "", "", WithNormalizedTrailingSpace())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 20.1000 |"))
 }
 
 func TestGetFoldedTableAsString(t *testing.T) {
@@ -1011,27 +1804,351 @@ func TestGetFoldedTableAsString(t *testing.T) {
 | Values                   |
 +--------------------------+
 | - id: 4                  |
-|   inst: 20.1             |
+|   inst: "20.1000"        |
 |   label: "12345"         |
 |   veryLongFieldName: tes |
 |                          |
 | - id: 5                  |
-|   inst: 22.1             |
+|   inst: "22.1000"        |
 |   label: "12"            |
 |   veryLongFieldName: te  |
 |                          |
 | - id: 6                  |
-|   inst: 1.2345           |
+|   inst: "1.2345"         |
 |   label: "123456789"     |
 |   veryLongFieldName: t   |
 |                          |
 +--------------------------+
 `
 
-	s, err := getFoldedTableAsString(data, schema)
+	s, err := getFoldedTableAsString(data, schema, newRenderOptions())
 	t.Logf("%s", s)
 	Expect(err).To(BeNil())
 	Expect(s).To(Equal(expected))
 }
 
+func TestGetFoldedTableAsStringWithKeyColumns(t *testing.T) {
+	RegisterTestingT(t)
+
+	data := [][]interface{}{
+		{4, "12345", 20.1, "tes"},
+		NewRawRow("--- raw ---"),
+		{5, "12", 22.1, "te"},
+	}
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldPrecision: 4,
+		},
+		{
+			FieldName: "VERY LONG FIELD NAME",
+			FieldType: TypeString,
+		},
+	}
+
+	expected :=
+		`+----+-------+--------------------------+
+| ID | LABEL | Values                   |
++----+-------+--------------------------+
+| 4  | 12345 | - inst: "20.1000"        |
+|    |       |   veryLongFieldName: tes |
+|    |       |                          |
+--- raw ---
+| 5  | 12    | - inst: "22.1000"        |
+|    |       |   veryLongFieldName: te  |
+|    |       |                          |
++----+-------+--------------------------+
+`
+
+	s, err := getFoldedTableAsString(data, schema, newRenderOptions(WithFoldKeyColumns("ID", "LABEL")))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	table := Table{Data: data, Schema: schema}
+	s, err = table.RenderTable("test", "", "", WithFoldAtLength(10), WithFoldKeyColumns("ID", "LABEL"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected + "Total: 2 test\n\n"))
+
+	_, err = table.RenderTable("test", "", "", WithFoldAtLength(10), WithFoldKeyColumns("UNKNOWN"))
+	Expect(err).NotTo(BeNil())
+
+	//the key columns are only used by the folded layout
+	s, err = table.RenderTable("test", "", "", WithFoldKeyColumns("ID"))
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("Values"))
+}
+
+func TestFoldedAndUnfoldedCellsAgree(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02 15:04",
+		},
+		{
+			FieldName: "UPTIME",
+			FieldType: TypeDuration,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+	}
+	data := [][]interface{}{
+		{1, 20.1, time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC), 90 * time.Minute, true},
+		{2, 3.0, time.Date(2021, 1, 2, 9, 30, 0, 0, time.UTC), 36 * time.Hour, false},
+	}
+	table := Table{Data: data, Schema: schema}
+
+	unfolded, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	folded, err := table.RenderTable("servers", "", "", WithFoldAtLength(10))
+	Expect(err).To(BeNil())
+	Expect(folded).To(ContainSubstring("Values"))
+
+	for _, row := range data {
+		for i := range schema {
+			value := getColumnCellString(row[i], &schema[i])
+			Expect(unfolded).To(ContainSubstring(" "+value+" "), value)
+			Expect(folded).To(MatchRegexp(`: "?`+regexp.QuoteMeta(value)+`"? `), value)
+		}
+	}
+}
+
+func TestRenderColumnHelp(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName:        "ID",
+			FieldType:        TypeInt,
+			FieldDescription: "the id of the item",
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{10, "test"}}, Schema: schema}
+
+	expected :=
+		`+--------+--------------------+
+| COLUMN | DESCRIPTION        |
++--------+--------------------+
+| ID     | the id of the item |
+| LABEL  |                    |
++--------+--------------------+
+`
+
+	s := table.RenderColumnHelp()
+	t.Logf("%s", s)
+	Expect(s).To(Equal(expected))
+
+	s, err := table.RenderTable("test", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("the id of the item"))
+}
+
 const _switchDeviceFixture1 = "{\"network_equipment_id\":1,\"datacenter_name\":\"uk-reading\",\"network_equipment_driver\":\"hp5900\",\"network_equipment_position\":\"tor\",\"network_equipment_provisioner_type\":\"vpls\",\"network_equipment_identifier_string\":\"UK_RDG_EVR01_00_0001_00A9_01\",\"network_equipment_description\":\"HP Comware Software, Version 7.1.045, Release 2311P06\",\"network_equipment_management_address\":\"10.0.0.0\",\"network_equipment_management_port\":22,\"network_equipment_management_username\":\"sad\",\"network_equipment_quarantine_vlan\":5,\"network_equipment_quarantine_subnet_start\":\"11.16.0.1\",\"network_equipment_quarantine_subnet_end\":\"11.16.0.00\",\"network_equipment_quarantine_subnet_prefix_size\":24,\"network_equipment_quarantine_subnet_gateway\":\"11.16.0.1\",\"network_equipment_primary_wan_ipv4_subnet_pool\":\"11.24.0.2\",\"network_equipment_primary_wan_ipv4_subnet_prefix_size\":22,\"network_equipment_primary_san_subnet_pool\":\"100.64.0.0\",\"network_equipment_primary_san_subnet_prefix_size\":21,\"network_equipment_primary_wan_ipv6_subnet_pool_id\":1,\"network_equipment_primary_wan_ipv6_subnet_cidr\":\"2A02:0CB8:0000:0000:0000:0000:0000:0000/53\",\"network_equipment_cached_updated_timestamp\":\"2020-08-04T20:11:49Z\",\"network_equipment_management_protocol\":\"ssh\",\"chassis_rack_id\":null,\"network_equipment_cache_wrapper_json\":null,\"network_equipment_cache_wrapper_phpserialize\":\"\",\"network_equipment_tor_linked_id\":null,\"network_equipment_uplink_ip_addresses_json\":null,\"network_equipment_management_address_mask\":null,\"network_equipment_management_address_gateway\":null,\"network_equipment_requires_os_install\":false,\"network_equipment_management_mac_address\":\"00:00:00:00:00:00\",\"volume_template_id\":null,\"network_equipment_country\":null,\"network_equipment_city\":null,\"network_equipment_datacenter\":null,\"network_equipment_datacenter_room\":null,\"network_equipment_datacenter_rack\":null,\"network_equipment_rack_position_upper_unit\":null,\"network_equipment_rack_position_lower_unit\":null,\"network_equipment_serial_numbers\":null,\"network_equipment_info_json\":null,\"network_equipment_management_subnet\":null,\"network_equipment_management_subnet_prefix_size\":null,\"network_equipment_management_subnet_start\":null,\"network_equipment_management_subnet_end\":null,\"network_equipment_management_subnet_gateway\":null,\"datacenter_id_parent\":null,\"network_equipment_dhcp_packet_sniffing_is_enabled\":1,\"network_equipment_driver_dump_cached_json\":null,\"network_equipment_tags\":[],\"network_equipment_management_password\":\"ddddd\"}"
+
+func TestRenderTransposedTableHumanReadableWrapped(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "Description",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "Config",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{
+			10,
+			"a rather long description that does not fit",
+			"key: value\n\nother: value",
+		},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderTransposedTableHumanReadable("", "", WithWrapWidth(30))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`ID: 10
+Description: a rather long
+             description that
+             does not fit
+Config: key: value
+
+        other: value
+`))
+
+	s, err = table.RenderTransposedTableHumanReadable("", "", WithAlignedKeys(), WithKeyValueSeparator(" = "))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(`ID          = 10
+Description = a rather long description that does not fit
+Config      = key: value
+
+              other: value
+`))
+}
+
+func TestGetTableAsStringWithOverflowingCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+			FieldSize: 3,
+		},
+		{
+			FieldName:      "PRICE",
+			FieldType:      TypeFloat,
+			FieldSize:      3,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+			FieldSize: 10,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, 1.5, "short"},
+		{123456, 123456.789, "second"},
+	}
+
+	s := getTableAsString(data, schema, newRenderOptions())
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for _, line := range lines {
+		Expect(VisibleWidth(line)).To(Equal(VisibleWidth(lines[0])), line)
+	}
+	Expect(lines[4]).To(Equal("| 123456| 123456.79| second    |"))
+
+	//the schema of the caller is not changed
+	Expect(schema[0].FieldSize).To(Equal(3))
+}
+
+func TestFieldWrapAt(t *testing.T) {
+	RegisterTestingT(t)
+
+	lines := []string{
+		"Wrapping keeps long cells of",
+		"the descriptions readable in",
+		"narrow terminals and breaks",
+		"every line on spaces between",
+		"the words so that columns to",
+		"the right of the wrapped cell",
+		"stay as wide as they were.",
+	}
+	sentence := strings.Join(lines, " ")
+	Expect(sentence).To(HaveLen(200))
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:   "DESCRIPTION",
+			FieldType:   TypeString,
+			FieldWrapAt: 30,
+		},
+	}
+	table := Table{Data: [][]interface{}{{1, sentence}, {2, "short"}}, Schema: schema}
+
+	Expect(ColumnWidths(table.Data, table.Schema)).To(Equal([]int{2, 29}))
+
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	expected := "+----+-------------------------------+\n" +
+		"| ID | DESCRIPTION                   |\n" +
+		"+----+-------------------------------+\n"
+	for k, line := range lines {
+		id := "  "
+		if k == 0 {
+			id = "1 "
+		}
+		expected += fmt.Sprintf("| %s | %-30s|\n", id, line)
+	}
+	expected += "| 2  | short                         |\n" +
+		"+----+-------------------------------+\n" +
+		"Total: 2 servers\n\n"
+	Expect(s).To(Equal(expected))
+
+	//long words are broken anywhere and the new lines of the cell are kept
+	table.Data = [][]interface{}{{1, strings.Repeat("x", 35) + "\nend"}}
+	cells, err := FormatCells(table.Data[0], table.Schema)
+	Expect(err).To(BeNil())
+	Expect(cells[1]).To(Equal([]string{strings.Repeat("x", 30), "xxxxx" + emptyString(25), "end" + emptyString(27)}))
+
+	//the json format is not wrapped
+	s, err = table.RenderTable("servers", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(strings.Repeat("x", 35)))
+}
+
+func BenchmarkRenderTableText(b *testing.B) {
+	schema := []SchemaField{
+		{FieldName: "ID", FieldType: TypeInt},
+		{FieldName: "LABEL", FieldType: TypeString, FieldSize: 20},
+		{FieldName: "COST", FieldType: TypeFloat, FieldPrecision: 2},
+		{FieldName: "CREATED", FieldType: TypeDateTime},
+		{FieldName: "ACTIVE", FieldType: TypeBool},
+	}
+	table := GenerateSampleTable(schema, 50000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := table.RenderTable("servers", "", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRenderTableCSV(b *testing.B) {
+	schema := []SchemaField{
+		{FieldName: "ID", FieldType: TypeInt},
+		{FieldName: "LABEL", FieldType: TypeString, FieldSize: 20},
+		{FieldName: "COST", FieldType: TypeFloat, FieldPrecision: 2},
+		{FieldName: "CREATED", FieldType: TypeDateTime},
+		{FieldName: "ACTIVE", FieldType: TypeBool},
+	}
+	table := GenerateSampleTable(schema, 50000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := table.RenderTable("servers", "", "csv"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
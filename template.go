@@ -0,0 +1,66 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//templateTable is the data passed to the templates of RenderWithTemplate
+type templateTable struct {
+	//Schema is the schema of the table
+	Schema []SchemaField
+	//Data are the rows of the table including the computed cells, without the raw rows
+	Data [][]interface{}
+	//Rows are the rows of Data as maps keyed by field name
+	Rows []map[string]interface{}
+}
+
+//RenderWithTemplate renders the table with a text/template. The template is executed with
+//.Schema, .Data (the rows, including the computed cells, without the raw rows) and .Rows (the rows as maps
+//keyed by field name, such as {{range .Rows}}{{.ID}}={{.LABEL}}{{end}}). The helper functions are
+//format (formats a cell like the text format: {{format "COST" .COST}}), decolorize and pad.
+func (t *Table) RenderWithTemplate(tmpl string) (string, error) {
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return "", err
+	}
+	data, err := computeColumns(t.getData(), t.Schema)
+	if err != nil {
+		return "", err
+	}
+	data = withoutRawRows(data)
+	schema := t.getResolvedSchema()
+
+	rows := make([]map[string]interface{}, len(data))
+	for k, row := range data {
+		rows[k] = make(map[string]interface{}, len(schema))
+		for i, field := range schema {
+			rows[k][field.FieldName] = row[i]
+		}
+	}
+
+	funcs := template.FuncMap{
+		"format": func(fieldName string, value interface{}) (string, error) {
+			i := getFieldIndex(schema, fieldName)
+			if i == -1 {
+				return "", fmt.Errorf("could not find field with name %s", fieldName)
+			}
+			return strings.Join(getCellLines(value, &schema[i]), "\n"), nil
+		},
+		"decolorize": decolorize,
+		"pad":        pad,
+	}
+
+	parsed, err := template.New("table").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := parsed.Execute(&sb, templateTable{Schema: schema, Data: data, Rows: rows}); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,52 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderWithTemplate(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:      "INST.",
+			FieldType:      TypeFloat,
+			FieldPrecision: 2,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "\x1b[31mweb\x1b[0m", 1.5},
+		NewRawRow("skipped"),
+		{2, "db", 2.0},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.RenderWithTemplate("{{range .Rows}}{{.ID}}={{decolorize .LABEL}}\n{{end}}")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("1=web\n2=db\n"))
+
+	s, err = table.RenderWithTemplate(`{{range .Schema}}{{pad .FieldName 6}}|{{end}}{{range .Rows}}{{format "INST." (index . "INST.")}};{{end}}`)
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID    |LABEL |INST. |1.50;2.00;"))
+
+	_, err = table.RenderWithTemplate("{{range .Rows}")
+	Expect(err).NotTo(BeNil())
+
+	_, err = table.RenderWithTemplate(`{{format "UNKNOWN" 1}}`)
+	Expect(err).NotTo(BeNil())
+
+	_, err = table.RenderWithTemplate(`{{.Missing}}`)
+	Expect(err).NotTo(BeNil())
+}
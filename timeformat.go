@@ -0,0 +1,185 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	//TimeLayoutEpochSeconds is a layout for date time cells holding the number of seconds since the unix epoch,
+	//either as numbers or as strings
+	TimeLayoutEpochSeconds = "epoch"
+	//TimeLayoutEpochMilliseconds is a layout for date time cells holding the number of milliseconds since the unix epoch,
+	//either as numbers or as strings
+	TimeLayoutEpochMilliseconds = "epoch-ms"
+)
+
+//timeLayoutSeparator separates the layouts of a FieldFormat or TimeFormat that holds more than one
+const timeLayoutSeparator = "|"
+
+//registeredTimeLayouts are tried after the layouts of the field when parsing date time cells
+var registeredTimeLayouts []string
+
+//RegisterTimeLayout adds a layout tried, after the layouts of the field, when parsing the date time cells of any table,
+//except those of the fields with FieldIgnoreRegisteredLayouts. The layout can be one of TimeLayoutEpochSeconds
+//and TimeLayoutEpochMilliseconds. It is not used for formatting.
+func RegisterTimeLayout(layout string) {
+	registeredTimeLayouts = append(registeredTimeLayouts, layout)
+}
+
+//getTimeLayouts returns the layouts tried in order when parsing the date time cells of a field: those of the FieldFormat
+//(or of DefaultTimeFormat if the field has none) separated by |, followed by the registered layouts unless
+//the field has FieldIgnoreRegisteredLayouts
+func getTimeLayouts(field *SchemaField) []string {
+	format := field.FieldFormat
+	if format == "" {
+		format = DefaultTimeFormat
+	}
+	layouts := strings.Split(format, timeLayoutSeparator)
+	if field.FieldIgnoreRegisteredLayouts {
+		return layouts
+	}
+	return append(layouts, registeredTimeLayouts...)
+}
+
+//isNilTime returns true if the cell is a nil *time.Time, which date time fields render like a nil cell
+func isNilTime(d interface{}) bool {
+	tm, ok := d.(*time.Time)
+	return ok && tm == nil
+}
+
+//parseDateTimeCell returns the time held by a date time cell, either a time.Time, a non nil *time.Time or a value
+//in one of the layouts of the field
+func parseDateTimeCell(d interface{}, field *SchemaField) (time.Time, bool) {
+	switch v := d.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	}
+	for _, layout := range getTimeLayouts(field) {
+		if tm, ok := parseTime(d, layout); ok {
+			return tm, true
+		}
+	}
+	return time.Time{}, false
+}
+
+//parseTime parses a cell with a single layout. Only the epoch layouts accept numbers.
+func parseTime(d interface{}, layout string) (time.Time, bool) {
+	if layout == TimeLayoutEpochSeconds || layout == TimeLayoutEpochMilliseconds {
+		var n float64
+		switch v := d.(type) {
+		case int:
+			n = float64(v)
+		case int64:
+			n = float64(v)
+		case float64:
+			n = v
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return time.Time{}, false
+			}
+			n = f
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return time.Time{}, false
+			}
+			n = f
+		default:
+			return time.Time{}, false
+		}
+		if layout == TimeLayoutEpochMilliseconds {
+			n /= 1000
+		}
+		sec := int64(n)
+		return time.Unix(sec, int64((n-float64(sec))*1e9)).UTC(), true
+	}
+
+	s, ok := d.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	tm, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return tm, true
+}
+
+//formatTime formats a time with a layout, which can be one of the epoch layouts. The time is converted to UTC first
+//if the layout writes a literal Z, such as DefaultTimeFormat, see hasLiteralZ.
+func formatTime(tm time.Time, layout string) string {
+	switch layout {
+	case TimeLayoutEpochSeconds:
+		return strconv.FormatInt(tm.Unix(), 10)
+	case TimeLayoutEpochMilliseconds:
+		return strconv.FormatInt(tm.UnixNano()/int64(time.Millisecond), 10)
+	default:
+		if hasLiteralZ(layout) {
+			tm = tm.UTC()
+		}
+		return tm.Format(layout)
+	}
+}
+
+//hasLiteralZ returns true if the layout writes a literal Z, such as the Z of 2006-01-02T15:04:05Z, which is only
+//right for the times in UTC. The Z of the zone elements, such as Z07:00, is not literal.
+func hasLiteralZ(layout string) bool {
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == 'Z' && !strings.HasPrefix(layout[i+1:], "07") {
+			return true
+		}
+	}
+	return false
+}
+
+//getResolvedSchema returns the schema with the TimeFormat of the table set as the FieldFormat of the date time
+//fields that do not have one, so that sorting and every renderer use the same layout:
+//FieldFormat, then Table.TimeFormat, then DefaultTimeFormat. The schema of the table is returned as is if
+//the table has no TimeFormat.
+func (t *Table) getResolvedSchema() []SchemaField {
+	if t.TimeFormat == "" {
+		return t.Schema
+	}
+
+	schema := make([]SchemaField, len(t.Schema))
+	copy(schema, t.Schema)
+	for i := range schema {
+		if schema[i].FieldType == TypeDateTime && schema[i].FieldFormat == "" {
+			schema[i].FieldFormat = t.TimeFormat
+		}
+	}
+	return schema
+}
+
+//getDateTimeAsString formats the time.Time cells and the cells in one of the layouts of the field with its output layout,
+//see getOutputTimeLayout. A nil *time.Time is written as the FieldDefault of the field, like a nil cell, and the cells
+//that cannot be parsed are formatted like interface cells.
+func getDateTimeAsString(d interface{}, field *SchemaField) string {
+	if isNilTime(d) {
+		return field.FieldDefault
+	}
+	if tm, ok := parseDateTimeCell(d, field); ok {
+		return formatTime(tm, getOutputTimeLayout(field))
+	}
+	return getInterfaceAsString(d)
+}
+
+//getExportedCell returns the value of a cell as written by the json and yaml formats. A Cell is written as its Value.
+func getExportedCell(d interface{}, field *SchemaField) interface{} {
+	if c, ok := d.(Cell); ok {
+		d = c.Value
+	}
+	if d == nil {
+		return nil
+	}
+	return getFieldTypeHandler(field).MarshalValue(d, field)
+}
@@ -0,0 +1,317 @@
+package tableformatter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTimeFormatResolution(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(format string) { DefaultTimeFormat = format }(DefaultTimeFormat)
+
+	early := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	late := time.Date(2021, 11, 12, 13, 14, 15, 0, time.UTC)
+
+	cases := []struct {
+		name              string
+		fieldFormat       string
+		tableFormat       string
+		defaultTimeFormat string
+		expectedLayout    string
+	}{
+		{"field format", "02.01.2006", "2006/01/02", "Jan 2 2006", "02.01.2006"},
+		{"table format", "", "2006/01/02", "Jan 2 2006", "2006/01/02"},
+		{"package default", "", "", "Jan 2 2006", "Jan 2 2006"},
+		{"built-in default", "", "", defaultTimeFormat, defaultTimeFormat},
+	}
+
+	for _, c := range cases {
+		DefaultTimeFormat = c.defaultTimeFormat
+
+		schema := []SchemaField{
+			{
+				FieldName: "ID",
+				FieldType: TypeInt,
+			},
+			{
+				FieldName:   "CREATED",
+				FieldType:   TypeDateTime,
+				FieldFormat: c.fieldFormat,
+			},
+		}
+
+		//the cells are either time.Time or strings in the layout of the field
+		table := Table{
+			Data: [][]interface{}{
+				{2, late},
+				{1, early.Format(c.expectedLayout)},
+			},
+			Schema:     schema,
+			TimeFormat: c.tableFormat,
+		}
+		lateStr := late.Format(c.expectedLayout)
+		earlyStr := early.Format(c.expectedLayout)
+
+		s, err := table.RenderTable("", "", "")
+		Expect(err).To(BeNil(), c.name)
+		Expect(s).To(ContainSubstring("| "+lateStr+" "), c.name)
+
+		s, err = table.RenderTableFoldable("", "", "", 1)
+		Expect(err).To(BeNil(), c.name)
+		Expect(s).To(ContainSubstring(lateStr), c.name)
+
+		s, err = table.RenderTable("", "", "csv")
+		Expect(err).To(BeNil(), c.name)
+		m, err := CSVUnmarshal(s)
+		Expect(err).To(BeNil(), c.name)
+		Expect(m[1][1]).To(Equal(lateStr), c.name)
+
+		s, err = table.RenderTable("", "", "json")
+		Expect(err).To(BeNil(), c.name)
+		Expect(s).To(ContainSubstring(`"CREATED": "`+lateStr+`"`), c.name)
+
+		table.Schema[1].FieldSortKey = 1
+		Expect(table.SortBySchema()).To(BeNil(), c.name)
+		Expect(table.Data[0][0]).To(Equal(1), c.name)
+		Expect(table.Data[0][1]).To(Equal(earlyStr), c.name)
+	}
+}
+
+func TestSortDateTimeWithFallbackLayouts(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(layouts []string) { registeredTimeLayouts = layouts }(registeredTimeLayouts)
+	RegisterTimeLayout(TimeLayoutEpochSeconds)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02T15:04:05Z|2006-01-02 15:04:05",
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "never"},
+		{2, "2013-11-29 13:00:01"},
+		{3, "2013-11-29T12:00:01Z"},
+		{4, "1385730001"},
+		{5, "unknown"},
+		{6, 1385726402},
+	}
+
+	sorter := TableSorter(schema).OrderBy("CREATED")
+	Expect(sorter.SortErr(data)).To(BeNil())
+
+	ids := []interface{}{}
+	for _, row := range data {
+		ids = append(ids, row[0])
+	}
+	Expect(ids).To(Equal([]interface{}{3, 6, 2, 4, 1, 5}))
+
+	Expect(sorter.Warnings()).To(HaveLen(2))
+	Expect(sorter.Warnings()[0]).To(ContainSubstring("never"))
+
+	//time.Time cells are formatted with the first layout
+	table := Table{
+		Data:   [][]interface{}{{1, time.Date(2013, 11, 29, 13, 0, 1, 0, time.UTC)}},
+		Schema: schema,
+	}
+	s, err := table.RenderTable("", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("2013-11-29T13:00:01Z"))
+
+	table.Schema[1].FieldFormat = TimeLayoutEpochMilliseconds
+	s, err = table.RenderTable("", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("1385730001000"))
+
+	//the registered layouts are not tried for the fields with FieldIgnoreRegisteredLayouts
+	table = Table{
+		Data:   [][]interface{}{{1, "1385730001"}},
+		Schema: []SchemaField{schema[0], {FieldName: "CREATED", FieldType: TypeDateTime, FieldIgnoreRegisteredLayouts: true}},
+	}
+	s, err = table.RenderTable("", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,CREATED\n1,1385730001\n"))
+}
+
+func TestSortDateTimeNilAndUnparseableCells(t *testing.T) {
+	RegisterTestingT(t)
+
+	created := time.Date(2013, 11, 29, 13, 0, 0, 0, time.UTC)
+	var missing *time.Time
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+	}
+
+	//valid times first, then the cells that cannot be parsed and the nil cells last, in both directions
+	for _, order := range []struct {
+		field    string
+		expected []int
+	}{
+		{"CREATED", []int{4, 6, 2, 5, 1, 8, 3, 7}},
+		{"-CREATED", []int{2, 6, 4, 5, 1, 8, 3, 7}},
+	} {
+		data := [][]interface{}{
+			{1, "never"},
+			{2, "2014-01-02T00:00:00Z"},
+			{3, nil},
+			{4, time.Date(2012, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{5, "garbage"},
+			{6, &created},
+			{7, missing},
+			{8, "unknown"},
+		}
+		Expect(TableSorter(schema).OrderBy(order.field, "ID").SortErr(data)).To(Succeed(), order.field)
+		Expect(getIDs(data)).To(Equal(order.expected), order.field)
+	}
+}
+
+func TestFieldOutputFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName:         "CREATED",
+			FieldType:         TypeDateTime,
+			FieldFormat:       time.RFC3339 + "|02/01/2006",
+			FieldOutputFormat: "2006-01-02 15:04",
+		},
+	}
+	data := [][]interface{}{
+		{1, "2012-11-29T13:00:00Z"},
+		{2, time.Date(2013, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{3, "30/11/2012"},
+		{4, "yesterday"},
+		{5, nil},
+	}
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,CREATED\n" +
+		"1,2012-11-29 13:00\n" +
+		"2,2013-01-02 03:04\n" +
+		"3,2012-11-30 00:00\n" +
+		"4,yesterday\n" +
+		"5,\n"))
+
+	s, err = table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 1  | 2012-11-29 13:00 |"))
+	Expect(s).To(ContainSubstring("| 4  | yesterday        |"))
+
+	//the json format keeps the layout of the field
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"CREATED": "2012-11-29T13:00:00Z"`))
+	Expect(s).To(ContainSubstring(`"CREATED": "2013-01-02T03:04:05Z"`))
+
+	//without an output layout the cells are written with the first layout of the field
+	table.Schema[1].FieldOutputFormat = ""
+	s, err = table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("\n3,2012-11-30T00:00:00Z\n"))
+}
+
+func TestDateTimeCellsOfMixedTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	created := time.Date(2012, 11, 30, 8, 0, 0, 0, time.UTC)
+	var missing *time.Time
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+	}
+	data := [][]interface{}{
+		{1, "2012-12-01T10:00:00Z"},
+		{2, missing},
+		{3, &created},
+		{4, time.Date(2012, 11, 29, 13, 0, 0, 0, time.UTC)},
+		{5, nil},
+	}
+	table := Table{Data: data, Schema: schema}
+
+	ms := TableSorter(schema).OrderBy("CREATED")
+	ms.Sort(data)
+	Expect(ms.Warnings()).To(BeEmpty())
+	Expect(getIDs(data)[:3]).To(Equal([]int{4, 3, 1}))
+	Expect(getIDs(data)[3:]).To(ConsistOf(2, 5))
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("4,2012-11-29T13:00:00Z\n3,2012-11-30T08:00:00Z\n1,2012-12-01T10:00:00Z\n"))
+	Expect(s).To(ContainSubstring("\n2,\n"))
+
+	s, err = table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 3  | 2012-11-30T08:00:00Z |"))
+	Expect(s).NotTo(ContainSubstring("nil"))
+
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"CREATED": "2012-11-29T13:00:00Z"`))
+	Expect(s).To(ContainSubstring(`"CREATED": "2012-11-30T08:00:00Z"`))
+	Expect(s).NotTo(ContainSubstring("wall"))
+
+	s, err = table.Render(WithFormat("json"), WithOmittedNilKeys())
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("null"))
+
+	s, err = table.Render(WithFormat("yaml"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("created: \"2012-11-30T08:00:00Z\""))
+}
+
+func TestFormatTimeInOtherLocations(t *testing.T) {
+	RegisterTestingT(t)
+
+	paris := time.FixedZone("CET", 3600)
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, paris)
+
+	//a literal Z is only written for the times in UTC
+	Expect(formatTime(tm, DefaultTimeFormat)).To(Equal("2019-12-31T23:00:00Z"))
+	Expect(formatTime(tm, time.RFC3339)).To(Equal("2020-01-01T00:00:00+01:00"))
+	Expect(formatTime(tm, "2006-01-02 15:04 MST")).To(Equal("2020-01-01 00:00 CET"))
+	Expect(formatTime(tm.UTC(), time.RFC3339)).To(Equal("2019-12-31T23:00:00Z"))
+
+	Expect(hasLiteralZ("2006-01-02T15:04:05Z")).To(BeTrue())
+	Expect(hasLiteralZ("2006-01-02T15:04:05Z07:00")).To(BeFalse())
+	Expect(hasLiteralZ("20060102T150405Z0700")).To(BeFalse())
+	Expect(hasLiteralZ("2006-01-02")).To(BeFalse())
+
+	table := Table{
+		Data:   [][]interface{}{{tm}},
+		Schema: []SchemaField{{FieldName: "CREATED", FieldType: TypeDateTime}},
+	}
+	for _, format := range []string{"csv", "json", "yaml"} {
+		s, err := table.Render(WithFormat(format))
+		Expect(err).To(BeNil())
+		Expect(s).To(ContainSubstring("2019-12-31T23:00:00Z"), format)
+	}
+}
@@ -0,0 +1,20 @@
+package tableformatter
+
+import "fmt"
+
+//getTrailer returns the line written after a table with count rows, without the new line
+func getTrailer(count int, tableName string, options *RenderOptions) string {
+	if options.TrailerFunc != nil {
+		return options.TrailerFunc(count, tableName)
+	}
+
+	noun := tableName
+	switch {
+	case count == 1 && options.TableNounSingular != "":
+		noun = options.TableNounSingular
+	case count != 1 && options.TableNounPlural != "":
+		noun = options.TableNounPlural
+	}
+
+	return fmt.Sprintf("Total: %d %s", count, noun)
+}
@@ -0,0 +1,51 @@
+package tableformatter
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderTableTrailer(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+	}
+
+	one := Table{Data: [][]interface{}{{1}}, Schema: schema}
+	two := Table{Data: [][]interface{}{{1}, {2}}, Schema: schema}
+
+	//the table name is used as is by default
+	s, err := one.RenderTable("employees", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("Total: 1 employees\n\n"))
+
+	s, err = one.RenderTable("employees", "", "", WithTableNoun("employee", "employees"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("Total: 1 employee\n\n"))
+
+	s, err = two.RenderTable("employees", "", "", WithTableNoun("employee", "employees"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("Total: 2 employees\n\n"))
+
+	german := func(count int, tableName string) string {
+		if count == 1 {
+			return "Gesamt: 1 Mitarbeiter"
+		}
+		return fmt.Sprintf("Gesamt: %d Mitarbeiter (%s)", count, tableName)
+	}
+	s, err = two.RenderTable("employees", "", "", WithTrailerFunc(german))
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("Gesamt: 2 Mitarbeiter (employees)\n\n"))
+
+	//the incomplete marker follows the trailer
+	two.AddErrorRow("page 2 failed")
+	s, err = two.RenderTable("employees", "", "", WithTrailerFunc(german))
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("Gesamt: 2 Mitarbeiter (employees) (incomplete)\n\n"))
+}
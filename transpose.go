@@ -0,0 +1,38 @@
+package tableformatter
+
+import "strconv"
+
+//TransposeSorted returns the table sorted by the given fields and transposed for display.
+//The rows are sorted on the original values of the fields, before the cells are converted to strings,
+//then the first column of the result holds the field names (KEY) and each row of the table becomes a column
+//of string cells named after its position in the sorted table. The table itself is not changed.
+func (t *Table) TransposeSorted(fieldNames ...string) (Table, error) {
+	t, err := t.getShapedTable(t.ExtraCells)
+	if err != nil {
+		return Table{}, err
+	}
+	source := t.getData(fieldNames...)
+	data := make([][]interface{}, len(source))
+	copy(data, source)
+
+	if len(fieldNames) > 0 {
+		if err := TableSorter(t.Schema).OrderBy(fieldNames...).SortErr(data); err != nil {
+			return Table{}, err
+		}
+	}
+
+	stringsTable := ConvertToStringTable(Table{Data: data, Schema: t.Schema, TimeFormat: t.TimeFormat})
+
+	headerRow := make([]interface{}, len(t.Schema))
+	for i, field := range t.Schema {
+		headerRow[i] = field.FieldName
+	}
+
+	transposed := TransposeTable(Table{Data: append([][]interface{}{headerRow}, stringsTable.Data...), Schema: stringsTable.Schema})
+	transposed.Schema[0].FieldName = "KEY"
+	for i := 1; i < len(transposed.Schema); i++ {
+		transposed.Schema[i].FieldName = strconv.Itoa(i)
+	}
+
+	return transposed, nil
+}
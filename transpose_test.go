@@ -0,0 +1,148 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestConvertToStringTableSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{10, "ten", "2020-11-10"},
+		{9, "nine", "2020-11-09"},
+		{100, "hundred", "2020-11-01"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Schema[1].FieldSize = 12
+
+	stringsTable := ConvertToStringTable(table)
+	Expect(stringsTable.Schema).To(Equal([]SchemaField{
+		{FieldName: "ID", FieldType: TypeString},
+		{FieldName: "LABEL", FieldType: TypeString, FieldSize: 12},
+		{FieldName: "CREATED", FieldType: TypeString},
+	}))
+
+	//the converted cells are sorted as text, as the schema says
+	Expect(TableSorter(stringsTable.Schema).OrderBy("ID").SortErr(stringsTable.Data)).To(BeNil())
+	Expect(stringsTable.Data[0][0]).To(Equal("10"))
+	Expect(stringsTable.Data[1][0]).To(Equal("100"))
+
+	//sorting before converting compares the numbers
+	Expect(TableSorter(table.Schema).OrderBy("ID").SortErr(table.Data)).To(BeNil())
+	Expect(ConvertToStringTable(table).Data[0]).To(Equal([]interface{}{"9", "nine", "2020-11-09"}))
+}
+
+func TestTransposeTableSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{10, "ten", "2020-11-10"},
+		{9, "nine", "2020-11-09"},
+		{100, "hundred", "2020-11-01"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	transposed := TransposeTable(table)
+
+	//the fields of the table have different types
+	Expect(transposed.Schema).To(Equal([]SchemaField{
+		{FieldName: "1", FieldType: TypeInterface},
+		{FieldName: "2", FieldType: TypeInterface},
+		{FieldName: "3", FieldType: TypeInterface},
+	}))
+
+	s, err := transposed.RenderTable("rows", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| 10         | 9          | 100        |"))
+
+	Expect(TransposeTable(ConvertToStringTable(table)).Schema[0].FieldType).To(Equal(TypeString))
+
+	//transposing back restores the data but not the schema
+	Expect(TransposeTable(transposed).Data).To(Equal(table.Data))
+
+	empty := TransposeTable(Table{Schema: table.Schema})
+	Expect(empty.Schema).To(BeEmpty())
+}
+
+func TestTransposeSorted(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:   "CREATED",
+			FieldType:   TypeDateTime,
+			FieldFormat: "2006-01-02",
+		},
+	}
+
+	data := [][]interface{}{
+		{10, "ten", "2020-11-10"},
+		{9, "nine", "2020-11-09"},
+		{100, "hundred", "2020-11-01"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	transposed, err := table.TransposeSorted("ID")
+	Expect(err).To(BeNil())
+	Expect(transposed.Data).To(Equal([][]interface{}{
+		{"ID", "9", "10", "100"},
+		{"LABEL", "nine", "ten", "hundred"},
+		{"CREATED", "2020-11-09", "2020-11-10", "2020-11-01"},
+	}))
+	Expect(transposed.Schema[0].FieldName).To(Equal("KEY"))
+	Expect(transposed.Schema[3].FieldName).To(Equal("3"))
+
+	//the table is not sorted
+	Expect(table.Data[0][0]).To(Equal(10))
+
+	s, err := transposed.RenderTable("fields", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| KEY     | 1          | 2          | 3          |"))
+
+	_, err = table.TransposeSorted("UNKNOWN")
+	Expect(err).NotTo(BeNil())
+}
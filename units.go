@@ -0,0 +1,177 @@
+package tableformatter
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//UnitValue is a quantity parsed from a string cell of a field with FieldNormalizeUnits, such as "1024 MB".
+//Value is in the canonical Unit, the bytes of "1024 MB". It sorts numerically against the other values of the same Unit
+//and is rendered with the largest prefix that keeps it at least 1, such as "1.0 GB", in every format.
+type UnitValue struct {
+	Value float64
+	Unit  string
+}
+
+//unitPrefixes are the prefixes of the units when rendering a UnitValue, the bytes using K and 1024
+var unitPrefixes = []string{"", "k", "M", "G", "T", "P"}
+
+//String returns the value with one decimal and the largest prefix of the unit that keeps it at least 1
+func (v UnitValue) String() string {
+	base := 1000.0
+	prefixes := unitPrefixes
+	if v.Unit == "B" {
+		base = 1024
+		prefixes = append([]string{"", "K"}, unitPrefixes[2:]...)
+	}
+
+	value := v.Value
+	k := 0
+	for k < len(prefixes)-1 && math.Abs(value) >= base {
+		value /= base
+		k++
+	}
+	return fmt.Sprintf("%.1f %s%s", value, prefixes[k], v.Unit)
+}
+
+//unitParser parses the strings matching pattern into a UnitValue
+type unitParser struct {
+	pattern     *regexp.Regexp
+	toCanonical func(match []string) (float64, string)
+}
+
+//getPrefixedParser returns a parser of the numbers followed by the unit with an optional prefix, such as 2 GHz,
+//scaling them by base for each prefix
+func getPrefixedParser(unit string, base float64) unitParser {
+	return unitParser{
+		pattern: regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([kKMGTP]?)(?:i?)` + unit + `\s*$`),
+		toCanonical: func(match []string) (float64, string) {
+			value, _ := strconv.ParseFloat(match[1], 64)
+			for _, prefix := range unitPrefixes {
+				if strings.EqualFold(prefix, match[2]) {
+					break
+				}
+				value *= base
+			}
+			return value, unit
+		},
+	}
+}
+
+//unitParsers are tried in order on the string cells of the fields with FieldNormalizeUnits
+var unitParsers = []unitParser{
+	getPrefixedParser("B", 1024),
+	getPrefixedParser("Hz", 1000),
+	getPrefixedParser("bps", 1000),
+}
+
+//RegisterUnitParser adds a parser of the string cells of the fields with FieldNormalizeUnits. toCanonical receives
+//the submatches of pattern and returns the value in the canonical unit and that unit. The parsers are tried
+//from the last registered to the first and then the built-in ones, which parse sizes such as 1024 MB or 1.5GiB,
+//frequencies such as 2 GHz and bit rates such as 10Gbps.
+func RegisterUnitParser(pattern *regexp.Regexp, toCanonical func(match []string) (float64, string)) {
+	unitParsers = append([]unitParser{{pattern: pattern, toCanonical: toCanonical}}, unitParsers...)
+}
+
+//parseUnitValue returns the UnitValue of the first parser matching s
+func parseUnitValue(s string) (UnitValue, bool) {
+	for _, parser := range unitParsers {
+		if match := parser.pattern.FindStringSubmatch(s); match != nil {
+			value, unit := parser.toCanonical(match)
+			return UnitValue{Value: value, Unit: unit}, true
+		}
+	}
+	return UnitValue{}, false
+}
+
+//applyUnits returns a copy of data with the string cells of the fields with FieldNormalizeUnits that can be parsed
+//replaced by their UnitValue, schema describing the cells of data. data is returned as is if no field has it.
+func applyUnits(data [][]interface{}, schema []SchemaField) [][]interface{} {
+	data, _ = normalizeUnits(data, schema)
+	return data
+}
+
+//normalizeUnits is applyUnits also returning a warning for each string cell that cannot be parsed
+func normalizeUnits(data [][]interface{}, schema []SchemaField) ([][]interface{}, []string) {
+	hasUnits := false
+	for _, field := range schema {
+		hasUnits = hasUnits || field.FieldNormalizeUnits
+	}
+	if !hasUnits {
+		return data, nil
+	}
+
+	var warnings []string
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		if isRawRow(row) {
+			newData[k] = row
+			continue
+		}
+
+		newRow := make([]interface{}, len(row))
+		copy(newRow, row)
+		for i := 0; i < len(row) && i < len(schema); i++ {
+			s, ok := row[i].(string)
+			if !ok || !schema[i].FieldNormalizeUnits {
+				continue
+			}
+			if v, ok := parseUnitValue(s); ok {
+				newRow[i] = v
+			} else {
+				warnings = append(warnings, fmt.Sprintf("row %d: could not parse %q as a value with a unit in field %s", k, s, schema[i].FieldName))
+			}
+		}
+		newData[k] = newRow
+	}
+
+	return newData, warnings
+}
+
+//NormalizeUnits replaces the string cells of the fields with FieldNormalizeUnits in Data by their UnitValue
+//and returns a warning for each cell that cannot be parsed, which is left unchanged.
+//Rendering and sorting parse the cells anyway, normalizing once saves parsing them again.
+func (t *Table) NormalizeUnits() []string {
+	schema := make([]SchemaField, 0, len(t.Schema))
+	for _, field := range t.Schema {
+		if !isComputed(&field) {
+			schema = append(schema, field)
+		}
+	}
+
+	data, warnings := normalizeUnits(t.Data, schema)
+	copy(t.Data, data)
+	return warnings
+}
+
+//unitLess returns a less function that compares the values with a unit numerically, after the other cells are parsed.
+//Values with different units are ordered by unit and the cells that are not values with a unit go after them.
+func unitLess(less lessFunc) lessFunc {
+	parse := func(d interface{}) (UnitValue, bool) {
+		switch v := d.(type) {
+		case UnitValue:
+			return v, true
+		case string:
+			return parseUnitValue(v)
+		}
+		return UnitValue{}, false
+	}
+
+	return func(a, b interface{}, field *SchemaField) bool {
+		va, okA := parse(a)
+		vb, okB := parse(b)
+		switch {
+		case okA && okB:
+			if va.Unit != vb.Unit {
+				return va.Unit < vb.Unit
+			}
+			return va.Value < vb.Value
+		case okA != okB:
+			return okA
+		}
+		return less(a, b, field)
+	}
+}
@@ -0,0 +1,113 @@
+package tableformatter
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseUnitValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	for s, expected := range map[string]string{
+		"1024 MB":  "1.0 GB",
+		"1.5GiB":   "1.5 GB",
+		"512 B":    "512.0 B",
+		"2 GHz":    "2.0 GHz",
+		"2400 MHz": "2.4 GHz",
+		"10Gbps":   "10.0 Gbps",
+		"100 kbps": "100.0 kbps",
+	} {
+		v, ok := parseUnitValue(s)
+		Expect(ok).To(BeTrue(), s)
+		Expect(v.String()).To(Equal(expected), s)
+	}
+
+	v, _ := parseUnitValue("1024 MB")
+	Expect(v).To(Equal(UnitValue{Value: 1024 * 1024 * 1024, Unit: "B"}))
+
+	_, ok := parseUnitValue("lots")
+	Expect(ok).To(BeFalse())
+}
+
+func TestFieldNormalizeUnits(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "NAME",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:           "RAM",
+			FieldType:           TypeString,
+			FieldNormalizeUnits: true,
+		},
+	}
+
+	getData := func() [][]interface{} {
+		return [][]interface{}{
+			{"a", "2 GB"},
+			{"b", "512 MB"},
+			{"c", "unknown"},
+			{"d", "1024 MB"},
+		}
+	}
+
+	data := getData()
+	Expect(TableSorter(schema).OrderBy("RAM").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal([][]interface{}{{"b", "512 MB"}, {"d", "1024 MB"}, {"a", "2 GB"}, {"c", "unknown"}}))
+
+	//the cells that cannot be parsed stay last when descending
+	Expect(TableSorter(schema).OrderBy("-RAM").SortErr(data)).To(Succeed())
+	Expect(data).To(Equal([][]interface{}{{"a", "2 GB"}, {"d", "1024 MB"}, {"b", "512 MB"}, {"c", "unknown"}}))
+
+	table := Table{Data: getData(), Schema: schema}
+	s, err := table.RenderTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| a    | 2.0 GB   |"))
+	Expect(s).To(ContainSubstring("| b    | 512.0 MB |"))
+	Expect(s).To(ContainSubstring("| c    | unknown  |"))
+	Expect(s).To(ContainSubstring("| d    | 1.0 GB   |"))
+
+	s, err = table.RenderTable("servers", "", "csv")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("d,1.0 GB\n"))
+
+	s, err = table.RenderTable("servers", "", "json")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"RAM": "512.0 MB"`))
+
+	Expect(DiagnoseTable(table)).To(Equal([]Problem{
+		{Severity: SeverityWarning, Row: 2, Column: "RAM", Message: `cannot parse "unknown" as a value with a unit`},
+	}))
+
+	warnings := table.NormalizeUnits()
+	Expect(warnings).To(Equal([]string{`row 2: could not parse "unknown" as a value with a unit in field RAM`}))
+	Expect(table.Data[1][1]).To(Equal(UnitValue{Value: 512 * 1024 * 1024, Unit: "B"}))
+	Expect(table.Data[2][1]).To(Equal("unknown"))
+
+	Expect(TableSorter(schema).OrderBy("RAM").SortErr(table.Data)).To(Succeed())
+	Expect(table.Data[0][0]).To(Equal("b"))
+}
+
+func TestRegisterUnitParser(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(parsers []unitParser) { unitParsers = parsers }(unitParsers)
+
+	RegisterUnitParser(regexp.MustCompile(`^([0-9]+) cores?$`), func(match []string) (float64, string) {
+		n, _ := strconv.Atoi(match[1])
+		return float64(n), "cores"
+	})
+
+	v, ok := parseUnitValue("8 cores")
+	Expect(ok).To(BeTrue())
+	Expect(v.String()).To(Equal("8.0 cores"))
+
+	//the built-in parsers are still used
+	_, ok = parseUnitValue("2 GHz")
+	Expect(ok).To(BeTrue())
+}
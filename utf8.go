@@ -0,0 +1,83 @@
+package tableformatter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+//invalidUTF8Replacement replaces each invalid UTF-8 sequence of the string cells
+const invalidUTF8Replacement = "�"
+
+//applySanitizedUTF8 returns a copy of data with the invalid UTF-8 sequences of the string cells and raw rows replaced by U+FFFD
+//if RenderOptions.SanitizeUTF8 is set, so that the widths of the text format are right and every format shows the same content.
+//data is returned as is otherwise or if all the cells are valid.
+func applySanitizedUTF8(data [][]interface{}, options *RenderOptions) [][]interface{} {
+	if !options.SanitizeUTF8 || countInvalidUTF8Cells(data) == 0 {
+		return data
+	}
+
+	newData := make([][]interface{}, len(data))
+	for k, row := range data {
+		newRow := make([]interface{}, len(row))
+		for i, d := range row {
+			switch v := d.(type) {
+			case string:
+				newRow[i] = toValidUTF8(v)
+			case RawRow:
+				newRow[i] = RawRow(toValidUTF8(string(v)))
+			default:
+				newRow[i] = d
+			}
+		}
+		newData[k] = newRow
+	}
+
+	return newData
+}
+
+//toValidUTF8 returns s with each run of invalid UTF-8 bytes replaced by invalidUTF8Replacement,
+//like strings.ToValidUTF8 of Go 1.13
+func toValidUTF8(s string) string {
+	var sb strings.Builder
+	invalid := false
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			if !invalid {
+				sb.WriteString(invalidUTF8Replacement)
+			}
+			invalid = true
+			i++
+			continue
+		}
+		invalid = false
+		sb.WriteString(s[i : i+size])
+		i += size
+	}
+	return sb.String()
+}
+
+//isInvalidUTF8Cell returns true if the cell is a string or a raw row that is not valid UTF-8
+func isInvalidUTF8Cell(d interface{}) bool {
+	switch v := d.(type) {
+	case string:
+		return !utf8.ValidString(v)
+	case RawRow:
+		return !utf8.ValidString(string(v))
+	default:
+		return false
+	}
+}
+
+//countInvalidUTF8Cells returns the number of cells that are not valid UTF-8
+func countInvalidUTF8Cells(data [][]interface{}) int {
+	n := 0
+	for _, row := range data {
+		for _, d := range row {
+			if isInvalidUTF8Cell(d) {
+				n++
+			}
+		}
+	}
+	return n
+}
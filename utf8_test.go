@@ -0,0 +1,95 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSanitizedUTF8(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "DESCRIPTION",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "port \xe9th0"},
+		{2, "uplink"},
+		NewRawRow("caf\xe9"),
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	for _, format := range []string{"", "json", "yaml", "csv", "md", "html"} {
+		s, err := table.Render(WithFormat(format))
+		Expect(err).To(BeNil())
+		Expect(s).To(ContainSubstring("port �th0"), format)
+		Expect(s).NotTo(ContainSubstring("\xe9"), format)
+	}
+
+	//the width is measured on the sanitized cell
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| port �th0   |"))
+	Expect(s).To(ContainSubstring("caf�"))
+
+	//the cells are kept as is when disabled
+	s, err = table.Render(WithFormat("csv"), WithUTF8Sanitization(false))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("port \xe9th0"))
+
+	//the table itself is not changed
+	Expect(table.Data[0][1]).To(Equal("port \xe9th0"))
+
+	values, err := table.DistinctValues("DESCRIPTION", 0)
+	Expect(err).To(BeNil())
+	Expect(values).To(Equal([]string{"port �th0", "uplink"}))
+}
+
+func TestToValidUTF8(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(toValidUTF8("München")).To(Equal("München"))
+	Expect(toValidUTF8("a\xffb")).To(Equal("a\uFFFDb"))
+	Expect(toValidUTF8("a\xff\xfe\xfdb\xc3")).To(Equal("a\uFFFDb\uFFFD"))
+	Expect(toValidUTF8("\uFFFD\xff")).To(Equal("\uFFFD\uFFFD"))
+	Expect(toValidUTF8("")).To(Equal(""))
+}
+
+func TestDiagnoseInvalidUTF8(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "DESCRIPTION",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "port \xe9th0"},
+		{2, "uplink"},
+		NewRawRow("caf\xe9"),
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.Data = append(table.Data, []interface{}{3, strings.Repeat("\xff", 3)})
+
+	Expect(DiagnoseTable(table)).To(Equal([]Problem{
+		{SeverityWarning, -1, "DESCRIPTION", "2 cells are not valid UTF-8, invalid bytes are rendered as U+FFFD"},
+		{SeverityWarning, -1, "", "1 raw rows are not valid UTF-8, invalid bytes are rendered as U+FFFD"},
+	}))
+}
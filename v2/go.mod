@@ -0,0 +1,10 @@
+module github.com/metalsoft-io/tableformatter/v2
+
+go 1.12
+
+require (
+	github.com/metalsoft-io/tableformatter v1.0.0
+	github.com/onsi/gomega v1.10.3
+)
+
+replace github.com/metalsoft-io/tableformatter => ../
@@ -0,0 +1,48 @@
+package tableformatter
+
+import (
+	v1 "github.com/metalsoft-io/tableformatter"
+)
+
+//Decolorize returns s without its ANSI escape sequences
+func Decolorize(s string) string {
+	s, _ = v1.StripColorsHook(s)
+	return s
+}
+
+//VisibleWidth returns the number of columns taken by s on a terminal, ignoring its ANSI escape sequences
+func VisibleWidth(s string) int {
+	return v1.VisibleWidth(s)
+}
+
+//TruncateString returns s cut to at most width columns, ending with ellipsis if it was cut.
+//The ANSI escape sequences of s are kept and do not count.
+func TruncateString(s string, width int, ellipsis string) string {
+	return v1.TruncateToWidth(s, width, ellipsis)
+}
+
+//WrapString returns the lines of s wrapped at width columns, at the spaces when possible
+func WrapString(s string, width int) []string {
+	return v1.WrapToWidth(s, width)
+}
+
+//ParseSchema returns the schema described by a spec such as "ID:int:6, LABEL:string:20, CREATED:datetime",
+//see ParseSchema in version 1
+func ParseSchema(spec string) ([]SchemaField, error) {
+	return v1.ParseSchema(spec)
+}
+
+//InferSchema returns a schema with the field types of the cells of data, the fields being named after fieldNames
+func InferSchema(data [][]interface{}, fieldNames ...string) []SchemaField {
+	return v1.InferSchema(data, fieldNames...)
+}
+
+//ObjectToTable returns a table with a row for a struct, its exported fields being the fields of the table.
+//An error is returned for anything other than a struct.
+func ObjectToTable(obj interface{}) (Table, error) {
+	table, err := v1.ObjectToTable(obj)
+	if err != nil {
+		return Table{}, err
+	}
+	return Table{Data: table.Data, Schema: table.Schema}, nil
+}
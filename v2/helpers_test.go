@@ -0,0 +1,47 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStringHelpers(t *testing.T) {
+	RegisterTestingT(t)
+
+	colored := "\x1b[31mproduction\x1b[0m"
+	Expect(Decolorize(colored)).To(Equal("production"))
+	Expect(VisibleWidth(colored)).To(Equal(10))
+	Expect(Decolorize(TruncateString(colored, 5, "…"))).To(Equal("prod…"))
+	Expect(Decolorize(TruncateString(colored, -1, "…"))).To(Equal(""))
+	Expect(WrapString("production web server", 10)).To(Equal([]string{"production", "web server"}))
+}
+
+func TestObjectToTable(t *testing.T) {
+	RegisterTestingT(t)
+
+	type server struct {
+		ID    int
+		Label string
+	}
+	table, err := ObjectToTable(server{ID: 1, Label: "web-1"})
+	Expect(err).To(BeNil())
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("web-1"))
+
+	_, err = ObjectToTable([]server{})
+	Expect(err).To(MatchError(ContainSubstring("Only struct types are supported")))
+}
+
+func TestParseSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema, err := ParseSchema("ID:int, LABEL:string:20")
+	Expect(err).To(BeNil())
+	Expect(schema).To(HaveLen(2))
+	Expect(schema[1].FieldSize).To(Equal(20))
+
+	_, err = ParseSchema("ID:unknown")
+	Expect(err).NotTo(BeNil())
+}
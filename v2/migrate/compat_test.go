@@ -0,0 +1,221 @@
+package migrate
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/metalsoft-io/tableformatter"
+	tableformatter "github.com/metalsoft-io/tableformatter/v2"
+)
+
+//intentionalDifferences are the ways in which the output of version 2 differs from the output of version 1 on purpose.
+//Each is applied to the output of version 1 in the formats it concerns to get the output of version 2.
+var intentionalDifferences = []struct {
+	description string
+	formats     []string
+	apply       func(s string) string
+}{
+	{
+		description: "the json formats end with a new line like the other formats",
+		formats:     []string{"json", "json-ordered"},
+		apply: func(s string) string {
+			return s + "\n"
+		},
+	},
+	{
+		description: "the Total line has no trailing space when the table has no name",
+		formats:     []string{"", "text", "text-fixed", "html-pre"},
+		apply: func(s string) string {
+			return regexp.MustCompile(`(?m)^(Total: \d+) $`).ReplaceAllString(s, "$1")
+		},
+	},
+}
+
+//getV2Output returns the output of version 2 expected for the output of version 1 in format
+func getV2Output(s string, format string) string {
+	for _, difference := range intentionalDifferences {
+		for _, f := range difference.formats {
+			if strings.EqualFold(f, format) {
+				s = difference.apply(s)
+			}
+		}
+	}
+	return s
+}
+
+//getCompatTable returns the fixture of the compatibility tests of version 1
+func getCompatTable() *v1.Table {
+	schema := []v1.SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: v1.TypeInt,
+			FieldSize: 4,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: v1.TypeString,
+		},
+		{
+			FieldName:      "COST",
+			FieldType:      v1.TypeFloat,
+			FieldPrecision: 2,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: v1.TypeDateTime,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: v1.TypeBool,
+		},
+		{
+			FieldName: "EXTRA",
+			FieldType: v1.TypeInterface,
+		},
+	}
+
+	data := [][]interface{}{
+		{2, "production-infrastructure\nsecond line", 10.5, "2020-11-03T10:00:00Z", true, nil},
+		{1, "\x1b[31mtest\x1b[0m", 1.257, "2020-11-02T09:30:00Z", false, []string{"a", "b"}},
+	}
+
+	return &v1.Table{Data: data, Schema: schema}
+}
+
+//compatRender renders a table through version 1 and through the same function of this package
+type compatRender struct {
+	format string
+	v1     func(t *v1.Table) (string, error)
+	v2     func(t tableformatter.Table) (string, error)
+}
+
+//getCompatRenders returns the renders compared between the versions by name, the tables being rendered with tableName
+//and the transposed tables with rowName
+func getCompatRenders(tableName string, rowName string) map[string]compatRender {
+	renders := map[string]compatRender{
+		"RenderTableAsJSON": {
+			format: "json",
+			v1:     func(t *v1.Table) (string, error) { return t.RenderTableAsJSON() },
+			v2:     RenderTableAsJSON,
+		},
+		"RenderTableAsCSV": {
+			format: "csv",
+			v1:     func(t *v1.Table) (string, error) { return t.RenderTableAsCSV() },
+			v2:     RenderTableAsCSV,
+		},
+		"RenderTableAsYAML": {
+			format: "yaml",
+			v1:     func(t *v1.Table) (string, error) { return t.RenderTableAsYAML() },
+			v2:     RenderTableAsYAML,
+		},
+		"RenderTableFoldable_folded": {
+			v1: func(t *v1.Table) (string, error) {
+				return t.RenderTableFoldable(tableName, "Servers:", "", 10)
+			},
+			v2: func(t tableformatter.Table) (string, error) {
+				return RenderTableFoldable(t, tableName, "Servers:", "", 10)
+			},
+		},
+		"RenderTransposedTable": {
+			v1: func(t *v1.Table) (string, error) {
+				return t.RenderTransposedTable(rowName, "Server:", "")
+			},
+			v2: func(t tableformatter.Table) (string, error) {
+				return RenderTransposedTable(t, rowName, "Server:", "")
+			},
+		},
+		"RenderTransposedTable_json": {
+			format: "json",
+			v1: func(t *v1.Table) (string, error) {
+				return t.RenderTransposedTable(rowName, "Server:", "json")
+			},
+			v2: func(t tableformatter.Table) (string, error) {
+				return RenderTransposedTable(t, rowName, "Server:", "json")
+			},
+		},
+	}
+
+	for _, format := range []string{"", "text", "json", "json-ordered", "csv", "yaml", "yaml-docs", "md", "html", "aligned", "html-pre"} {
+		format := format
+		name := "RenderTable_" + format
+		if format == "" {
+			name = "RenderTable"
+		}
+		renders[name] = compatRender{
+			format: format,
+			v1: func(t *v1.Table) (string, error) {
+				return t.RenderTable(tableName, "Servers:", format)
+			},
+			v2: func(t tableformatter.Table) (string, error) {
+				return RenderTable(t, tableName, "Servers:", format)
+			},
+		}
+		renders["RenderTableFoldable_"+format] = compatRender{
+			format: format,
+			v1: func(t *v1.Table) (string, error) {
+				return t.RenderTableFoldable(tableName, "Servers:", format, 1000)
+			},
+			v2: func(t tableformatter.Table) (string, error) {
+				return RenderTableFoldable(t, tableName, "Servers:", format, 1000)
+			},
+		}
+	}
+	return renders
+}
+
+func TestCompatibility(t *testing.T) {
+	RegisterTestingT(t)
+
+	for name, render := range getCompatRenders("servers", "server") {
+		expected, err := render.v1(getCompatTable())
+		Expect(err).To(BeNil(), name)
+
+		table, _ := FromV1(getCompatTable())
+		s, err := render.v2(table)
+		Expect(err).To(BeNil(), name)
+		Expect(s).To(Equal(getV2Output(expected, render.format)), name)
+	}
+}
+
+func TestCompatibilityWithoutTableName(t *testing.T) {
+	RegisterTestingT(t)
+
+	for name, render := range getCompatRenders("", "") {
+		expected, err := render.v1(getCompatTable())
+		Expect(err).To(BeNil(), name)
+
+		table, _ := FromV1(getCompatTable())
+		s, err := render.v2(table)
+		Expect(err).To(BeNil(), name)
+		Expect(s).To(Equal(getV2Output(expected, render.format)), name)
+	}
+
+	//the differences are those listed
+	s, err := getCompatTable().RenderTable("", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("Total: 2 \n"))
+	s, err = RenderTable(tableformatter.Table{Data: getCompatTable().Data, Schema: getCompatTable().Schema}, "", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("Total: 2\n"))
+}
+
+func TestFromV1AndToV1(t *testing.T) {
+	RegisterTestingT(t)
+
+	v1Table := getCompatTable()
+	v1Table.TimeFormat = "2006-01-02"
+	table, config := FromV1(v1Table)
+	Expect(config.TimeFormat).To(Equal("2006-01-02"))
+	Expect(table.Data).To(Equal(v1Table.Data))
+
+	s, err := config.Render(table, tableformatter.WithFormat("csv"))
+	Expect(err).To(BeNil())
+	expected, err := v1Table.RenderTableAsCSV()
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(expected))
+
+	Expect(ToV1(table).Schema).To(Equal(v1Table.Schema))
+}
@@ -0,0 +1,66 @@
+//Package migrate helps moving from version 1 of tableformatter to version 2 one call site at a time. It converts
+//the tables of version 1 to version 2 and back, and renders version 2 tables with the positional functions of version 1,
+//which version 2 replaces with options. The output is the one of version 2, see the documentation of its package
+//for the differences.
+package migrate
+
+import (
+	v1 "github.com/metalsoft-io/tableformatter"
+	tableformatter "github.com/metalsoft-io/tableformatter/v2"
+)
+
+//FromV1 returns a version 2 table with the rows and the schema of a version 1 table, and the Config rendering it
+//with the TimeFormat of the table or with DefaultTimeFormat. The error rows of the table are not kept.
+func FromV1(t *v1.Table) (tableformatter.Table, tableformatter.Config) {
+	config := tableformatter.Config{TimeFormat: t.TimeFormat}
+	if config.TimeFormat == "" {
+		config.TimeFormat = v1.DefaultTimeFormat
+	}
+	return tableformatter.Table{
+		Data:       t.Data,
+		Schema:     t.Schema,
+		RowSources: t.RowSources,
+		ExtraCells: t.ExtraCells,
+	}, config
+}
+
+//ToV1 returns a version 1 table with the rows and the schema of a version 2 table, for the functions
+//that version 2 does not have yet
+func ToV1(t tableformatter.Table) *v1.Table {
+	return &v1.Table{
+		Data:       t.Data,
+		Schema:     t.Schema,
+		RowSources: t.RowSources,
+		ExtraCells: t.ExtraCells,
+	}
+}
+
+//RenderTable renders the table like Table.RenderTable in version 1
+func RenderTable(t tableformatter.Table, tableName string, topLine string, format string, opts ...tableformatter.RenderOption) (string, error) {
+	return t.Render(append(opts, tableformatter.WithTableName(tableName), tableformatter.WithTopLine(topLine), tableformatter.WithFormat(format))...)
+}
+
+//RenderTableFoldable renders the table like Table.RenderTableFoldable in version 1
+func RenderTableFoldable(t tableformatter.Table, tableName string, topLine string, format string, foldAtLength int, opts ...tableformatter.RenderOption) (string, error) {
+	return RenderTable(t, tableName, topLine, format, append(opts, tableformatter.WithFoldAtLength(foldAtLength))...)
+}
+
+//RenderTransposedTable renders the table like Table.RenderTransposedTable in version 1
+func RenderTransposedTable(t tableformatter.Table, tableName string, topLine string, format string, opts ...tableformatter.RenderOption) (string, error) {
+	return RenderTable(t, tableName, topLine, format, append(opts, tableformatter.WithTransposed())...)
+}
+
+//RenderTableAsJSON renders the table like Table.RenderTableAsJSON in version 1
+func RenderTableAsJSON(t tableformatter.Table) (string, error) {
+	return t.Render(tableformatter.WithFormat("json"))
+}
+
+//RenderTableAsCSV renders the table like Table.RenderTableAsCSV in version 1
+func RenderTableAsCSV(t tableformatter.Table) (string, error) {
+	return t.Render(tableformatter.WithFormat("csv"))
+}
+
+//RenderTableAsYAML renders the table like Table.RenderTableAsYAML in version 1
+func RenderTableAsYAML(t tableformatter.Table) (string, error) {
+	return t.Render(tableformatter.WithFormat("yaml"))
+}
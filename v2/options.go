@@ -0,0 +1,202 @@
+package tableformatter
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/metalsoft-io/tableformatter"
+)
+
+//RenderOption changes how Render and RenderTo render a table
+type RenderOption func(*renderOptions)
+
+//renderOptions are the options of version 1 set by the RenderOptions and what version 2 renders differently
+type renderOptions struct {
+	v1Options []v1.RenderOption
+	//format is the format set with WithFormat, text if empty
+	format string
+	//trailerFunc and the nouns replace the table name in the Total line of the text formats
+	trailerFunc       TrailerFunc
+	tableNounSingular string
+	tableNounPlural   string
+}
+
+//newRenderOptions returns the options set by opts
+func newRenderOptions(opts []RenderOption) *renderOptions {
+	options := &renderOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+//withV1Option returns a RenderOption adding an option of version 1
+func withV1Option(opt v1.RenderOption) RenderOption {
+	return func(o *renderOptions) {
+		o.v1Options = append(o.v1Options, opt)
+	}
+}
+
+//getV1Options returns the options of version 1 that render the table like version 2
+func (o *renderOptions) getV1Options() []v1.RenderOption {
+	return append(o.v1Options, v1.WithTrailerFunc(o.getTrailer))
+}
+
+//getTrailer returns the Total line of the text formats, without the trailing space of version 1 if the table has no name
+func (o *renderOptions) getTrailer(count int, tableName string) string {
+	if o.trailerFunc != nil {
+		return o.trailerFunc(count, tableName)
+	}
+
+	noun := tableName
+	switch {
+	case count == 1 && o.tableNounSingular != "":
+		noun = o.tableNounSingular
+	case count != 1 && o.tableNounPlural != "":
+		noun = o.tableNounPlural
+	}
+	return strings.TrimSuffix(fmt.Sprintf("Total: %d %s", count, noun), " ")
+}
+
+//isJSON returns true if the format is one of the json formats, which version 1 does not end with a new line
+func (o *renderOptions) isJSON() bool {
+	format := strings.ToLower(o.format)
+	return format == "json" || format == "json-ordered"
+}
+
+//getOutput returns the output of version 1 changed as version 2 renders it
+func (o *renderOptions) getOutput(s string) string {
+	if o.isJSON() && s != "" && !strings.HasSuffix(s, "\n") {
+		return s + "\n"
+	}
+	return s
+}
+
+//TrailerFunc returns the line written after a table with count rows named tableName
+type TrailerFunc = v1.TrailerFunc
+
+//ColumnOverflow is what happens to the fields left out by WithMaxColumns
+type ColumnOverflow = v1.ColumnOverflow
+
+const (
+	//ColumnOverflowDrop leaves the fields out and writes a notice with their number under the table
+	ColumnOverflowDrop = v1.ColumnOverflowDrop
+	//ColumnOverflowFold shows the fields in a last OTHER column as name=value pairs
+	ColumnOverflowFold = v1.ColumnOverflowFold
+)
+
+//ErrOutputTruncated is returned with the partial output when the output does not fit in the size set by WithMaxOutputBytes
+type ErrOutputTruncated = v1.ErrOutputTruncated
+
+//ErrInvalidFormat is returned for a format that is not supported
+type ErrInvalidFormat = v1.ErrInvalidFormat
+
+//WithFormat sets the format: text (the default), text-fixed, json, json-ordered, csv, yaml, yaml-docs, md, html,
+//aligned or html-pre. Format names are not case sensitive.
+func WithFormat(format string) RenderOption {
+	return func(o *renderOptions) {
+		o.format = format
+		o.v1Options = append(o.v1Options, v1.WithFormat(format))
+	}
+}
+
+//WithTableName sets the name of the rows written in the Total line of the text formats
+func WithTableName(tableName string) RenderOption {
+	return withV1Option(v1.WithTableName(tableName))
+}
+
+//WithTopLine sets the line written before the table by the text formats
+func WithTopLine(topLine string) RenderOption {
+	return withV1Option(v1.WithTopLine(topLine))
+}
+
+//WithFoldAtLength sets the row length above which the text format folds the table, 100 by default
+func WithFoldAtLength(foldAtLength int) RenderOption {
+	return withV1Option(v1.WithFoldAtLength(foldAtLength))
+}
+
+//WithTransposed renders the text format as a key-value table of the first row
+func WithTransposed() RenderOption {
+	return withV1Option(v1.WithTransposed())
+}
+
+//WithWrapWidth sets the width at which the cells of the text format are wrapped, 0 disables wrapping
+func WithWrapWidth(width int) RenderOption {
+	return withV1Option(v1.WithWrapWidth(width))
+}
+
+//WithMaxOutputBytes limits the size of the output. The rows that do not fit are left out and the partial output
+//is returned together with an ErrOutputTruncated.
+func WithMaxOutputBytes(maxBytes int) RenderOption {
+	return withV1Option(v1.WithMaxOutputBytes(maxBytes))
+}
+
+//WithMaxColumns sets the number of columns shown by the formats read by people and what happens to the others
+func WithMaxColumns(maxColumns int, overflow ColumnOverflow) RenderOption {
+	return withV1Option(v1.WithMaxColumns(maxColumns, overflow))
+}
+
+//WithoutColors removes the ANSI escape sequences of the cells, raw rows and field names
+func WithoutColors() RenderOption {
+	return withV1Option(v1.WithoutColors())
+}
+
+//WithEmptyAsNil renders the empty string cells like nil cells in all the formats
+func WithEmptyAsNil() RenderOption {
+	return withV1Option(v1.WithEmptyAsNil())
+}
+
+//WithOmittedNilKeys makes the json and yaml formats leave out the keys of nil cells instead of writing null
+func WithOmittedNilKeys() RenderOption {
+	return withV1Option(v1.WithOmittedNilKeys())
+}
+
+//WithDurationsInSeconds makes the json and yaml formats write the cells of the duration fields as their number of seconds
+func WithDurationsInSeconds() RenderOption {
+	return withV1Option(v1.WithDurationsInSeconds())
+}
+
+//WithFieldIDKeys makes the json formats use the FieldID of the fields as keys instead of their FieldName
+func WithFieldIDKeys() RenderOption {
+	return withV1Option(v1.WithFieldIDKeys())
+}
+
+//WithStrictKeys makes rendering an error if fields produce the same key in the json, yaml or folded text formats
+func WithStrictKeys() RenderOption {
+	return withV1Option(v1.WithStrictKeys())
+}
+
+//WithMetadata wraps the rows of the json and yaml formats with the name of the table and the number of rows
+func WithMetadata() RenderOption {
+	return withV1Option(v1.WithMetadata())
+}
+
+//WithRowNumbers renders a first column named # with the number of each row, starting at 1
+func WithRowNumbers() RenderOption {
+	return withV1Option(v1.WithRowNumbers())
+}
+
+//WithAggregateRow renders the footer row of the FieldAggregate of the fields as a last row in every format
+func WithAggregateRow() RenderOption {
+	return withV1Option(v1.WithAggregateRow())
+}
+
+//WithWideMode renders the fields with FieldWideOnly in every format
+func WithWideMode() RenderOption {
+	return withV1Option(v1.WithWideMode())
+}
+
+//WithTrailerFunc sets the function returning the Total line of the text formats
+func WithTrailerFunc(trailerFunc TrailerFunc) RenderOption {
+	return func(o *renderOptions) {
+		o.trailerFunc = trailerFunc
+	}
+}
+
+//WithTableNoun replaces the table name in the Total line of the text formats by singular for one row and plural otherwise
+func WithTableNoun(singular string, plural string) RenderOption {
+	return func(o *renderOptions) {
+		o.tableNounSingular = singular
+		o.tableNounPlural = plural
+	}
+}
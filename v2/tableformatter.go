@@ -0,0 +1,267 @@
+//Package tableformatter renders tables as text, json, csv, yaml and the other formats of version 1 of
+//github.com/metalsoft-io/tableformatter, which stays maintained for fixes. Version 2 keeps the formats and the fields
+//of version 1 and changes what could not be changed without breaking the callers that compare its output byte by byte:
+//
+//	the tables are rendered with the options of Render and RenderTo only, the positional RenderTable functions are gone,
+//	rendering and sorting never change the table, Sort returns a sorted copy,
+//	the functions return errors instead of panicking, such as ObjectToTable given something other than a struct,
+//	the settings kept in the package variables of version 1, such as DefaultTimeFormat, are held by a Config,
+//	the helpers are named after what they do to a string, such as Decolorize and TruncateString.
+//
+//The output differs from version 1 on purpose in two ways, checked by the compatibility tests of the migrate package:
+//
+//	the json and json-ordered formats end with a new line like the other formats,
+//	the Total line of the text formats has no trailing space when the table has no name.
+//
+//Version 2 renders with version 1 without reading the package variables of version 1: DefaultTimeFormat and the layouts
+//added with RegisterTimeLayout do not change the output of version 2. The field types and the unit parsers registered
+//with version 1 are used by version 2.
+//
+//The migrate package converts the tables of version 1 and renders version 2 tables with the positional functions
+//of version 1, so that callers can move one call site at a time.
+package tableformatter
+
+import (
+	"io"
+	"strings"
+
+	v1 "github.com/metalsoft-io/tableformatter"
+)
+
+//SchemaField describes a field of a table, see the documentation of version 1 for its settings
+type SchemaField = v1.SchemaField
+
+//Cell is a cell rendered as AsString by the formats read by people and as Value by the others
+type Cell = v1.Cell
+
+//RawRow is a line that the text format prints verbatim between the rows of a table, see NewRawRow
+type RawRow = v1.RawRow
+
+//SeverityCell is a cell of any field annotated with a Severity, see WithSeverity
+type SeverityCell = v1.SeverityCell
+
+//Severity is the state of the value of a SeverityCell
+type Severity = v1.Severity
+
+const (
+	//SeverityOK is the severity of a cell in a good state, printed in green
+	SeverityOK = v1.SeverityOK
+	//SeverityWarning is the severity of a cell that needs attention, printed in yellow
+	SeverityWarning = v1.SeverityWarning
+	//SeverityError is the severity of a cell in a failed state, printed in red
+	SeverityError = v1.SeverityError
+)
+
+//UnitValue is a quantity such as 1024 MB, parsed from the string cells of the fields with FieldNormalizeUnits
+type UnitValue = v1.UnitValue
+
+//Alignment is the horizontal position of the cells of a field in their column, see FieldAlignment
+type Alignment = v1.Alignment
+
+const (
+	//AlignLeft pads the cells on the right, it is the default
+	AlignLeft = v1.AlignLeft
+	//AlignRight pads the cells on the left
+	AlignRight = v1.AlignRight
+	//AlignCenter splits the padding evenly on both sides of the cells
+	AlignCenter = v1.AlignCenter
+)
+
+//Aggregate is the value computed over the cells of a field shown in the footer row, see FieldAggregate
+type Aggregate = v1.Aggregate
+
+const (
+	//AggregateNone leaves the cell of the field in the footer row empty, it is the default
+	AggregateNone = v1.AggregateNone
+	//AggregateSum is the sum of the cells
+	AggregateSum = v1.AggregateSum
+	//AggregateAvg is the average of the cells
+	AggregateAvg = v1.AggregateAvg
+	//AggregateMin is the smallest cell
+	AggregateMin = v1.AggregateMin
+	//AggregateMax is the largest cell
+	AggregateMax = v1.AggregateMax
+	//AggregateCount is the number of cells
+	AggregateCount = v1.AggregateCount
+)
+
+//ExtraCells is what happens to the cells of the rows after the last field of the schema
+type ExtraCells = v1.ExtraCells
+
+const (
+	//ExtraCellsStrict makes rendering a table with extra cells an error
+	ExtraCellsStrict = v1.ExtraCellsStrict
+	//ExtraCellsIgnore leaves the extra cells out
+	ExtraCellsIgnore = v1.ExtraCellsIgnore
+	//ExtraCellsAutoExtend renders the extra cells in fields named EXTRA 1, EXTRA 2 and so on
+	ExtraCellsAutoExtend = v1.ExtraCellsAutoExtend
+)
+
+const (
+	//TypeInt is printed as %d
+	TypeInt = v1.TypeInt
+	//TypeString is printed as %s
+	TypeString = v1.TypeString
+	//TypeFloat is printed with the FieldPrecision of the field
+	TypeFloat = v1.TypeFloat
+	//TypeDateTime is printed with the first layout of the FieldFormat of the field, or of the Config
+	TypeDateTime = v1.TypeDateTime
+	//TypeInterface is printed as %v
+	TypeInterface = v1.TypeInterface
+	//TypeBool is printed as true or false
+	TypeBool = v1.TypeBool
+	//TypeDuration is printed like time.Duration
+	TypeDuration = v1.TypeDuration
+	//TypeVersion holds version strings, printed as they are and sorted like semantic versions
+	TypeVersion = v1.TypeVersion
+	//TypeIP holds IPv4 and IPv6 addresses, printed as they are and sorted numerically
+	TypeIP = v1.TypeIP
+	//TypeBytes holds numbers of bytes, printed as sizes such as 1.5 GiB by the text formats and as numbers by the others
+	TypeBytes = v1.TypeBytes
+)
+
+//defaultTimeFormat is the layout of the date time fields when neither the field nor the Config set one
+const defaultTimeFormat = "2006-01-02T15:04:05Z"
+
+//Table is a table of Data, or of the rows extracted from RowSources, described by Schema.
+//The table is never changed by the functions of the package.
+type Table struct {
+	Data   [][]interface{}
+	Schema []SchemaField
+	//RowSources are the objects the rows are extracted from by the FieldExtract of the fields, instead of Data
+	RowSources []interface{}
+	//ExtraCells is what happens to the cells of the rows of Data after the last field, an error by default
+	ExtraCells ExtraCells
+}
+
+//NewRawRow returns a raw row that can be appended to the data of a table
+func NewRawRow(line string) []interface{} {
+	return v1.NewRawRow(line)
+}
+
+//WithSeverity annotates a cell of any field with a severity. The formats read by people color the cell by its severity
+//and the others write its value.
+func WithSeverity(value interface{}, severity Severity) SeverityCell {
+	return v1.WithSeverity(value, severity)
+}
+
+//Config holds the settings that version 1 kept in package variables. The zero Config holds the defaults,
+//and Table.Render and Sort use it.
+type Config struct {
+	//TimeFormat is the layout of the date time fields that do not set a FieldFormat, 2006-01-02T15:04:05Z if empty
+	TimeFormat string
+	//TimeLayouts are tried in order after the layouts of the field when parsing date time cells. They are not used
+	//for printing.
+	TimeLayouts []string
+}
+
+//Render renders the table in the format and with the settings given by the options, see RenderOption.
+//It is the same as Config{}.Render.
+func (t Table) Render(opts ...RenderOption) (string, error) {
+	return Config{}.Render(t, opts...)
+}
+
+//RenderTo is like Render but writes the output to w, one row at a time in the text and csv formats.
+//It is the same as Config{}.RenderTo.
+func (t Table) RenderTo(w io.Writer, opts ...RenderOption) error {
+	return Config{}.RenderTo(w, t, opts...)
+}
+
+//Render renders the table with the settings of the config, see Table.Render. A panic of the FieldCompute of a field
+//is returned as an error and a panic of its FieldExtract is rendered in the cell, like in version 1.
+func (c Config) Render(t Table, opts ...RenderOption) (string, error) {
+	options := newRenderOptions(opts)
+	s, err := c.getV1Table(t).Render(options.getV1Options()...)
+	if err != nil {
+		return s, err
+	}
+	return options.getOutput(s), nil
+}
+
+//RenderTo renders the table with the settings of the config to w, see Table.RenderTo
+func (c Config) RenderTo(w io.Writer, t Table, opts ...RenderOption) error {
+	options := newRenderOptions(opts)
+	if !options.isJSON() {
+		return c.getV1Table(t).RenderTo(w, options.getV1Options()...)
+	}
+
+	s, err := c.Render(t, opts...)
+	if _, werr := io.WriteString(w, s); werr != nil {
+		return werr
+	}
+	return err
+}
+
+//Sort returns a copy of the table with the rows sorted by the given fields. A field name prefixed with - or
+//suffixed with :desc sorts descending, see MultiSorter.OrderBy in version 1. It is the same as Config{}.Sort.
+func Sort(t Table, fieldNames ...string) (Table, error) {
+	return Config{}.Sort(t, fieldNames...)
+}
+
+//Sort returns a copy of the table with the rows sorted by the given fields, the date time cells being parsed
+//with the layouts of the config
+func (c Config) Sort(t Table, fieldNames ...string) (Table, error) {
+	sorted := t.getCopy()
+	err := v1.TableSorter(c.getSchema(sorted.Schema)).OrderBy(fieldNames...).SortErr(sorted.Data)
+	if err != nil {
+		return Table{}, err
+	}
+	return sorted, nil
+}
+
+//getCopy returns a copy of the table with its own schema and rows
+func (t Table) getCopy() Table {
+	c := t
+	c.Schema = append([]SchemaField(nil), t.Schema...)
+	if t.Data != nil {
+		c.Data = make([][]interface{}, len(t.Data))
+		for k, row := range t.Data {
+			c.Data[k] = append([]interface{}(nil), row...)
+		}
+	}
+	if t.RowSources != nil {
+		c.RowSources = append([]interface{}(nil), t.RowSources...)
+	}
+	return c
+}
+
+//getV1Table returns a copy of the table as a table of version 1 with the settings of the config
+func (c Config) getV1Table(t Table) *v1.Table {
+	t = t.getCopy()
+	return &v1.Table{
+		Data:       t.Data,
+		Schema:     c.getSchema(t.Schema),
+		RowSources: t.RowSources,
+		ExtraCells: t.ExtraCells,
+		TimeFormat: c.getTimeFormat(),
+	}
+}
+
+//getTimeFormat returns the TimeFormat of the config, 2006-01-02T15:04:05Z if empty
+func (c Config) getTimeFormat() string {
+	if c.TimeFormat == "" {
+		return defaultTimeFormat
+	}
+	return c.TimeFormat
+}
+
+//getSchema returns the schema with the layouts of the config added to the date time fields, so that version 1
+//does not use its package variables. The first layout of a field is the one used for printing.
+func (c Config) getSchema(schema []SchemaField) []SchemaField {
+	timeFormat := c.getTimeFormat()
+
+	newSchema := make([]SchemaField, len(schema))
+	copy(newSchema, schema)
+	for i := range newSchema {
+		if newSchema[i].FieldType != TypeDateTime {
+			continue
+		}
+		layouts := []string{newSchema[i].FieldFormat}
+		if newSchema[i].FieldFormat == "" {
+			layouts[0] = timeFormat
+		}
+		newSchema[i].FieldFormat = strings.Join(append(layouts, c.TimeLayouts...), "|")
+		newSchema[i].FieldIgnoreRegisteredLayouts = true
+	}
+	return newSchema
+}
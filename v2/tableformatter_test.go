@@ -0,0 +1,237 @@
+package tableformatter
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/metalsoft-io/tableformatter"
+)
+
+func getTestTable() Table {
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "CREATED",
+			FieldType: TypeDateTime,
+		},
+		{
+			FieldName: "ACTIVE",
+			FieldType: TypeBool,
+		},
+	}
+	data := [][]interface{}{
+		{2, "web-2", "2020-11-03T10:00:00Z", true},
+		{1, "web-1", "2020-11-02T09:30:00Z", false},
+	}
+	return Table{Data: data, Schema: schema}
+}
+
+func TestRender(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, err := getTestTable().Render(WithTableName("servers"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal(
+		"+----+-------+----------------------+--------+\n" +
+			"| ID | LABEL | CREATED              | ACTIVE |\n" +
+			"+----+-------+----------------------+--------+\n" +
+			"| 2  | web-2 | 2020-11-03T10:00:00Z | true   |\n" +
+			"| 1  | web-1 | 2020-11-02T09:30:00Z | false  |\n" +
+			"+----+-------+----------------------+--------+\n" +
+			"Total: 2 servers\n\n"))
+
+	s, err = getTestTable().Render()
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("\nTotal: 2\n\n"))
+
+	s, err = getTestTable().Render(WithTableNoun("server", "servers"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HaveSuffix("\nTotal: 2 servers\n\n"))
+
+	s, err = getTestTable().Render(WithFormat("JSON"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("[\n"))
+	Expect(s).To(HaveSuffix("]\n"))
+
+	_, err = getTestTable().Render(WithFormat("xml"))
+	_, ok := err.(*ErrInvalidFormat)
+	Expect(ok).To(BeTrue())
+}
+
+func TestRenderTo(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, format := range []string{"", "csv", "json", "json-ordered", "yaml"} {
+		expected, err := getTestTable().Render(WithFormat(format))
+		Expect(err).To(BeNil(), format)
+
+		var sb strings.Builder
+		Expect(getTestTable().RenderTo(&sb, WithFormat(format))).To(Succeed(), format)
+		Expect(sb.String()).To(Equal(expected), format)
+	}
+}
+
+func TestRenderAndSortDoNotChangeTheTable(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := getTestTable()
+	table.Schema[1].FieldSize = 1
+	_, err := table.Render(WithRowNumbers(), WithMaxColumns(2, ColumnOverflowFold))
+	Expect(err).To(BeNil())
+	Expect(table).To(Equal(func() Table {
+		t := getTestTable()
+		t.Schema[1].FieldSize = 1
+		return t
+	}()))
+
+	sorted, err := Sort(table, "ID")
+	Expect(err).To(BeNil())
+	Expect(sorted.Data[0][0]).To(Equal(1))
+	Expect(table.Data[0][0]).To(Equal(2))
+
+	_, err = Sort(table, "MISSING")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestPanicsAreReturnedAsErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := getTestTable()
+	table.Schema = append(table.Schema, SchemaField{
+		FieldName:        "COMPUTED",
+		FieldType:        TypeString,
+		FieldComputeFrom: []string{"ID"},
+		FieldCompute: func(values ...interface{}) interface{} {
+			panic("cannot compute")
+		},
+	})
+	_, err := table.Render()
+	Expect(err).To(MatchError("could not compute field COMPUTED: cannot compute"))
+
+	var sb strings.Builder
+	Expect(table.RenderTo(&sb)).To(MatchError("could not compute field COMPUTED: cannot compute"))
+
+	table = getTestTable()
+	table.Data[0][3] = "yes"
+	_, err = Sort(table, "ACTIVE")
+	Expect(err).NotTo(BeNil())
+
+	_, err = ObjectToTable(5)
+	Expect(err).NotTo(BeNil())
+	_, err = ObjectToTable(nil)
+	Expect(err).NotTo(BeNil())
+}
+
+func TestConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := getTestTable()
+	table.Data[0][2] = time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC)
+	table.Data[1][2] = "02/11/2020"
+	config := Config{TimeFormat: "2006-01-02", TimeLayouts: []string{"02/01/2006"}}
+
+	s, err := config.Render(table, WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID,LABEL,CREATED,ACTIVE\n2,web-2,2020-11-03,true\n"))
+
+	sorted, err := config.Sort(table, "CREATED")
+	Expect(err).To(BeNil())
+	Expect(sorted.Data[0][0]).To(Equal(1))
+
+	//the config does not change the package variables of version 1
+	Expect(v1.DefaultTimeFormat).To(Equal(defaultTimeFormat))
+	s, err = table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(HavePrefix("ID,LABEL,CREATED,ACTIVE\n2,web-2,2020-11-03T10:00:00Z,true\n"))
+
+	//without the layouts of the config the cell cannot be parsed and sorts last
+	sorted, err = Sort(table, "CREATED")
+	Expect(err).To(BeNil())
+	Expect(sorted.Data[0][0]).To(Equal(2))
+}
+
+func TestConfigsDoNotAffectEachOther(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := getTestTable()
+	table.Data[0][2] = time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC)
+	table.Data[1][2] = "02/11/2020"
+	table.Data = append(table.Data, []interface{}{3, "web-3", "2020.11.01", true})
+
+	configs := []Config{
+		{TimeFormat: "2006-01-02", TimeLayouts: []string{"02/01/2006"}},
+		{TimeFormat: "02 Jan 2006 15:04", TimeLayouts: []string{"2006.01.02"}},
+	}
+	expectedOutputs := []string{
+		"ID,LABEL,CREATED,ACTIVE\n2,web-2,2020-11-03,true\n1,web-1,2020-11-02,false\n3,web-3,2020.11.01,true\n",
+		"ID,LABEL,CREATED,ACTIVE\n2,web-2,03 Nov 2020 10:00,true\n1,web-1,02/11/2020,false\n3,web-3,01 Nov 2020 00:00,true\n",
+	}
+	//the cells that cannot be parsed with the layouts of the config sort last
+	expectedOrders := [][]interface{}{{1, 2, 3}, {3, 2, 1}}
+
+	var wg sync.WaitGroup
+	outputs := make([][]string, len(configs))
+	orders := make([][][]interface{}, len(configs))
+	for i, config := range configs {
+		outputs[i] = make([]string, 20)
+		orders[i] = make([][]interface{}, 20)
+		for k := 0; k < 20; k++ {
+			wg.Add(1)
+			go func(i int, k int, config Config) {
+				defer wg.Done()
+				outputs[i][k], _ = config.Render(table, WithFormat("csv"))
+				sorted, _ := config.Sort(table, "CREATED")
+				for _, row := range sorted.Data {
+					orders[i][k] = append(orders[i][k], row[0])
+				}
+			}(i, k, config)
+		}
+	}
+	wg.Wait()
+
+	for i := range configs {
+		for k := range outputs[i] {
+			Expect(outputs[i][k]).To(Equal(expectedOutputs[i]), "config %d", i)
+			Expect(orders[i][k]).To(Equal(expectedOrders[i]), "config %d", i)
+		}
+	}
+
+	//each config renders the same after the other one
+	for i, config := range configs {
+		s, err := config.Render(table, WithFormat("csv"))
+		Expect(err).To(BeNil())
+		Expect(s).To(Equal(expectedOutputs[i]))
+	}
+}
+
+func TestConfigIgnoresTheSettingsOfVersion1(t *testing.T) {
+	RegisterTestingT(t)
+
+	defer func(timeFormat string) { v1.DefaultTimeFormat = timeFormat }(v1.DefaultTimeFormat)
+	v1.DefaultTimeFormat = "2006-01-02"
+	//the registered layouts of version 1 cannot be removed, version 2 must ignore them in the other tests too
+	v1.RegisterTimeLayout("02/01/2006")
+
+	table := getTestTable()
+	table.Data[0][2] = time.Date(2020, 11, 3, 10, 0, 0, 0, time.UTC)
+	table.Data[1][2] = "02/11/2020"
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,LABEL,CREATED,ACTIVE\n2,web-2,2020-11-03T10:00:00Z,true\n1,web-1,02/11/2020,false\n"))
+
+	sorted, err := Sort(table, "CREATED")
+	Expect(err).To(BeNil())
+	Expect(sorted.Data[0][0]).To(Equal(2))
+}
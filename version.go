@@ -0,0 +1,118 @@
+package tableformatter
+
+import (
+	"strings"
+)
+
+//versionHandler handles the version strings of TypeVersion fields, rendered as they are in every format
+type versionHandler struct{ stringHandler }
+
+//version is a version string split into its dotted numeric release segments and its pre-release identifiers
+type version struct {
+	release    []string
+	prerelease []string
+}
+
+//parseVersion splits a version such as 1.10.2, v2.0.0-rc.1 or 1.2+build.5 into its release segments, which must be
+//numbers, and its pre-release identifiers. The build metadata after + is ignored.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "v"), "V")
+	if i := strings.Index(s, "+"); i != -1 {
+		s = s[:i]
+	}
+
+	var v version
+	if i := strings.Index(s, "-"); i != -1 {
+		v.prerelease = strings.Split(s[i+1:], ".")
+		for _, id := range v.prerelease {
+			if id == "" {
+				return version{}, false
+			}
+		}
+		s = s[:i]
+	}
+
+	v.release = strings.Split(s, ".")
+	for _, segment := range v.release {
+		if segment == "" || strings.TrimLeft(segment, "0123456789") != "" {
+			return version{}, false
+		}
+	}
+	return v, true
+}
+
+//compareVersions compares two versions like semantic versions: the release segments as numbers, the missing ones
+//being 0, then a version with pre-release identifiers before the same version without, then the identifiers one by one,
+//numbers before other identifiers, numbers as numbers and the others as strings, fewer identifiers first.
+//It returns -1 if a is before b, 1 if it is after and 0 if they are equal.
+func compareVersions(a, b version) int {
+	for i := 0; i < len(a.release) || i < len(b.release); i++ {
+		segmentA, segmentB := "0", "0"
+		if i < len(a.release) {
+			segmentA = a.release[i]
+		}
+		if i < len(b.release) {
+			segmentB = b.release[i]
+		}
+		if c := compareDigits(segmentA, segmentB); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		idA, idB := a.prerelease[i], b.prerelease[i]
+		numericA := strings.TrimLeft(idA, "0123456789") == ""
+		numericB := strings.TrimLeft(idB, "0123456789") == ""
+
+		var c int
+		switch {
+		case numericA && numericB:
+			c = compareDigits(idA, idB)
+		case numericA:
+			c = -1
+		case numericB:
+			c = 1
+		default:
+			c = strings.Compare(idA, idB)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(a.prerelease) < len(b.prerelease):
+		return -1
+	case len(a.prerelease) > len(b.prerelease):
+		return 1
+	}
+	return 0
+}
+
+//Less orders the versions like semantic versions, see compareVersions, and the strings that are not versions
+//after them, compared as strings
+func (versionHandler) Less(a, b interface{}, field *SchemaField) bool {
+	sa, sb := getInterfaceAsString(a), getInterfaceAsString(b)
+	va, okA := parseVersion(sa)
+	vb, okB := parseVersion(sb)
+
+	switch {
+	case okA && okB:
+		if c := compareVersions(va, vb); c != 0 {
+			return c < 0
+		}
+		return sa < sb
+	case okA != okB:
+		return okA
+	}
+	return sa < sb
+}
@@ -0,0 +1,157 @@
+package tableformatter
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTypeVersionSort(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "VERSION",
+			FieldType: TypeVersion,
+		},
+	}
+	data := [][]interface{}{
+		{1, "1.10.0"},
+		{2, "1.9.3"},
+		{3, "v2.0.0"},
+		{4, "2.0.0-rc.1"},
+		{5, "2.0.0-beta.11"},
+		{6, "2.0.0-beta.2"},
+		{7, "2.0.0-alpha"},
+		{8, "1.9"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	Expect(TableSorter(table.Schema).OrderBy("VERSION").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{8, 2, 1, 7, 6, 5, 4, 3}))
+
+	Expect(TableSorter(table.Schema).OrderBy("-VERSION").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{3, 4, 5, 6, 7, 1, 2, 8}))
+}
+
+func TestTypeVersionSortMalformed(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "VERSION",
+			FieldType: TypeVersion,
+		},
+	}
+	data := [][]interface{}{
+		{1, "1.10.0"},
+		{2, "1.9.3"},
+		{3, "v2.0.0"},
+		{4, "2.0.0-rc.1"},
+		{5, "2.0.0-beta.11"},
+		{6, "2.0.0-beta.2"},
+		{7, "2.0.0-alpha"},
+		{8, "1.9"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Data = [][]interface{}{
+		{1, "latest"},
+		{2, "1.10"},
+		{3, nil},
+		{4, "1.x"},
+		{5, "1.2"},
+	}
+	Expect(TableSorter(table.Schema).OrderBy("VERSION").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{5, 2, 4, 1, 3}))
+
+	//the values that are not versions stay last whatever the direction
+	Expect(TableSorter(table.Schema).OrderBy("-VERSION").SortErr(table.Data)).To(BeNil())
+	Expect(getIDs(table.Data)).To(Equal([]int{2, 5, 4, 1, 3}))
+}
+
+func TestCompareVersions(t *testing.T) {
+	RegisterTestingT(t)
+
+	//each version is before the next one
+	versions := []string{
+		"0.9",
+		"1.0.0-0.3.7",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.9",
+		"1.10",
+		"1.10.1",
+		"10.0",
+	}
+	for i := 1; i < len(versions); i++ {
+		a, ok := parseVersion(versions[i-1])
+		Expect(ok).To(BeTrue(), versions[i-1])
+		b, ok := parseVersion(versions[i])
+		Expect(ok).To(BeTrue(), versions[i])
+		Expect(compareVersions(a, b)).To(Equal(-1), versions[i-1]+" < "+versions[i])
+		Expect(compareVersions(b, a)).To(Equal(1), versions[i]+" > "+versions[i-1])
+	}
+
+	a, _ := parseVersion("v1.2")
+	b, _ := parseVersion("1.2.0+build.7")
+	Expect(compareVersions(a, b)).To(Equal(0))
+
+	for _, s := range []string{"", "latest", "1..2", "1.x", "1.0-", "1.0-rc..1"} {
+		_, ok := parseVersion(s)
+		Expect(ok).To(BeFalse(), s)
+	}
+}
+
+func TestTypeVersionRendersOriginalString(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "VERSION",
+			FieldType: TypeVersion,
+		},
+	}
+	data := [][]interface{}{
+		{1, "1.10.0"},
+		{2, "1.9.3"},
+		{3, "v2.0.0"},
+		{4, "2.0.0-rc.1"},
+		{5, "2.0.0-beta.11"},
+		{6, "2.0.0-beta.2"},
+		{7, "2.0.0-alpha"},
+		{8, "1.9"},
+	}
+	table := &Table{Data: data, Schema: schema}
+	table.Data = table.Data[2:4]
+
+	s, err := table.Render(WithFormat("csv"))
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("ID,VERSION\n3,v2.0.0\n4,2.0.0-rc.1\n"))
+
+	s, err = table.Render(WithFormat("json"))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring(`"VERSION": "v2.0.0"`))
+	Expect(s).To(ContainSubstring(`"VERSION": "2.0.0-rc.1"`))
+
+	s, err = table.Render(WithoutColors())
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("| v2.0.0     |"))
+	Expect(s).To(ContainSubstring("| 2.0.0-rc.1 |"))
+}
@@ -0,0 +1,34 @@
+package tableformatter
+
+//exportFormats are the formats that render the wide only fields even without RenderOptions.WideMode
+var exportFormats = map[string]bool{
+	"json":         true,
+	"json-ordered": true,
+	"csv":          true,
+	"yaml":         true,
+	"yaml-docs":    true,
+}
+
+//getProfileSchema returns a copy of the schema in which the fields with FieldWideOnly are hidden,
+//unless RenderOptions.WideMode is set or the format is one of the exportFormats. The schema is returned as is otherwise.
+func getProfileSchema(schema []SchemaField, format string, options *RenderOptions) []SchemaField {
+	if options.WideMode || exportFormats[format] {
+		return schema
+	}
+
+	var newSchema []SchemaField
+	for i, field := range schema {
+		if !field.FieldWideOnly || field.FieldHidden {
+			continue
+		}
+		if newSchema == nil {
+			newSchema = make([]SchemaField, len(schema))
+			copy(newSchema, schema)
+		}
+		newSchema[i].FieldHidden = true
+	}
+	if newSchema == nil {
+		return schema
+	}
+	return newSchema
+}
@@ -0,0 +1,71 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRenderWideOnlyFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+		{
+			FieldName:     "DATACENTER",
+			FieldType:     TypeString,
+			FieldWideOnly: true,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+		},
+	}
+
+	data := [][]interface{}{
+		{1, "web-1", "us-santaclara", "active"},
+		{2, "db-1", "uk-reading", "stopped"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+
+	s, err := table.Render()
+	Expect(err).To(BeNil())
+	Expect(strings.Split(s, "\n")[1]).To(Equal("| ID | LABEL | STATUS  |"))
+
+	s, err = table.Render(WithWideMode())
+	Expect(err).To(BeNil())
+	Expect(strings.Split(s, "\n")[1]).To(Equal("| ID | LABEL | DATACENTER    | STATUS  |"))
+
+	for _, format := range []string{"aligned", "md", "html"} {
+		s, err = table.Render(WithFormat(format))
+		Expect(err).To(BeNil())
+		Expect(s).NotTo(ContainSubstring("DATACENTER"), format)
+		Expect(s).NotTo(ContainSubstring("uk-reading"), format)
+	}
+
+	s, err = table.RenderTransposedTable("servers", "", "")
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("DATACENTER"))
+
+	//the formats exporting the data render every field
+	for _, format := range []string{"json", "json-ordered", "csv", "yaml", "yaml-docs"} {
+		s, err = table.Render(WithFormat(format))
+		Expect(err).To(BeNil())
+		Expect(s).To(ContainSubstring("uk-reading"), format)
+	}
+
+	//FieldHidden wins over WideMode
+	table.Schema[2].FieldHidden = true
+	s, err = table.Render(WithWideMode())
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring("DATACENTER"))
+}
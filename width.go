@@ -0,0 +1,128 @@
+package tableformatter
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+//wideRanges are the ranges of the runes displayed two columns wide: the East Asian wide and fullwidth
+//characters and the emoji presented as pictographs
+var wideRanges = []struct{ first, last rune }{
+	{0x1100, 0x115F},   //Hangul Jamo initial consonants
+	{0x231A, 0x231B},   //watch, hourglass
+	{0x2329, 0x232A},   //angle brackets
+	{0x23E9, 0x23EC},   //media controls
+	{0x23F0, 0x23F0},   //alarm clock
+	{0x23F3, 0x23F3},   //hourglass with flowing sand
+	{0x25FD, 0x25FE},   //medium small squares
+	{0x2614, 0x2615},   //umbrella, hot beverage
+	{0x2648, 0x2653},   //zodiac signs
+	{0x267F, 0x267F},   //wheelchair
+	{0x2693, 0x2693},   //anchor
+	{0x26A1, 0x26A1},   //high voltage
+	{0x26AA, 0x26AB},   //medium circles
+	{0x26BD, 0x26BE},   //soccer ball, baseball
+	{0x26C4, 0x26C5},   //snowman, sun behind cloud
+	{0x26CE, 0x26CE},   //ophiuchus
+	{0x26D4, 0x26D4},   //no entry
+	{0x26EA, 0x26EA},   //church
+	{0x26F2, 0x26F3},   //fountain, golf
+	{0x26F5, 0x26F5},   //sailboat
+	{0x26FA, 0x26FA},   //tent
+	{0x26FD, 0x26FD},   //fuel pump
+	{0x2705, 0x2705},   //check mark button
+	{0x270A, 0x270B},   //raised fists
+	{0x2728, 0x2728},   //sparkles
+	{0x274C, 0x274C},   //cross mark
+	{0x274E, 0x274E},   //cross mark button
+	{0x2753, 0x2755},   //question and exclamation marks
+	{0x2757, 0x2757},   //exclamation mark
+	{0x2795, 0x2797},   //plus, minus, divide
+	{0x27B0, 0x27B0},   //curly loop
+	{0x27BF, 0x27BF},   //double curly loop
+	{0x2B1B, 0x2B1C},   //large squares
+	{0x2B50, 0x2B50},   //star
+	{0x2B55, 0x2B55},   //circle
+	{0x2E80, 0x303E},   //CJK radicals, Kangxi radicals, CJK symbols and punctuation
+	{0x3041, 0x33FF},   //Hiragana, Katakana, Bopomofo, Hangul compatibility Jamo, CJK compatibility
+	{0x3400, 0x4DBF},   //CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   //CJK unified ideographs
+	{0xA000, 0xA4CF},   //Yi
+	{0xA960, 0xA97F},   //Hangul Jamo extended A
+	{0xAC00, 0xD7A3},   //Hangul syllables
+	{0xF900, 0xFAFF},   //CJK compatibility ideographs
+	{0xFE10, 0xFE19},   //vertical forms
+	{0xFE30, 0xFE6F},   //CJK compatibility forms, small form variants
+	{0xFF00, 0xFF60},   //fullwidth forms
+	{0xFFE0, 0xFFE6},   //fullwidth signs
+	{0x16FE0, 0x18CFF}, //Tangut, Khitan
+	{0x1B000, 0x1B2FF}, //Kana supplement and extensions, Nushu
+	{0x1F004, 0x1F004}, //mahjong tile red dragon
+	{0x1F0CF, 0x1F0CF}, //joker
+	{0x1F18E, 0x1F18E}, //AB button
+	{0x1F191, 0x1F19A}, //squared words
+	{0x1F200, 0x1F2FF}, //enclosed ideographic supplement
+	{0x1F300, 0x1F64F}, //miscellaneous symbols and pictographs, emoticons
+	{0x1F680, 0x1F6FF}, //transport and map symbols
+	{0x1F7E0, 0x1F7EB}, //colored circles and squares
+	{0x1F90C, 0x1F9FF}, //supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, //symbols and pictographs extended A
+	{0x20000, 0x2FFFD}, //CJK unified ideographs extensions B to F
+	{0x30000, 0x3FFFD}, //CJK unified ideographs extension G
+}
+
+//runeWidth returns the number of columns a terminal uses to display r: 0 for combining marks and other zero width
+//runes such as the zero width joiner and the variation selectors, 2 for wide runes and 1 for the others
+func runeWidth(r rune) int {
+	//the combining marks and the wide runes start at U+0300
+	if r < 0x300 {
+		return 1
+	}
+	switch {
+	case r == 0x200B || r == 0x200C || r == 0x200D || r == 0x2060 || r == 0xFEFF:
+		return 0
+	case r >= 0xFE00 && r <= 0xFE0F:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return 0
+	}
+
+	if r < wideRanges[0].first {
+		return 1
+	}
+	lo, hi := 0, len(wideRanges)
+	for lo < hi {
+		m := (lo + hi) / 2
+		switch {
+		case r < wideRanges[m].first:
+			hi = m
+		case r > wideRanges[m].last:
+			lo = m + 1
+		default:
+			return 2
+		}
+	}
+	return 1
+}
+
+//stringWidth returns the number of columns a terminal uses to display s, which must not hold ANSI escape sequences
+func stringWidth(s string) int {
+	if isASCII(s) {
+		return len(s)
+	}
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+//isASCII returns true if s holds only ASCII characters, which are all one column wide
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package tableformatter
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestVisibleWidthOfWideRunes(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(VisibleWidth("München")).To(Equal(7))
+	//u followed by a combining diaeresis
+	Expect(VisibleWidth("Mu\u0308nchen")).To(Equal(7))
+	Expect(VisibleWidth("田中")).To(Equal(4))
+	Expect(VisibleWidth("\x1b[31m東京\x1b[0m")).To(Equal(4))
+	Expect(VisibleWidth("ok 🚀")).To(Equal(5))
+	//the emoji of a sequence joined by zero width joiners are counted one by one
+	Expect(VisibleWidth("👨‍👩‍👧")).To(Equal(6))
+	Expect(VisibleWidth("ＡＢ")).To(Equal(4))
+
+	Expect(TruncateToWidth("田中太郎", 5, "…")).To(Equal("田中…"))
+	Expect(TruncateToWidth("田中太郎", 6, "…")).To(Equal("田中…"))
+	Expect(TruncateToWidth("été", 3, "")).To(Equal("été"))
+
+	Expect(WrapToWidth("東京都千代田区", 4)).To(Equal([]string{"東京", "都千", "代田", "区"}))
+	Expect(WrapToWidth("東京都千代田区", 5)).To(Equal([]string{"東京", "都千", "代田", "区"}))
+	Expect(pad("田中", 5)).To(Equal("田中 "))
+}
+
+func TestRenderWideRunes(t *testing.T) {
+	RegisterTestingT(t)
+
+	getTable := func() *Table {
+		schema := []SchemaField{
+			{
+				FieldName: "DATACENTER",
+				FieldType: TypeString,
+			},
+			{
+				FieldName: "OWNER",
+				FieldType: TypeString,
+			},
+			{
+				FieldName:      "STATUS",
+				FieldType:      TypeString,
+				FieldAlignment: AlignRight,
+			},
+		}
+
+		data := [][]interface{}{
+			{"München", "田中", "ok"},
+			{"Mu\u0308nchen-2", "山田太郎", "🚀 launched"},
+			{"東京\nOsaka", "Zoë", "\x1b[32m✅\x1b[0m"},
+			{"São Paulo", "José", "🔥🔥"},
+		}
+
+		return &Table{Data: data, Schema: schema}
+	}
+
+	renders := map[string]func(t *Table) (string, error){
+		"text": func(t *Table) (string, error) {
+			return t.RenderTable("datacenters", "", "")
+		},
+		"aligned": func(t *Table) (string, error) {
+			return t.RenderTable("datacenters", "", "aligned")
+		},
+		"transposed": func(t *Table) (string, error) {
+			t.Data = t.Data[1:2]
+			return t.RenderTransposedTable("datacenter", "", "")
+		},
+	}
+
+	for name, render := range renders {
+		s, err := render(getTable())
+		Expect(err).To(BeNil(), name)
+
+		golden := filepath.Join("testdata", "width", name+".golden")
+		if *updateGolden {
+			Expect(ioutil.WriteFile(golden, []byte(s), 0644)).To(Succeed())
+			continue
+		}
+
+		expected, err := ioutil.ReadFile(golden)
+		Expect(err).To(BeNil(), name)
+		Expect(s).To(Equal(string(expected)), name)
+
+		if name == "aligned" {
+			continue
+		}
+		//every line of the table, before the trailer, has the same width
+		lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+		lines = lines[:len(lines)-1]
+		for _, line := range lines {
+			Expect(VisibleWidth(line)).To(Equal(VisibleWidth(lines[0])), name+": "+line)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tableformatter
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+//AssertWidthStable renders the table with and without colors and fails the test if the layouts differ:
+//each line must have the same width and the same text once the colors are removed.
+//It guards the contract that colors never change the layout of a table.
+func AssertWidthStable(t *testing.T, table Table, opts ...RenderOption) {
+	t.Helper()
+
+	colorTable := table
+	colorTable.Schema = append([]SchemaField{}, table.Schema...)
+	colored, err := colorTable.Render(opts...)
+	if err != nil {
+		t.Fatalf("could not render the table with colors: %s", err)
+	}
+
+	plainTable := table
+	plainTable.Schema = append([]SchemaField{}, table.Schema...)
+	plain, err := plainTable.Render(append(opts, WithoutColors())...)
+	if err != nil {
+		t.Fatalf("could not render the table without colors: %s", err)
+	}
+
+	coloredLines := strings.Split(colored, "\n")
+	plainLines := strings.Split(plain, "\n")
+	if len(coloredLines) != len(plainLines) {
+		t.Fatalf("the table has %d lines with colors and %d without", len(coloredLines), len(plainLines))
+	}
+	for i := range coloredLines {
+		if VisibleWidth(coloredLines[i]) != VisibleWidth(plainLines[i]) {
+			t.Errorf("line %d is %d wide with colors and %d without:\n%q\n%q", i, VisibleWidth(coloredLines[i]), VisibleWidth(plainLines[i]), coloredLines[i], plainLines[i])
+			continue
+		}
+		if decolorize(coloredLines[i]) != plainLines[i] {
+			t.Errorf("line %d differs with colors:\n%q\n%q", i, decolorize(coloredLines[i]), plainLines[i])
+		}
+	}
+}
+
+func TestWidthStable(t *testing.T) {
+	schema := []SchemaField{
+		{
+			FieldName: "\x1b[1mNAME\x1b[0m",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+			FieldSize: 6,
+		},
+		{
+			FieldName:      "LINK",
+			FieldType:      TypeString,
+			FieldAlignment: AlignRight,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", "\x1b[32mactive\x1b[0m", "\x1b]8;;https://example.com/1\x1b\\details\x1b]8;;\x1b\\"},
+		{"\x1b[38;5;208mdb-1\x1b[39m", "\x1b(B\x1b[mdown", "\x1b]8;;https://example.com/2\x07logs\x1b]8;;\x07"},
+		NewRawRow("\x1b[2m--- raw ---\x1b[0m"),
+		{"multi\n\x1b[31mline\x1b[0m", "\x1b[1;31mfailed\x1b[0m\x1b[K", "none"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	AssertWidthStable(t, table)
+	AssertWidthStable(t, table, WithFormat("aligned"))
+	AssertWidthStable(t, table, WithNormalizedTrailingSpace())
+	AssertWidthStable(t, table, WithFoldAtLength(10))
+	AssertWidthStable(t, table, WithNarrowLayout())
+	AssertWidthStable(t, table, WithTransposed())
+
+	table.AddErrorRow("\x1b[33mpage 2\x1b[0m failed")
+	AssertWidthStable(t, table)
+}
+
+func TestWithoutColors(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "\x1b[1mNAME\x1b[0m",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "STATUS",
+			FieldType: TypeString,
+			FieldSize: 6,
+		},
+		{
+			FieldName:      "LINK",
+			FieldType:      TypeString,
+			FieldAlignment: AlignRight,
+		},
+	}
+
+	data := [][]interface{}{
+		{"web-1", "\x1b[32mactive\x1b[0m", "\x1b]8;;https://example.com/1\x1b\\details\x1b]8;;\x1b\\"},
+		{"\x1b[38;5;208mdb-1\x1b[39m", "\x1b(B\x1b[mdown", "\x1b]8;;https://example.com/2\x07logs\x1b]8;;\x07"},
+		NewRawRow("\x1b[2m--- raw ---\x1b[0m"),
+		{"multi\n\x1b[31mline\x1b[0m", "\x1b[1;31mfailed\x1b[0m\x1b[K", "none"},
+	}
+
+	table := Table{Data: data, Schema: schema}
+	table.AddErrorRow("page 2 failed")
+	for _, format := range []string{"", "aligned", "html-pre", "md"} {
+		s, err := table.Render(WithFormat(format), WithoutColors())
+		Expect(err).To(BeNil())
+		Expect(s).NotTo(ContainSubstring("\x1b"), format)
+	}
+
+	s, err := table.Render(WithFormat("json"), WithoutColors())
+	Expect(err).To(BeNil())
+	Expect(s).NotTo(ContainSubstring(`\u001b`))
+	Expect(s).To(ContainSubstring(`"active"`))
+}
@@ -0,0 +1,105 @@
+package tableformatter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+//invalidKeyCharacters matches the characters not allowed in the generated yaml keys
+var invalidKeyCharacters = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+//repeatedUnderscores matches the runs of underscores collapsed in the generated yaml keys
+var repeatedUnderscores = regexp.MustCompile(`__+`)
+
+//getYAMLKey returns the lowerCamel key of a field name, without its colors, with only the characters A-Z, a-z, 0-9 and _.
+//Names without any of these characters are keyed "field".
+func getYAMLKey(fieldName string) string {
+	key := strcase.ToLowerCamel(strings.ToLower(decolorize(fieldName)))
+	key = invalidKeyCharacters.ReplaceAllString(key, "")
+	key = repeatedUnderscores.ReplaceAllString(key, "_")
+	if key == "" {
+		return "field"
+	}
+	return key
+}
+
+//getYAMLKeys returns the yaml keys of the fields of the schema, in order.
+//Fields whose names produce the same key are suffixed with 2, 3 and so on in the order of the schema.
+func getYAMLKeys(schema []SchemaField) []string {
+	keys := make([]string, len(schema))
+	used := map[string]bool{}
+	for i, field := range schema {
+		key := getYAMLKey(field.FieldName)
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s%d", getYAMLKey(field.FieldName), n)
+		}
+		used[key] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+//getJSONKeyFold returns the form of a field name compared to tell whether two json keys collide.
+//Names that differ only by case collide as many decoders match keys case insensitively.
+func getJSONKeyFold(fieldName string) string {
+	return strings.ToLower(fieldName)
+}
+
+//getJSONKeys returns the json keys of the fields of the schema, in order: their names, with the names that collide
+//with the name of a previous field suffixed with 2, 3 and so on in the order of the schema, see getJSONKeyFold
+func getJSONKeys(schema []SchemaField) []string {
+	keys := make([]string, len(schema))
+	used := map[string]bool{}
+	for i, field := range schema {
+		key := field.FieldName
+		for n := 2; used[getJSONKeyFold(key)]; n++ {
+			key = fmt.Sprintf("%s%d", field.FieldName, n)
+		}
+		used[getJSONKeyFold(key)] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+//ErrKeyCollision is returned with RenderOptions.StrictKeys when several fields produce the same key
+type ErrKeyCollision struct {
+	Key    string
+	Fields []string
+}
+
+func (e *ErrKeyCollision) Error() string {
+	return fmt.Sprintf("fields %s produce the same key %s", strings.Join(e.Fields, ", "), e.Key)
+}
+
+//getKeyCollision returns an ErrKeyCollision for the first key produced by several fields of the schema, nil if there is none.
+//keyOf returns the key of a field name.
+func getKeyCollision(schema []SchemaField, keyOf func(fieldName string) string) error {
+	var keys []string
+	fields := map[string][]string{}
+	for _, field := range schema {
+		key := keyOf(field.FieldName)
+		if _, ok := fields[key]; !ok {
+			keys = append(keys, key)
+		}
+		fields[key] = append(fields[key], field.FieldName)
+	}
+
+	for _, key := range keys {
+		if len(fields[key]) > 1 {
+			return &ErrKeyCollision{Key: key, Fields: fields[key]}
+		}
+	}
+	return nil
+}
+
+//YAMLKeys returns the field names of the table keyed by the keys used for them by the yaml formats
+func (t *Table) YAMLKeys() map[string]string {
+	names := map[string]string{}
+	for i, key := range getYAMLKeys(t.Schema) {
+		names[key] = t.Schema[i].FieldName
+	}
+	return names
+}
@@ -0,0 +1,149 @@
+package tableformatter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGetYAMLKey(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(getYAMLKey("DESC.")).To(Equal("desc"))
+	Expect(getYAMLKey("INST.")).To(Equal("inst"))
+	Expect(getYAMLKey("VERY LONG FIELD NAME")).To(Equal("veryLongFieldName"))
+	Expect(getYAMLKey("IP addr.")).To(Equal("ipAddr"))
+	Expect(getYAMLKey("ÜBER")).To(Equal("ber"))
+	Expect(getYAMLKey("%")).To(Equal("field"))
+}
+
+func TestRenderTableYAMLKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "DESC.",
+			FieldType: TypeString,
+		},
+		{
+			FieldName: "INST.",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "SERVER_ID",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "Server ID",
+			FieldType: TypeInt,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{1, "allow ssh", 2, 10, 20}}, Schema: schema}
+
+	Expect(table.YAMLKeys()).To(Equal(map[string]string{
+		"id":        "ID",
+		"desc":      "DESC.",
+		"inst":      "INST.",
+		"serverId":  "SERVER_ID",
+		"serverId2": "Server ID",
+	}))
+
+	s, err := table.RenderTable("rules", "", "yaml")
+	Expect(err).To(BeNil())
+
+	var rows []map[string]interface{}
+	Expect(yaml.Unmarshal([]byte(s), &rows)).To(BeNil())
+	Expect(rows[0]).To(Equal(map[string]interface{}{
+		"id":        1,
+		"desc":      "allow ssh",
+		"inst":      2,
+		"serverId":  10,
+		"serverId2": 20,
+	}))
+}
+
+func TestRenderTableCollidingKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "Field1",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "FIELD1",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{1, 2, "web-1"}}, Schema: schema}
+
+	s, err := table.RenderTable("rows", "", "yaml")
+	Expect(err).To(BeNil())
+	Expect(s).To(Equal("- field1: 1\n  field12: 2\n  label: web-1\n"))
+
+	s, err = table.RenderTable("rows", "", "json")
+	Expect(err).To(BeNil())
+	var rows []map[string]interface{}
+	Expect(json.Unmarshal([]byte(s), &rows)).To(BeNil())
+	Expect(rows[0]).To(Equal(map[string]interface{}{"Field1": 1.0, "FIELD12": 2.0, "LABEL": "web-1"}))
+
+	s, err = table.RenderTable("rows", "", "json-ordered")
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("\"Field1\": 1,\n\t\t\"FIELD12\": 2,\n\t\t\"LABEL\": \"web-1\""))
+
+	s, err = table.RenderTable("rows", "", "", WithFoldAtLength(5))
+	Expect(err).To(BeNil())
+	Expect(s).To(ContainSubstring("field12: 2"))
+}
+
+func TestRenderTableStrictKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	schema := []SchemaField{
+		{
+			FieldName: "Field1",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "FIELD1",
+			FieldType: TypeInt,
+		},
+		{
+			FieldName: "LABEL",
+			FieldType: TypeString,
+		},
+	}
+
+	table := Table{Data: [][]interface{}{{1, 2, "web-1"}}, Schema: schema}
+
+	for _, format := range []string{"json", "json-ordered", "yaml", "yaml-docs"} {
+		_, err := table.RenderTable("rows", "", format, WithStrictKeys())
+		Expect(err).To(BeAssignableToTypeOf(&ErrKeyCollision{}), format)
+		Expect(err.(*ErrKeyCollision).Fields).To(Equal([]string{"Field1", "FIELD1"}), format)
+	}
+	Expect(table.RenderTo(&strings.Builder{}, WithStrictKeys(), WithFoldAtLength(5))).To(MatchError("fields Field1, FIELD1 produce the same key field1"))
+
+	//the text and csv formats do not use keys
+	for _, format := range []string{"", "csv"} {
+		_, err := table.RenderTable("rows", "", format, WithStrictKeys())
+		Expect(err).To(BeNil(), format)
+	}
+
+	//with FieldIDKeys the json formats are keyed by FieldID
+	table.Schema[1].FieldID = "OTHER"
+	_, err := table.RenderTable("rows", "", "json", WithStrictKeys(), WithFieldIDKeys())
+	Expect(err).To(BeNil())
+}